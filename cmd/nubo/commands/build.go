@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"github.com/nubolang/nubo/internal/bundle"
+	"github.com/spf13/cobra"
+)
+
+// buildCmd represents the build command
+var buildCmd = &cobra.Command{
+	Use:   "build <file|folder>",
+	Short: "Bundle a Nubo app into a single executable",
+	Long: "Build copies the nubo runtime and appends the app: prepared sources, static assets, " +
+		"built plugins, vendored packages and the current config. The result runs without nubo installed.",
+	Run: execBuild,
+}
+
+func init() {
+	buildCmd.Flags().StringP("output", "o", "app", "Path of the executable to create")
+	buildCmd.Flags().String("entry", "main.nubo", "File to run when building a folder")
+	buildCmd.Flags().Bool("serve", false, "Serve the folder over HTTP instead of running an entry file")
+	buildCmd.Flags().String("addr", "", "Address to listen on when serving (defaults to the config)")
+	rootCmd.AddCommand(buildCmd)
+}
+
+func execBuild(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	entry, _ := cmd.Flags().GetString("entry")
+	serve, _ := cmd.Flags().GetBool("serve")
+	addr, _ := cmd.Flags().GetString("addr")
+
+	err := bundle.Write(bundle.Options{
+		Source: args[0],
+		Output: output,
+		Entry:  entry,
+		Serve:  serve,
+		Addr:   addr,
+	})
+	if err != nil {
+		cmd.PrintErrln(err)
+		return
+	}
+
+	cmd.Printf("Built %s\n", output)
+}
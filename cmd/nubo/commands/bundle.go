@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nubolang/nubo/cmd/nubo/logger"
+	"github.com/nubolang/nubo/config"
+	"github.com/nubolang/nubo/events"
+	"github.com/nubolang/nubo/internal/bundle"
+	"github.com/nubolang/nubo/internal/runner"
+	"github.com/nubolang/nubo/internal/runtime"
+	"github.com/nubolang/nubo/packer"
+	"github.com/nubolang/nubo/plug"
+	"github.com/nubolang/nubo/server"
+	"go.uber.org/zap"
+)
+
+// ExecuteBundle boots the app bundled into the running executable by
+// nubo build, bypassing the CLI.
+func ExecuteBundle(b *bundle.Bundle) error {
+	defer plug.GetManager().StopAll()
+
+	if data, err := b.ReadFile(bundle.ConfigName); err == nil {
+		if err := config.LoadData(data); err != nil {
+			return err
+		}
+	}
+	config.Verify()
+	zap.ReplaceGlobals(logger.Create(""))
+
+	dir, err := b.Extract()
+	if err != nil {
+		return err
+	}
+
+	app := filepath.Join(dir, bundle.AppDir)
+	packer.UseVendor(app, filepath.Join(dir, bundle.PackagesDir))
+	plug.GetManager().UsePrebuilt()
+
+	if b.Manifest.Serve {
+		addr := b.Manifest.Addr
+		if addr == "" {
+			addr = config.Current.Runtime.Server.Address
+		}
+
+		srv, err := server.New(app)
+		if err != nil {
+			return err
+		}
+		return srv.Serve(addr)
+	}
+
+	var eventProvider events.Provider
+	if config.Current.Runtime.Events.Enabled {
+		eventProvider = events.NewDefaultProvider()
+	}
+
+	ret, err := runner.Execute(filepath.Join(app, filepath.FromSlash(b.Manifest.Entry)), runtime.New(eventProvider, runtime.DefaultOptions()))
+	if err != nil {
+		return err
+	}
+	if ret != nil {
+		fmt.Fprintln(os.Stdout, ret.String())
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nubolang/nubo/internal/checker"
+	"github.com/spf13/cobra"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check <file|directory>",
+	Short: "Report type errors in Nubo files without running them",
+	Run:   execCheck,
+}
+
+func init() {
+	// Add the check command to the root command
+	rootCmd.AddCommand(checkCmd)
+}
+
+func execCheck(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cmd.Help()
+		return
+	}
+
+	files, err := getFilesFromArgs(args)
+	if err != nil {
+		cmd.PrintErrln(err)
+		os.Exit(1)
+	}
+
+	var (
+		ch       = checker.New()
+		problems int
+	)
+
+	for _, path := range files {
+		if filepath.Ext(path) != ".nubo" {
+			continue
+		}
+		if err := ch.CheckFile(path); err != nil {
+			cmd.PrintErrln(err)
+			problems++
+		}
+	}
+
+	diagnostics := ch.Diagnostics()
+	for _, diagnostic := range diagnostics {
+		cmd.PrintErrln(diagnostic)
+	}
+
+	problems += len(diagnostics)
+
+	if problems > 0 {
+		cmd.PrintErrln(fmt.Sprintf("Found %d problem(s)", problems))
+		os.Exit(1)
+	}
+	fmt.Println("No problems found")
+}
@@ -27,7 +27,7 @@ func execRun(cmd *cobra.Command, args []string) {
 		eventProvider = events.NewDefaultProvider()
 	}
 
-	ex := runtime.New(eventProvider)
+	ex := runtime.New(eventProvider, runtime.DefaultOptions())
 	ret, err := runner.Execute(filePath, ex)
 	if err != nil {
 		cmd.PrintErrln(err)
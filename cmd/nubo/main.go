@@ -4,13 +4,26 @@ import (
 	"log"
 
 	"github.com/nubolang/nubo/cmd/nubo/commands"
+	"github.com/nubolang/nubo/internal/bundle"
 	"go.uber.org/zap"
 )
 
 func main() {
 	defer zap.L().Sync()
 
-	err := commands.Execute()
+	// An executable produced by nubo build runs its app instead of the CLI.
+	b, err := bundle.Open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if b != nil {
+		if err := commands.ExecuteBundle(b); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	err = commands.Execute()
 	if err != nil {
 		log.Fatal(err)
 	}
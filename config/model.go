@@ -185,13 +185,6 @@ func Load() error {
 
 	Base = base
 
-	pwd, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-
-	Nubo = filepath.Join(pwd, ".nubo")
-
 	config := filepath.Join(base, "config.yaml")
 	if _, err := os.Stat(config); err != nil {
 		if err := createConfigFile(config); err != nil {
@@ -210,6 +203,19 @@ func Load() error {
 		return err
 	}
 
+	return LoadData(data)
+}
+
+// LoadData sets Current from the contents of a config file. Bundled
+// executables use it to apply the config they were built with.
+func LoadData(data []byte) error {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	Nubo = filepath.Join(pwd, ".nubo")
+
 	var cfg Config
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return err
@@ -1,11 +1,18 @@
 package events
 
-import "github.com/nubolang/nubo/language"
+import (
+	"context"
+
+	"github.com/nubolang/nubo/language"
+)
 
 // Event represents a pubsub event.
 type Event struct {
 	// ID is the unique identifier of the event.
 	ID string
+	// Name is the name the event was declared with. Events of different
+	// files may share a name; plugins publish to every one of them.
+	Name string
 	// Args is the list of arguments of the event.
 	Args []language.FnArg
 }
@@ -33,3 +40,53 @@ type Provider interface {
 	// Close closes the provider.
 	Close() error
 }
+
+type providerKey struct{}
+
+// WithProvider returns a copy of ctx that carries p, so native code called
+// from the interpreter can reach the runtime's provider.
+func WithProvider(ctx context.Context, p Provider) context.Context {
+	return context.WithValue(ctx, providerKey{}, p)
+}
+
+// ProviderFrom returns the provider stored in ctx by WithProvider.
+func ProviderFrom(ctx context.Context) (Provider, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	p, ok := ctx.Value(providerKey{}).(Provider)
+	return p, ok && p != nil
+}
+
+type detachKey struct{}
+
+// WithDetach returns a copy of ctx that carries register, which the
+// interpreter uses to collect cleanup functions run when it detaches.
+func WithDetach(ctx context.Context, register func(UnsubscribeFunc)) context.Context {
+	return context.WithValue(ctx, detachKey{}, register)
+}
+
+// OnDetach registers fn to run when the interpreter ctx belongs to detaches,
+// so native code can release resources such as file watchers. It reports
+// false if ctx does not belong to an interpreter.
+func OnDetach(ctx context.Context, fn UnsubscribeFunc) bool {
+	if ctx == nil {
+		return false
+	}
+	register, ok := ctx.Value(detachKey{}).(func(UnsubscribeFunc))
+	if !ok || register == nil {
+		return false
+	}
+	register(fn)
+	return true
+}
+
+// HasDetach reports whether ctx belongs to an interpreter, so functions
+// registered with OnDetach will run.
+func HasDetach(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	register, ok := ctx.Value(detachKey{}).(func(UnsubscribeFunc))
+	return ok && register != nil
+}
@@ -0,0 +1,62 @@
+package nubo
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/nubolang/nubo/events"
+	"github.com/nubolang/nubo/server"
+	"github.com/nubolang/nubo/server/modules"
+)
+
+// HandlerOptions configures a Handler.
+type HandlerOptions struct {
+	// Prefix is the path the app is mounted at, e.g. "/app". It is stripped
+	// before routing.
+	Prefix string
+	// Options restricts the pages; nil uses DefaultOptions.
+	Options *Options
+	// Events returns the event provider of a request; nil uses the config.
+	Events func() events.Provider
+}
+
+// PageResult holds what a page returned, see CaptureResult.
+type PageResult = server.Result
+
+// Handler returns an http.Handler that serves the file based routes in fsys
+// like `nubo serve`, so an app (e.g. an embed.FS) can be mounted next to
+// other Go handlers.
+func Handler(fsys fs.FS, opts HandlerOptions) (http.Handler, error) {
+	runOpts := DefaultOptions()
+	if opts.Options != nil {
+		runOpts = *opts.Options
+	}
+
+	srv, err := server.NewFS(fsys, server.Options{
+		Runtime: runOpts,
+		Events:  opts.Events,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(opts.Prefix, "/")
+	if prefix == "" {
+		return srv, nil
+	}
+	return http.StripPrefix(prefix, srv), nil
+}
+
+// WithValue returns a shallow copy of r that makes value available to pages
+// as request.value(key). Go values are bound like with native.Bind.
+func WithValue(r *http.Request, key string, value any) *http.Request {
+	return r.WithContext(modules.WithValues(r.Context(), map[string]any{key: value}))
+}
+
+// CaptureResult returns a shallow copy of r and a PageResult that holds the
+// return value or error of the page once the Handler served r.
+func CaptureResult(r *http.Request) (*http.Request, *PageResult) {
+	res := new(PageResult)
+	return r.WithContext(server.WithResult(r.Context(), res)), res
+}
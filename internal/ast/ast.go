@@ -64,6 +64,8 @@ func (a *Ast) handleToken(tokens []*lexer.Token, inx *int) (*astnode.Node, error
 		return parsers.EventParser(a.ctx, tokens, inx)
 	case lexer.TokenStruct:
 		return parsers.StructParser(a.ctx, tokens, inx)
+	case lexer.TokenEnum:
+		return parsers.EnumParser(a.ctx, tokens, inx)
 	case lexer.TokenFn:
 		return parsers.FnParser(a.ctx, a, tokens, inx, New(a.ctx, a.nodeTimeout), false)
 	case lexer.TokenIdentifier:
@@ -78,6 +80,8 @@ func (a *Ast) handleToken(tokens []*lexer.Token, inx *int) (*astnode.Node, error
 		return parsers.PubParser(a.ctx, a, tokens, inx)
 	case lexer.TokenWhile:
 		return parsers.WhileParser(a.ctx, a, tokens, inx)
+	case lexer.TokenMatch:
+		return parsers.MatchParser(a.ctx, a, tokens, inx)
 	case lexer.TokenIf:
 		return parsers.IfParser(a.ctx, a, tokens, inx)
 	case lexer.TokenFor:
@@ -27,6 +27,9 @@ type Node struct {
 	Args        []*Node `yaml:"args,omitempty"`
 	Body        []*Node `yaml:"body,omitempty"`
 	ArrayAccess []*Node `yaml:"array_access,omitempty"`
+	// TypeParams holds the type parameters of a generic declaration or the
+	// type arguments of a generic type.
+	TypeParams []*Node `yaml:"type_params,omitempty"`
 
 	Attrs map[string]any `yaml:"attrs,omitempty"`
 	Flags AppendFlags    `yaml:"flags,omitempty"`
@@ -45,4 +45,9 @@ const (
 	NodeTypeDefer
 	NodeTypeSpawn
 	NodeTypeBlock
+	NodeTypeEnum
+	NodeTypeEnumVariant
+	NodeTypeMatch
+	NodeTypeMatchArm
+	NodeTypePattern
 )
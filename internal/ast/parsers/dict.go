@@ -60,51 +60,26 @@ loop:
 				break loop
 			}
 
-			if token.Type != lexer.TokenString && token.Type != lexer.TokenIdentifier && token.Type != lexer.TokenNumber {
-				return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("dict key must be string, identifier or number, got '%s'", token.Value), node.Debug)
-			}
-
-			key, err := singleValueParser(ctx, sn, tokens, inx, token)
-			if err != nil {
-				return nil, err
-			}
-
-			if key.Kind == "IDENTIFIER" {
-				key.Kind = "STRING"
-				key.IsReference = false
-			}
-
-			dataset := &astnode.Node{
-				Type: astnode.NodeTypeDictField,
-				Value: &astnode.Node{
-					Type:  astnode.NodeTypeExpression,
-					Body:  []*astnode.Node{key},
-					Debug: key.Debug,
-				},
-			}
-
-			if err := inxPP(tokens, inx); err != nil {
-				return nil, err
-			}
-
-			token = tokens[*inx]
-			if token.Type != lexer.TokenColon {
-				return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected ':', got '%s'", token.Value), node.Debug)
-			}
+			if token.Type == lexer.TokenEllipsis {
+				if err := inxPP(tokens, inx); err != nil {
+					return nil, err
+				}
 
-			if err := inxPP(tokens, inx); err != nil {
-				return nil, err
-			}
+				value, err := ValueParser(ctx, sn, tokens, inx)
+				if err != nil {
+					return nil, err
+				}
 
-			value, err := ValueParser(ctx, sn, tokens, inx)
-			if err != nil {
+				node.Children = append(node.Children, &astnode.Node{
+					Type:     astnode.NodeTypeDictField,
+					Flags:    astnode.AppendFlags{"SPREAD"},
+					Children: []*astnode.Node{value},
+					Debug:    token.Debug,
+				})
+			} else if err := dictFieldParser(ctx, sn, tokens, inx, node); err != nil {
 				return nil, err
 			}
 
-			dataset.Children = append(dataset.Children, value)
-
-			node.Children = append(node.Children, dataset)
-
 			if *inx >= len(tokens) {
 				return nil, newErr(ErrUnexpectedToken, "unexpected end of input", node.Debug)
 			}
@@ -140,3 +115,54 @@ loop:
 
 	return node, nil
 }
+
+// dictFieldParser parses a key: value entry of a dict literal starting at
+// tokens[*inx] and appends it to node.
+func dictFieldParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, inx *int, node *astnode.Node) error {
+	token := tokens[*inx]
+	if token.Type != lexer.TokenString && token.Type != lexer.TokenIdentifier && token.Type != lexer.TokenNumber {
+		return newErr(ErrUnexpectedToken, fmt.Sprintf("dict key must be string, identifier or number, got '%s'", token.Value), node.Debug)
+	}
+
+	key, err := singleValueParser(ctx, sn, tokens, inx, token)
+	if err != nil {
+		return err
+	}
+
+	if key.Kind == "IDENTIFIER" {
+		key.Kind = "STRING"
+		key.IsReference = false
+	}
+
+	dataset := &astnode.Node{
+		Type: astnode.NodeTypeDictField,
+		Value: &astnode.Node{
+			Type:  astnode.NodeTypeExpression,
+			Body:  []*astnode.Node{key},
+			Debug: key.Debug,
+		},
+	}
+
+	if err := inxPP(tokens, inx); err != nil {
+		return err
+	}
+
+	token = tokens[*inx]
+	if token.Type != lexer.TokenColon {
+		return newErr(ErrUnexpectedToken, fmt.Sprintf("expected ':', got '%s'", token.Value), node.Debug)
+	}
+
+	if err := inxPP(tokens, inx); err != nil {
+		return err
+	}
+
+	value, err := ValueParser(ctx, sn, tokens, inx)
+	if err != nil {
+		return err
+	}
+
+	dataset.Children = append(dataset.Children, value)
+
+	node.Children = append(node.Children, dataset)
+	return nil
+}
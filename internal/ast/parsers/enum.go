@@ -0,0 +1,156 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/lexer"
+)
+
+func EnumParser(ctx context.Context, tokens []*lexer.Token, inx *int) (*astnode.Node, error) {
+	node := &astnode.Node{
+		Type:  astnode.NodeTypeEnum,
+		Debug: tokens[*inx].Debug,
+	}
+
+	if err := inxPP(tokens, inx); err != nil {
+		return nil, err
+	}
+
+	token := tokens[*inx]
+	if token.Type != lexer.TokenIdentifier {
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+	}
+	node.Content = token.Value
+
+	if err := inxPP(tokens, inx); err != nil {
+		return nil, err
+	}
+
+	token = tokens[*inx]
+	if token.Type != lexer.TokenOpenBrace {
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected '{', got %s", token.Type), token.Debug)
+	}
+	*inx++
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			if !skipSeparators(tokens, inx) {
+				return nil, newErr(ErrSyntaxError, "unexpected end of input", node.Debug)
+			}
+
+			token = tokens[*inx]
+			if token.Type == lexer.TokenCloseBrace {
+				*inx++
+				if len(node.Body) == 0 {
+					return nil, newErr(ErrSyntaxError, fmt.Sprintf("enum %s must have at least one variant", node.Content), node.Debug)
+				}
+				return node, nil
+			}
+
+			variant, err := enumVariantParser(ctx, tokens, inx)
+			if err != nil {
+				return nil, err
+			}
+			node.Body = append(node.Body, variant)
+		}
+	}
+}
+
+func enumVariantParser(ctx context.Context, tokens []*lexer.Token, inx *int) (*astnode.Node, error) {
+	token := tokens[*inx]
+	if token.Type != lexer.TokenIdentifier {
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected variant name, got %s", token.Type), token.Debug)
+	}
+
+	variant := &astnode.Node{
+		Type:    astnode.NodeTypeEnumVariant,
+		Content: token.Value,
+		Debug:   token.Debug,
+	}
+
+	if err := inxPP(tokens, inx); err != nil {
+		return nil, err
+	}
+
+	if tokens[*inx].Type != lexer.TokenOpenParen {
+		return variant, nil
+	}
+
+	for {
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token = tokens[*inx]
+		if token.Type == lexer.TokenCloseParen {
+			*inx++
+			return variant, nil
+		}
+
+		if token.Type != lexer.TokenIdentifier {
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+		}
+
+		field := &astnode.Node{
+			Type:    astnode.NodeTypeStructField,
+			Content: token.Value,
+			Debug:   token.Debug,
+		}
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token = tokens[*inx]
+		if token.Type != lexer.TokenColon {
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected ':', got %s", token.Type), token.Debug)
+		}
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		typ, err := TypeParser(ctx, tokens, inx)
+		if err != nil {
+			return nil, err
+		}
+		field.ValueType = typ
+		variant.Body = append(variant.Body, field)
+
+		if err := inxPPIf(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token = tokens[*inx]
+		switch token.Type {
+		case lexer.TokenCloseParen:
+			*inx++
+			return variant, nil
+		case lexer.TokenComma:
+		default:
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected ',' or ')', got %s", token.Type), token.Debug)
+		}
+	}
+}
+
+// skipSeparators moves past whitespace, newlines and commas between the
+// entries of a braced list such as the variants of an enum. It reports
+// whether any tokens are left.
+func skipSeparators(tokens []*lexer.Token, inx *int) bool {
+	for *inx < len(tokens) {
+		switch tokens[*inx].Type {
+		case lexer.TokenComma, lexer.TokenSemicolon, lexer.TokenNewLine:
+		default:
+			if !isWhite(tokens[*inx]) {
+				return true
+			}
+		}
+		*inx++
+	}
+	return false
+}
@@ -36,6 +36,19 @@ func FnParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, inx *i
 	}
 
 	token := tokens[*inx]
+	if !inline && token.Type == lexer.TokenLessThan {
+		params, err := typeParamsParser(ctx, tokens, inx)
+		if err != nil {
+			return nil, err
+		}
+		node.TypeParams = params
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+		token = tokens[*inx]
+	}
+
 	if token.Type != lexer.TokenOpenParen {
 		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected '(', got %s", token.Type), token.Debug)
 	}
@@ -53,6 +66,9 @@ loop:
 				return nil, err
 			}
 			if arg != nil {
+				if len(args) > 0 && args[len(args)-1].Flags.Contains("VARIADIC") {
+					return nil, newErr(ErrSyntaxError, "a variadic argument must be the last one", arg.Debug)
+				}
 				args = append(args, arg)
 			}
 			if last {
@@ -188,14 +204,33 @@ func fnArgumentParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token
 		return nil, true, nil
 	}
 
-	if token.Type != lexer.TokenIdentifier {
-		return nil, false, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+	variadic := token.Type == lexer.TokenEllipsis
+	if variadic {
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, false, err
+		}
+		token = tokens[*inx]
 	}
+
 	node := &astnode.Node{
 		Type:    astnode.NodeTypeFunctionArgument,
 		Content: token.Value,
 		Debug:   token.Debug,
 	}
+	if variadic {
+		node.Flags.Append("VARIADIC")
+	}
+
+	if isPatternStart(token) && !(len(typeOnly) > 0 && typeOnly[0]) {
+		pattern, err := PatternParser(ctx, sn, tokens, inx)
+		if err != nil {
+			return nil, false, err
+		}
+		node.Content = patternName(pattern)
+		node.Args = []*astnode.Node{pattern}
+	} else if token.Type != lexer.TokenIdentifier {
+		return nil, false, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+	}
 
 	if err := inxNlPP(tokens, inx); err != nil {
 		return nil, false, err
@@ -229,6 +264,9 @@ func fnArgumentParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token
 	}
 
 	if token.Type == lexer.TokenAssign {
+		if variadic {
+			return nil, false, newErr(ErrSyntaxError, "a variadic argument cannot have a default value", token.Debug)
+		}
 		if len(typeOnly) > 0 && typeOnly[0] {
 			return nil, false, newErr(ErrSyntaxError, "type only function, default values not supported")
 		}
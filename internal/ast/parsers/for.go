@@ -21,15 +21,11 @@ func ForParser(ctx context.Context, p Parser_HTML, tokens []*lexer.Token, inx *i
 	forValue := &astnode.ForValue{}
 
 	token := tokens[*inx]
-	if token.Type != lexer.TokenIdentifier {
-		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
-	}
-
-	forValue.Value = &astnode.Node{
-		Type:  astnode.NodeTypeValue,
-		Kind:  "IDENTIFIER",
-		Value: token.Value,
+	value, err := forTargetParser(ctx, p, tokens, inx)
+	if err != nil {
+		return nil, err
 	}
+	forValue.Value = value
 
 	if err := inxPP(tokens, inx); err != nil {
 		return nil, newErr(ErrUnexpectedEOF, "expected ',' or 'in', got EOF", token.Debug)
@@ -42,16 +38,13 @@ func ForParser(ctx context.Context, p Parser_HTML, tokens []*lexer.Token, inx *i
 		}
 		token = tokens[*inx]
 
-		if token.Type != lexer.TokenIdentifier {
-			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+		value, err := forTargetParser(ctx, p, tokens, inx)
+		if err != nil {
+			return nil, err
 		}
 
 		forValue.Iterator = forValue.Value
-		forValue.Value = &astnode.Node{
-			Type:  astnode.NodeTypeValue,
-			Kind:  "IDENTIFIER",
-			Value: token.Value,
-		}
+		forValue.Value = value
 
 		if err := inxPP(tokens, inx); err != nil {
 			return nil, newErr(ErrUnexpectedEOF, "expected 'in', got EOF", token.Debug)
@@ -155,3 +148,22 @@ bodyloop:
 
 	return node, nil
 }
+
+// forTargetParser parses a loop variable: a plain identifier or a
+// destructuring pattern.
+func forTargetParser(ctx context.Context, p Parser_HTML, tokens []*lexer.Token, inx *int) (*astnode.Node, error) {
+	token := tokens[*inx]
+	if isPatternStart(token) {
+		return PatternParser(ctx, p, tokens, inx)
+	}
+
+	if token.Type != lexer.TokenIdentifier {
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+	}
+
+	return &astnode.Node{
+		Type:  astnode.NodeTypeValue,
+		Kind:  "IDENTIFIER",
+		Value: token.Value,
+	}, nil
+}
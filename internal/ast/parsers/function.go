@@ -191,12 +191,24 @@ func fnParseArg(ctx context.Context, attrParser Parser_HTML, tokens []*lexer.Tok
 		tokens = tokens[2:] // Remove name and colon
 	}
 
+	spread := len(tokens) > 1 && tokens[0].Type == lexer.TokenEllipsis
+	if spread {
+		if argName != "" {
+			return nil, newErr(ErrInvalidFunctionArg, "a named argument cannot be spread", tokens[0].Debug)
+		}
+		tokens = tokens[1:]
+	}
+
 	tinx := 0
 	node, err := ValueParser(ctx, attrParser, tokens, &tinx)
 	if err != nil {
 		return nil, err
 	}
 
+	if spread {
+		node.Flags.Append("SPREAD")
+	}
+
 	if argName != "" {
 		node.Kind = "NAMED_ARG"
 		node.ArgName = argName
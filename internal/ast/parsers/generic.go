@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/lexer"
+)
+
+// typeParamsParser parses the type parameters of a generic declaration like
+// <K, V>. inx points at '<' and is left at '>'.
+func typeParamsParser(ctx context.Context, tokens []*lexer.Token, inx *int) ([]*astnode.Node, error) {
+	var params []*astnode.Node
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token := tokens[*inx]
+		if token.Type != lexer.TokenIdentifier {
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected type parameter name, got %s", token.Type), token.Debug)
+		}
+		if slices.ContainsFunc(params, func(p *astnode.Node) bool { return p.Content == token.Value }) {
+			return nil, newErr(ErrSyntaxError, fmt.Sprintf("duplicate type parameter %s", token.Value), token.Debug)
+		}
+
+		params = append(params, &astnode.Node{
+			Type:    astnode.NodeTypeType,
+			Content: token.Value,
+			Debug:   token.Debug,
+		})
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token = tokens[*inx]
+		switch token.Type {
+		case lexer.TokenGreaterThan:
+			return params, nil
+		case lexer.TokenComma:
+		default:
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected ',' or '>', got %s", token.Type), token.Debug)
+		}
+	}
+}
+
+// typeArgsParser parses the type arguments of a generic type like
+// <string, int>. inx points at '<' and is left after '>'.
+func typeArgsParser(ctx context.Context, tokens []*lexer.Token, inx *int) ([]*astnode.Node, error) {
+	var args []*astnode.Node
+
+	for {
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		typ, err := TypeParser(ctx, tokens, inx)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, typ)
+
+		for *inx < len(tokens) && slices.Contains(white, tokens[*inx].Type) {
+			*inx++
+		}
+		if *inx >= len(tokens) {
+			return nil, newErr(ErrUnexpectedEOF, "unexpected end of type arguments", typ.Debug)
+		}
+
+		token := tokens[*inx]
+		switch token.Type {
+		case lexer.TokenGreaterThan:
+			*inx++
+			return args, nil
+		case lexer.TokenComma:
+		default:
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected ',' or '>', got %s", token.Type), token.Debug)
+		}
+	}
+}
@@ -18,6 +18,7 @@ func ListParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, inx
 	token := tokens[*inx]
 	node.Debug = token.Debug
 	if token.Type == lexer.TokenCloseBracket {
+		listEnd(tokens, inx)
 		return node, nil
 	}
 
@@ -79,10 +80,18 @@ loop:
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
+			spread := cleaned[cinx].Type == lexer.TokenEllipsis
+			if spread {
+				cinx++
+			}
+
 			value, err := ValueParser(ctx, sn, cleaned, &cinx)
 			if err != nil {
 				return nil, err
 			}
+			if spread {
+				value.Flags.Append("SPREAD")
+			}
 
 			node.Children = append(node.Children, value)
 			token := cleaned[cinx]
@@ -99,5 +108,15 @@ loop:
 		}
 	}
 
+	listEnd(tokens, inx)
 	return node, nil
 }
+
+// listEnd moves past the closing bracket of a list, like DictParser does
+// with its closing brace, so a list can be followed by more tokens.
+func listEnd(tokens []*lexer.Token, inx *int) {
+	last := *inx
+	if err := inxPP(tokens, inx); err != nil {
+		*inx = last
+	}
+}
@@ -0,0 +1,215 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/lexer"
+)
+
+func MatchParser(ctx context.Context, p interface {
+	HTMLAttrValueParser
+	parser
+}, tokens []*lexer.Token, inx *int) (*astnode.Node, error) {
+	node := &astnode.Node{
+		Type:  astnode.NodeTypeMatch,
+		Debug: tokens[*inx].Debug,
+	}
+
+	var (
+		valueTokens []*lexer.Token
+		braceCount  = 0
+	)
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			if err := inxPP(tokens, inx); err != nil {
+				return nil, err
+			}
+
+			token := tokens[*inx]
+			if token.Type == lexer.TokenOpenBrace {
+				if braceCount == 0 {
+					break loop
+				}
+				braceCount++
+			}
+			if token.Type == lexer.TokenCloseBrace {
+				braceCount--
+			}
+
+			valueTokens = append(valueTokens, token)
+		}
+	}
+
+	if len(valueTokens) == 0 {
+		return nil, newErr(ErrSyntaxError, "match expects a value", node.Debug)
+	}
+
+	vinx := 0
+	value, err := ValueParser(ctx, p, valueTokens, &vinx)
+	if err != nil {
+		return nil, err
+	}
+	node.Args = append(node.Args, value)
+
+	var body []*lexer.Token
+	token := tokens[*inx]
+	braceCount = 1
+
+bodyloop:
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			*inx++
+
+			if *inx >= len(tokens) {
+				return nil, newErr(ErrUnexpectedToken, "unexpected end of input", token.Debug)
+			}
+
+			token = tokens[*inx]
+
+			if token.Type == lexer.TokenCloseBrace {
+				braceCount--
+				if braceCount == 0 {
+					*inx++
+					break bodyloop
+				}
+			} else if token.Type == lexer.TokenOpenBrace || token.Type == lexer.TokenUnescapedBrace {
+				braceCount++
+			}
+
+			body = append(body, token)
+		}
+	}
+
+	binx := 0
+	for skipSeparators(body, &binx) {
+		arm, err := matchArmParser(ctx, p, body, &binx)
+		if err != nil {
+			return nil, err
+		}
+		node.Body = append(node.Body, arm)
+	}
+
+	return node, nil
+}
+
+func matchArmParser(ctx context.Context, p parser, tokens []*lexer.Token, inx *int) (*astnode.Node, error) {
+	token := tokens[*inx]
+	if token.Type != lexer.TokenIdentifier {
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected variant name or '_', got %s", token.Type), token.Debug)
+	}
+
+	arm := &astnode.Node{
+		Type:    astnode.NodeTypeMatchArm,
+		Content: token.Value,
+		Debug:   token.Debug,
+	}
+
+	if err := inxPP(tokens, inx); err != nil {
+		return nil, err
+	}
+
+	// A qualified pattern such as Status.Active keeps the enum name in Kind.
+	if tokens[*inx].Type == lexer.TokenDot {
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+		token = tokens[*inx]
+		if token.Type != lexer.TokenIdentifier {
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected variant name, got %s", token.Type), token.Debug)
+		}
+		arm.Kind = arm.Content
+		arm.Content = token.Value
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+	}
+
+	if tokens[*inx].Type == lexer.TokenOpenParen {
+		arm.Flags.Append("FIELDS")
+		for {
+			if err := inxPP(tokens, inx); err != nil {
+				return nil, err
+			}
+
+			token = tokens[*inx]
+			if token.Type == lexer.TokenCloseParen {
+				break
+			}
+			if token.Type == lexer.TokenComma {
+				continue
+			}
+			if token.Type != lexer.TokenIdentifier {
+				return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, got %s", token.Type), token.Debug)
+			}
+
+			arm.Args = append(arm.Args, &astnode.Node{
+				Type:    astnode.NodeTypeFunctionArgument,
+				Content: token.Value,
+				Debug:   token.Debug,
+			})
+		}
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+	}
+
+	token = tokens[*inx]
+	if token.Type != lexer.TokenArrow {
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected '=>', got %s", token.Type), token.Debug)
+	}
+
+	if err := inxPP(tokens, inx); err != nil {
+		return nil, err
+	}
+
+	if tokens[*inx].Type == lexer.TokenOpenBrace {
+		body, err := getBraceBodyParse(ctx, tokens, inx, p)
+		if err != nil {
+			return nil, err
+		}
+		arm.Body = body
+		return arm, nil
+	}
+
+	// A single statement runs until the end of the line or the next arm.
+	var (
+		start = *inx
+		depth = 0
+	)
+	for ; *inx < len(tokens); *inx++ {
+		switch tokens[*inx].Type {
+		case lexer.TokenOpenParen, lexer.TokenOpenBracket, lexer.TokenOpenBrace, lexer.TokenUnescapedBrace:
+			depth++
+			continue
+		case lexer.TokenCloseParen, lexer.TokenCloseBracket, lexer.TokenCloseBrace:
+			depth--
+			continue
+		case lexer.TokenNewLine, lexer.TokenComma:
+			if depth > 0 {
+				continue
+			}
+		default:
+			continue
+		}
+		break
+	}
+
+	body, err := p.Parse(tokens[start:*inx])
+	if err != nil {
+		return nil, err
+	}
+	arm.Body = body
+	return arm, nil
+}
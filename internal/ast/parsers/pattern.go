@@ -0,0 +1,231 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/lexer"
+)
+
+// isPatternStart reports whether token opens a destructuring pattern.
+func isPatternStart(token *lexer.Token) bool {
+	return token.Type == lexer.TokenOpenBrace || token.Type == lexer.TokenOpenBracket
+}
+
+// PatternParser parses a destructuring pattern: an identifier, a dict
+// pattern such as { name, age: years = 0, ...rest } or a list pattern such
+// as [head, ...tail]. inx is left on the last token of the pattern.
+func PatternParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, inx *int) (*astnode.Node, error) {
+	token := tokens[*inx]
+
+	switch token.Type {
+	case lexer.TokenIdentifier:
+		return &astnode.Node{
+			Type:    astnode.NodeTypePattern,
+			Kind:    "IDENTIFIER",
+			Content: token.Value,
+			Debug:   token.Debug,
+		}, nil
+	case lexer.TokenOpenBrace, lexer.TokenOpenBracket:
+	default:
+		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier, '{' or '[', got %s", token.Type), token.Debug)
+	}
+
+	node := &astnode.Node{
+		Type:  astnode.NodeTypePattern,
+		Kind:  "DICT",
+		Debug: token.Debug,
+	}
+
+	closing := lexer.TokenType(lexer.TokenCloseBrace)
+	if token.Type == lexer.TokenOpenBracket {
+		node.Kind = "LIST"
+		closing = lexer.TokenCloseBracket
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if err := patternNext(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token = tokens[*inx]
+		if token.Type == closing {
+			return node, nil
+		}
+
+		if len(node.Children) > 0 && node.Children[len(node.Children)-1].Flags.Contains("REST") {
+			return nil, newErr(ErrSyntaxError, "a rest element must be the last one in a pattern", token.Debug)
+		}
+
+		var (
+			child *astnode.Node
+			err   error
+		)
+
+		switch {
+		case token.Type == lexer.TokenEllipsis:
+			if err := patternNext(tokens, inx); err != nil {
+				return nil, err
+			}
+			token = tokens[*inx]
+			if token.Type != lexer.TokenIdentifier {
+				return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected identifier after '...', got %s", token.Type), token.Debug)
+			}
+			child = &astnode.Node{
+				Type:    astnode.NodeTypePattern,
+				Kind:    "IDENTIFIER",
+				Content: token.Value,
+				Flags:   astnode.AppendFlags{"REST"},
+				Debug:   token.Debug,
+			}
+
+		case node.Kind == "DICT":
+			if token.Type != lexer.TokenIdentifier {
+				return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected key, got %s", token.Type), token.Debug)
+			}
+			key := token.Value
+
+			if *inx+1 < len(tokens) && tokens[*inx+1].Type == lexer.TokenColon {
+				*inx++
+				if err := patternNext(tokens, inx); err != nil {
+					return nil, err
+				}
+				if child, err = PatternParser(ctx, sn, tokens, inx); err != nil {
+					return nil, err
+				}
+			} else if child, err = PatternParser(ctx, sn, tokens, inx); err != nil {
+				return nil, err
+			}
+			child.ArgName = key
+
+		default:
+			if child, err = PatternParser(ctx, sn, tokens, inx); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := patternNext(tokens, inx); err != nil {
+			return nil, err
+		}
+
+		token = tokens[*inx]
+		if token.Type == lexer.TokenAssign {
+			if child.Flags.Contains("REST") {
+				return nil, newErr(ErrSyntaxError, "a rest element cannot have a default value", token.Debug)
+			}
+			if err := patternNext(tokens, inx); err != nil {
+				return nil, err
+			}
+
+			fallback, err := patternDefault(ctx, sn, tokens, inx, closing)
+			if err != nil {
+				return nil, err
+			}
+			child.FallbackValue = fallback
+			token = tokens[*inx]
+		}
+
+		node.Children = append(node.Children, child)
+
+		switch token.Type {
+		case closing:
+			return node, nil
+		case lexer.TokenComma:
+		default:
+			return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected ',' or '%s', got %s", closing, token.Type), token.Debug)
+		}
+	}
+}
+
+// patternNext moves to the next token of a pattern, skipping whitespace and
+// newlines.
+func patternNext(tokens []*lexer.Token, inx *int) error {
+	for {
+		if err := inxPP(tokens, inx); err != nil {
+			return err
+		}
+		if tokens[*inx].Type != lexer.TokenNewLine {
+			return nil
+		}
+	}
+}
+
+// patternDefault parses the default value of a pattern element, which runs
+// until the next ',' or the end of the pattern.
+func patternDefault(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, inx *int, closing lexer.TokenType) (*astnode.Node, error) {
+	var (
+		start = *inx
+		depth = 0
+	)
+
+	for ; *inx < len(tokens); *inx++ {
+		switch tokens[*inx].Type {
+		case lexer.TokenOpenParen, lexer.TokenOpenBracket, lexer.TokenOpenBrace:
+			depth++
+			continue
+		case lexer.TokenCloseParen, lexer.TokenCloseBracket, lexer.TokenCloseBrace:
+			if depth > 0 || tokens[*inx].Type != closing {
+				depth--
+				continue
+			}
+		case lexer.TokenComma, lexer.TokenNewLine:
+			if depth > 0 {
+				continue
+			}
+		default:
+			continue
+		}
+		break
+	}
+
+	if *inx >= len(tokens) {
+		return nil, newErr(ErrSyntaxError, "unexpected end of input", tokens[start].Debug)
+	}
+
+	value := tokens[start:*inx]
+	vinx := 0
+	fallback, err := ValueParser(ctx, sn, value, &vinx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokens[*inx].Type == lexer.TokenNewLine {
+		if err := patternNext(tokens, inx); err != nil {
+			return nil, err
+		}
+	}
+	return fallback, nil
+}
+
+// patternName describes a pattern in error messages and function
+// signatures, e.g. {name, age}.
+func patternName(node *astnode.Node) string {
+	switch node.Kind {
+	case "DICT", "LIST":
+		parts := make([]string, len(node.Children))
+		for i, child := range node.Children {
+			name := patternName(child)
+			if child.ArgName != "" && (child.Kind != "IDENTIFIER" || child.Content != child.ArgName) {
+				name = child.ArgName + ": " + name
+			}
+			parts[i] = name
+		}
+		if node.Kind == "DICT" {
+			return "{" + strings.Join(parts, ", ") + "}"
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+
+	if node.Flags.Contains("REST") {
+		return "..." + node.Content
+	}
+	return node.Content
+}
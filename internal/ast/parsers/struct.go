@@ -29,6 +29,19 @@ func StructParser(ctx context.Context, tokens []*lexer.Token, inx *int) (*astnod
 	}
 
 	token = tokens[*inx]
+	if token.Type == lexer.TokenLessThan {
+		params, err := typeParamsParser(ctx, tokens, inx)
+		if err != nil {
+			return nil, err
+		}
+		node.TypeParams = params
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+		token = tokens[*inx]
+	}
+
 	if token.Type != lexer.TokenOpenBrace {
 		return nil, newErr(ErrUnexpectedToken, fmt.Sprintf("expected '{', got %s", token.Type), token.Debug)
 	}
@@ -122,6 +135,14 @@ loop:
 				return nil, newErr(ErrUnexpectedToken, "unexpected end of input", token.Debug)
 			}
 
+			// An optional tag follows the type, as in `email: string `json:"mail"``.
+			if token = tokens[*inx]; token.Type == lexer.TokenString {
+				child.Value = token.Value
+				if err := inxPP(tokens, inx); err != nil {
+					return nil, err
+				}
+			}
+
 			body = append(body, child)
 
 			token = tokens[*inx]
@@ -72,6 +72,14 @@ func TypeParser(ctx context.Context, tokens []*lexer.Token, inx *int) (*astnode.
 
 		*inx++
 
+		if *inx < len(tokens) && tokens[*inx].Type == lexer.TokenLessThan {
+			args, err := typeArgsParser(ctx, tokens, inx)
+			if err != nil {
+				return nil, err
+			}
+			node.TypeParams = args
+		}
+
 		if *inx < len(tokens) && tokens[*inx].Type == lexer.TokenQuestion {
 			node.Flags = append(node.Flags, "OPTIONAL")
 			*inx++
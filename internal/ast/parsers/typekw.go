@@ -34,6 +34,19 @@ func TypeKWParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, in
 	}
 
 	token = tokens[*inx]
+	if token.Type == lexer.TokenLessThan {
+		params, err := typeParamsParser(ctx, tokens, inx)
+		if err != nil {
+			return nil, err
+		}
+		node.TypeParams = params
+
+		if err := inxPP(tokens, inx); err != nil {
+			return nil, err
+		}
+		token = tokens[*inx]
+	}
+
 	if token.Type != lexer.TokenColon {
 		return nil, newErr(ErrSyntaxError, "type keyword expects ':'")
 	}
@@ -27,6 +27,10 @@ func ValueParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token, inx
 		return IncludeParser(ctx, sn, tokens, inx)
 	}
 
+	if token.Type == lexer.TokenSpawn {
+		return SpawnParser(ctx, sn, tokens, inx)
+	}
+
 	if token.Type == lexer.TokenIdentifier && token.Value == "dict" {
 		return DictParser(ctx, sn, tokens, inx)
 	}
@@ -30,12 +30,18 @@ func VariableParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token,
 
 	token = tokens[*inx]
 
-	if token.Type != lexer.TokenIdentifier {
+	if isPatternStart(token) {
+		pattern, err := PatternParser(ctx, sn, tokens, inx)
+		if err != nil {
+			return nil, err
+		}
+		node.Args = []*astnode.Node{pattern}
+	} else if token.Type != lexer.TokenIdentifier {
 		return nil, newErr(ErrSyntaxError, fmt.Sprintf("expected identifier, got %s", token.Value), token.Debug)
+	} else {
+		node.Content = token.Value
 	}
 
-	node.Content = token.Value
-
 	if err := inxPP(tokens, inx); err != nil {
 		return nil, err
 	}
@@ -75,6 +81,8 @@ func VariableParser(ctx context.Context, sn Parser_HTML, tokens []*lexer.Token,
 
 		node.Value = value
 		node.Flags.Append("NODEVALUE")
+	} else if len(node.Args) > 0 {
+		return nil, newErr(ErrSyntaxError, "a destructuring declaration needs a value", node.Debug)
 	}
 
 	return skipSemi(tokens, inx, node), nil
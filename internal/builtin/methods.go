@@ -12,6 +12,7 @@ import (
 	"github.com/nubolang/nubo/internal/codehighlight"
 	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native"
 	"github.com/nubolang/nubo/native/n"
@@ -133,8 +134,16 @@ func sleepFn(ctx native.FnCtx) (language.Object, error) {
 		return nil, fmt.Errorf("duration must be non-negative")
 	}
 
-	time.Sleep(time.Duration(value) * time.Millisecond)
-	return nil, nil
+	timer := time.NewTimer(time.Duration(value) * time.Millisecond)
+	defer timer.Stop()
+
+	// A cancelled task must not stay asleep.
+	select {
+	case <-timer.C:
+		return nil, nil
+	case <-ctx.Context().Done():
+		return nil, ctx.Context().Err()
+	}
 }
 
 func stringFn(ctx native.FnCtx) (language.Object, error) {
@@ -506,6 +515,9 @@ func exitFn(ctx native.FnCtx) (language.Object, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := sandbox.FromContext(ctx.Context()).CheckProcess("exit"); err != nil {
+		return nil, err
+	}
 
 	os.Exit(int(code.Value().(int64)))
 
@@ -537,6 +549,9 @@ func unwrapFn(ctx native.FnCtx) (language.Object, error) {
 func envFn(a *n.Args) (any, error) {
 	name := a.Name("name").String()
 	value := a.Name("value")
+	if err := sandbox.FromContext(a.Context()).CheckProcess("env"); err != nil {
+		return nil, err
+	}
 
 	if value.Type().Base() == language.ObjectTypeNil {
 		return os.Getenv(name), nil
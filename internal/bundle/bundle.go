@@ -0,0 +1,157 @@
+// Package bundle packs a Nubo app into a copy of the nubo executable and
+// boots it from there.
+//
+// A bundled executable is the nubo binary followed by a zip payload and a
+// fixed size trailer:
+//
+//	[nubo binary][zip payload][sha256 of payload][payload size][magic]
+//
+// The payload holds the app under app/, vendored packages under packages/,
+// the nubo config and a manifest describing how to start the app.
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	magic       = "NUBOAPP1"
+	trailerSize = sha256Size + 8 + len(magic)
+	sha256Size  = 32
+
+	// ManifestName is the payload file holding the Manifest.
+	ManifestName = "bundle.yaml"
+	// ConfigName is the payload file holding the embedded nubo config.
+	ConfigName = "config.yaml"
+	// AppDir is the payload directory holding the app sources and assets.
+	AppDir = "app"
+	// PackagesDir is the payload directory holding vendored packages.
+	PackagesDir = "packages"
+)
+
+// Manifest describes how a bundled app boots.
+type Manifest struct {
+	// Version is the nubo version that built the bundle.
+	Version string `yaml:"version"`
+	// Entry is the file to run, relative to AppDir. Unused when Serve is set.
+	Entry string `yaml:"entry,omitempty"`
+	// Serve starts the HTTP server on AppDir instead of running Entry.
+	Serve bool `yaml:"serve,omitempty"`
+	// Addr overrides the configured server address.
+	Addr string `yaml:"addr,omitempty"`
+}
+
+// Bundle is an app embedded in an executable.
+type Bundle struct {
+	Manifest Manifest
+
+	id string
+	zr *zip.Reader
+}
+
+// Open returns the bundle appended to the running executable, or nil if the
+// executable carries no bundle.
+func Open() (*Bundle, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return OpenFile(exe)
+}
+
+// OpenFile returns the bundle appended to the executable at path, or nil if
+// it carries none.
+func OpenFile(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := readTrailer(f)
+	if err != nil || t == nil {
+		f.Close()
+		return nil, err
+	}
+
+	// The file stays open for the lifetime of the process; the zip reader
+	// reads from it lazily.
+	zr, err := zip.NewReader(io.NewSectionReader(f, t.offset, t.size), t.size)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("bundle: read payload: %w", err)
+	}
+
+	b := &Bundle{id: hex.EncodeToString(t.sum[:]), zr: zr}
+
+	data, err := b.ReadFile(ManifestName)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: read manifest: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("bundle: parse manifest: %w", err)
+	}
+	return b, nil
+}
+
+// ReadFile returns the contents of a payload file.
+func (b *Bundle) ReadFile(name string) ([]byte, error) {
+	f, err := b.zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// trailer locates the payload inside an executable.
+type trailer struct {
+	offset int64
+	size   int64
+	sum    [sha256Size]byte
+}
+
+// readTrailer returns the trailer of f, or nil if f has none.
+func readTrailer(f *os.File) (*trailer, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < int64(trailerSize) {
+		return nil, nil
+	}
+
+	buf := make([]byte, trailerSize)
+	if _, err := f.ReadAt(buf, info.Size()-int64(trailerSize)); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(buf[trailerSize-len(magic):], []byte(magic)) {
+		return nil, nil
+	}
+
+	t := &trailer{size: int64(binary.LittleEndian.Uint64(buf[sha256Size:]))}
+	copy(t.sum[:], buf[:sha256Size])
+	t.offset = info.Size() - int64(trailerSize) - t.size
+	if t.offset < 0 {
+		return nil, errors.New("bundle: corrupt trailer")
+	}
+	return t, nil
+}
+
+// writeTrailer appends the trailer for a payload of size bytes.
+func writeTrailer(w io.Writer, sum []byte, size int64) error {
+	buf := make([]byte, 0, trailerSize)
+	buf = append(buf, sum...)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(size))
+	buf = append(buf, magic...)
+	_, err := w.Write(buf)
+	return err
+}
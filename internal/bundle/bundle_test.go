@@ -0,0 +1,110 @@
+package bundle
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/dotfolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The nubo command registers the node types for prepared files; the tests
+// run without it.
+func init() {
+	gob.Register(&astnode.Node{})
+	gob.Register(&astnode.ForValue{})
+}
+
+func writeApp(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.nubo":        "println(\"hi\")\n",
+		"lib/util.nubo":    "fn id(x: int) int { return x }\n",
+		"static/style.css": "body {}\n",
+		".git/HEAD":        "ref: refs/heads/main\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	app := writeApp(t)
+	output := filepath.Join(t.TempDir(), "app")
+
+	require.NoError(t, Write(Options{Source: app, Output: output, Entry: "main.nubo"}))
+
+	b, err := OpenFile(output)
+	require.NoError(t, err)
+	require.NotNil(t, b)
+	assert.Equal(t, "main.nubo", b.Manifest.Entry)
+	assert.False(t, b.Manifest.Serve)
+
+	data, err := b.ReadFile("app/lib/util.nubo")
+	require.NoError(t, err)
+	assert.Equal(t, "fn id(x: int) int { return x }\n", string(data))
+
+	_, err = b.ReadFile("app/main.nuboc")
+	assert.NoError(t, err, "sources are prepared")
+	_, err = b.ReadFile("app/static/style.nuboc")
+	assert.Error(t, err, "only .nubo files are prepared")
+	_, err = b.ReadFile("app/.git/HEAD")
+	assert.Error(t, err, ".git is left out")
+
+	dir, err := b.Extract()
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(dir, AppDir, "static", "style.css"))
+	require.NoError(t, err)
+	assert.Equal(t, "body {}\n", string(data))
+
+	nodes, ok := dotfolder.HasPrepared(filepath.Join(dir, AppDir, "main.nubo"))
+	assert.True(t, ok, "prepared files are extracted next to their sources")
+	assert.NotEmpty(t, nodes)
+
+	again, err := b.Extract()
+	require.NoError(t, err)
+	assert.Equal(t, dir, again, "a payload is extracted once")
+
+	// The bundled executable starts with the runtime it was built from.
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	info, err := os.Stat(exe)
+	require.NoError(t, err)
+	f, err := os.Open(output)
+	require.NoError(t, err)
+	defer f.Close()
+	tr, err := readTrailer(f)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), tr.offset)
+}
+
+func TestOpenFile(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	b, err := OpenFile(exe)
+	assert.NoError(t, err)
+	assert.Nil(t, b, "a plain executable carries no bundle")
+
+	app := writeApp(t)
+	_, _, err = resolve(Options{Source: filepath.Join(app, "main.nubo"), Serve: true})
+	assert.ErrorContains(t, err, "serving needs a directory")
+
+	_, _, err = resolve(Options{Source: app, Entry: "missing.nubo"})
+	assert.ErrorContains(t, err, "entry missing.nubo not found")
+
+	root, manifest, err := resolve(Options{Source: filepath.Join(app, "main.nubo")})
+	require.NoError(t, err)
+	assert.Equal(t, app, root)
+	assert.Equal(t, "main.nubo", manifest.Entry)
+}
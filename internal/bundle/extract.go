@@ -0,0 +1,96 @@
+package bundle
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// completeMarker is written once a payload was fully extracted.
+const completeMarker = ".complete"
+
+// Extract unpacks the payload into the user cache and returns the
+// directory. Each payload is extracted once; later starts reuse it.
+func (b *Bundle) Extract() (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(cache, "nubo", "bundles", b.id[:16])
+	if _, err := os.Stat(filepath.Join(dir, completeMarker)); err == nil {
+		zap.L().Debug("bundle.extract.cached", zap.String("dir", dir))
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), b.id[:16]+".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, f := range b.zr.File {
+		if err := extractFile(tmp, f); err != nil {
+			return "", fmt.Errorf("bundle: extract %s: %w", f.Name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmp, completeMarker), nil, 0644); err != nil {
+		return "", err
+	}
+
+	_ = os.RemoveAll(dir)
+	if err := os.Rename(tmp, dir); err != nil {
+		// Another process may have extracted the same payload meanwhile.
+		if _, statErr := os.Stat(filepath.Join(dir, completeMarker)); statErr == nil {
+			return dir, nil
+		}
+		return "", err
+	}
+
+	zap.L().Debug("bundle.extract.done", zap.String("dir", dir), zap.Int("files", len(b.zr.File)))
+	return dir, nil
+}
+
+// extractFile writes f below root, keeping its mode and modification time so
+// prepared files stay newer than their sources.
+func extractFile(root string, f *zip.File) error {
+	dest := filepath.Join(root, filepath.FromSlash(f.Name))
+	if !strings.HasPrefix(dest, filepath.Clean(root)+string(os.PathSeparator)) {
+		return fmt.Errorf("path escapes the bundle")
+	}
+
+	mode := f.Mode()
+	if mode.IsDir() {
+		return os.MkdirAll(dest, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(dest, f.Modified, f.Modified)
+}
@@ -0,0 +1,327 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nubolang/nubo/config"
+	"github.com/nubolang/nubo/internal/dotfolder"
+	"github.com/nubolang/nubo/packer"
+	"github.com/nubolang/nubo/plug"
+	"github.com/nubolang/nubo/version"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// Options configures Write.
+type Options struct {
+	// Source is the app directory, or a single file to run.
+	Source string
+	// Output is the path of the executable to create.
+	Output string
+	// Entry is the file to run, relative to Source. Ignored when Source is a
+	// file or Serve is set.
+	Entry string
+	// Serve boots the HTTP server instead of running Entry.
+	Serve bool
+	// Addr overrides the configured server address.
+	Addr string
+}
+
+// Write creates a self-contained executable for the app described by opts.
+// Plugins are built, sources are prepared and locked packages are vendored
+// before everything is appended to a copy of the running nubo binary.
+func Write(opts Options) error {
+	root, manifest, err := resolve(opts)
+	if err != nil {
+		return err
+	}
+
+	output, err := filepath.Abs(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	if err := buildPlugins(root); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := copyRuntime(out); err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(out, hash)}
+	zw := zip.NewWriter(counter)
+
+	if err := addTree(zw, root, AppDir, output, true); err != nil {
+		return err
+	}
+	if err := addPackages(zw, root); err != nil {
+		return err
+	}
+	if err := addConfig(zw); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := addBytes(zw, ManifestName, data, time.Now()); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if err := writeTrailer(out, hash.Sum(nil), counter.n); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// resolve returns the app root and the manifest for opts.
+func resolve(opts Options) (string, *Manifest, error) {
+	source, err := filepath.Abs(opts.Source)
+	if err != nil {
+		return "", nil, err
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", nil, err
+	}
+
+	manifest := &Manifest{Version: version.Version, Serve: opts.Serve, Addr: opts.Addr}
+	if !info.IsDir() {
+		if opts.Serve {
+			return "", nil, fmt.Errorf("bundle: serving needs a directory, got file %s", opts.Source)
+		}
+		manifest.Entry = filepath.Base(source)
+		return filepath.Dir(source), manifest, nil
+	}
+
+	if !opts.Serve {
+		manifest.Entry = filepath.ToSlash(opts.Entry)
+		if _, err := os.Stat(filepath.Join(source, opts.Entry)); err != nil {
+			return "", nil, fmt.Errorf("bundle: entry %s not found in %s (use --entry or --serve)", opts.Entry, opts.Source)
+		}
+	}
+	return source, manifest, nil
+}
+
+// copyRuntime writes the running nubo binary to w, without any bundle it may
+// carry itself.
+func copyRuntime(w io.Writer) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+
+	t, err := readTrailer(f)
+	if err != nil {
+		return err
+	}
+	if t != nil {
+		size = t.offset
+	}
+
+	_, err = io.Copy(w, io.NewSectionReader(f, 0, size))
+	return err
+}
+
+// buildPlugins builds every plugin below root so its binary gets bundled.
+func buildPlugins(root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && skipDir(d.Name()) && p != root {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != "_plug.yaml" {
+			return nil
+		}
+
+		dir := filepath.Dir(p)
+		zap.L().Debug("bundle.plugin.build", zap.String("dir", dir))
+		bin, err := plug.Build(dir)
+		if err != nil {
+			return err
+		}
+		if rel, err := filepath.Rel(root, bin); err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("bundle: plugin binary %s is outside of the app", bin)
+		}
+		return nil
+	})
+}
+
+// addTree adds the files below dir to zw under prefix. With prepare set,
+// every .nubo file gets a .nuboc sibling holding its syntax tree.
+func addTree(zw *zip.Writer, dir, prefix, exclude string, prepare bool) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if skipDir(d.Name()) && p != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if p == exclude || !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := path.Join(prefix, filepath.ToSlash(rel))
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := addFile(zw, name, p, info); err != nil {
+			return err
+		}
+
+		if prepare && filepath.Ext(p) == ".nubo" {
+			nodes, err := dotfolder.PrepareFile(p)
+			if err != nil {
+				return err
+			}
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(nodes); err != nil {
+				return err
+			}
+			prepared := strings.TrimSuffix(name, ".nubo") + ".nuboc"
+			if err := addBytes(zw, prepared, buf.Bytes(), info.ModTime()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// addPackages vendors the packages locked by the app at root.
+func addPackages(zw *zip.Writer, root string) error {
+	lock, err := packer.LoadLockFile(root)
+	if err != nil {
+		return err
+	}
+	if len(lock.Entries) == 0 {
+		return nil
+	}
+
+	cache, err := packer.PackageDir()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range lock.Entries {
+		dir, _, err := entry.Download(cache)
+		if err != nil {
+			return fmt.Errorf("bundle: package %s: %w", entry.Name, err)
+		}
+		rel, err := filepath.Rel(cache, dir)
+		if err != nil {
+			return err
+		}
+		zap.L().Debug("bundle.package.add", zap.String("name", entry.Name), zap.String("dir", dir))
+		if err := addTree(zw, dir, path.Join(PackagesDir, filepath.ToSlash(rel)), "", false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addConfig embeds the nubo config file in use, if there is one.
+func addConfig(zw *zip.Writer) error {
+	file, err := config.GetFile()
+	if err != nil {
+		return nil
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	return addFile(zw, ConfigName, file, info)
+}
+
+func addFile(zw *zip.Writer, name, src string, info fs.FileInfo) error {
+	hdr, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	hdr.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func addBytes(zw *zip.Writer, name string, data []byte, modified time.Time) error {
+	hdr := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified}
+	hdr.SetMode(0644)
+
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// skipDir reports whether a directory is left out of the bundle.
+func skipDir(name string) bool {
+	return name == ".git" || name == dotfolder.RootFolderName
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
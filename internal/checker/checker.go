@@ -0,0 +1,273 @@
+// Package checker statically checks Nubo source files without running them.
+package checker
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nubolang/nubo/config"
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/builtin"
+	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/internal/packages"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native"
+)
+
+// Checker walks the AST of Nubo files and reports type mismatches, unknown
+// identifiers, wrong arities and unreachable code.
+type Checker struct {
+	global   *scope
+	modules  map[string]*scope
+	included map[string]struct{}
+	structs  map[string]*structInfo
+	enums    map[string]*enumInfo
+
+	diagnostics []*exception.Expection
+	reported    map[string]struct{}
+}
+
+// New creates a checker that knows about the builtins and the @std packages.
+func New() *Checker {
+	c := &Checker{
+		global:   newScope(nil),
+		modules:  make(map[string]*scope),
+		included: make(map[string]struct{}),
+		structs:  make(map[string]*structInfo),
+		enums:    make(map[string]*enumInfo),
+		reported: make(map[string]struct{}),
+	}
+
+	for name, obj := range builtin.GetBuiltins() {
+		c.global.declare(name, c.objectSymbol(obj))
+	}
+
+	// Declared by the interpreter in every file.
+	for name, typ := range map[string]*language.Type{
+		"__id__":         language.TypeInt,
+		"__entry__":      language.TypeBool,
+		"__dir__":        language.TypeString,
+		"__file__":       language.TypeString,
+		"__concurrent__": language.TypeBool,
+	} {
+		c.global.declare(name, &symbol{typ: typ, constant: true})
+	}
+
+	return c
+}
+
+// CheckFile checks the file at path and every file it imports. The returned
+// error reports files that cannot be read or parsed, problems found in the
+// source are collected as diagnostics.
+func (c *Checker) CheckFile(path string) error {
+	_, err := c.module(filepath.Clean(path))
+	return err
+}
+
+// Diagnostics returns the problems found so far ordered by file and position.
+func (c *Checker) Diagnostics() []*exception.Expection {
+	sort.SliceStable(c.diagnostics, func(i, j int) bool {
+		a, _ := exception.Unwrap(c.diagnostics[i])
+		b, _ := exception.Unwrap(c.diagnostics[j])
+		if a.Debug == nil || b.Debug == nil {
+			return a.Debug != nil
+		}
+		if a.Debug.File != b.Debug.File {
+			return a.Debug.File < b.Debug.File
+		}
+		if a.Debug.Line != b.Debug.Line {
+			return a.Debug.Line < b.Debug.Line
+		}
+		return a.Debug.Column < b.Debug.Column
+	})
+	return c.diagnostics
+}
+
+// report records a diagnostic. The same problem found twice, for example in
+// a file imported from several places, is reported once.
+func (c *Checker) report(excp *exception.Expection) {
+	key := excp.GetMessage(false)
+	if _, ok := c.reported[key]; ok {
+		return
+	}
+	c.reported[key] = struct{}{}
+	c.diagnostics = append(c.diagnostics, excp)
+}
+
+func typeError(format string, args ...any) *exception.Expection {
+	return exception.Create(format, args...).WithLevel(exception.LevelType)
+}
+
+func semanticError(format string, args ...any) *exception.Expection {
+	return exception.Create(format, args...).WithLevel(exception.LevelSemantic)
+}
+
+// module checks a file once and returns its top level scope.
+func (c *Checker) module(path string) (*scope, error) {
+	if sc, ok := c.modules[path]; ok {
+		return sc, nil
+	}
+
+	nodes, err := native.NodesFromFile(path, path)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := newScope(c.global)
+	c.modules[path] = sc
+
+	f := &file{checker: c, path: path, root: sc}
+	f.block(sc, nodes)
+	return sc, nil
+}
+
+// resolvePath mirrors how the interpreter locates imported files.
+func resolvePath(current, fileName string) string {
+	var path string
+	if filepath.IsAbs(fileName) {
+		path = filepath.Clean(fileName)
+	} else {
+		path = fileName
+		for oldPrefix, newPrefix := range config.Current.Runtime.Interpreter.Import.Prefix {
+			if rest, ok := strings.CutPrefix(fileName, oldPrefix); ok {
+				path = filepath.Join(newPrefix, rest)
+				break
+			}
+		}
+
+		if path == fileName {
+			path = filepath.Clean(filepath.Join(filepath.Dir(current), fileName))
+		}
+	}
+
+	if filepath.Ext(path) == "" {
+		path += ".nubo"
+	}
+	return path
+}
+
+func (f *file) handleImport(sc *scope, node *astnode.Node) {
+	source, _ := node.Value.(string)
+
+	if strings.HasPrefix(source, "@std") {
+		obj, ok := packages.ImportPackage(source, nil, node.Debug)
+		if !ok {
+			f.report(semanticError("unknown package %q", source), node.Debug)
+			return
+		}
+		pkg := f.checker.objectSymbol(obj)
+
+		switch node.Kind {
+		case "SINGLE":
+			sc.declare(node.Content, pkg)
+		case "MULTIPLE":
+			for _, child := range node.Children {
+				name, _ := child.Value.(string)
+				sym, ok := f.member(pkg, child.Content, node.Debug)
+				if !ok {
+					sym = &symbol{typ: anyType()}
+				}
+				sc.declare(name, sym)
+			}
+		}
+		return
+	}
+
+	// Server modules, provided packages and packer imports are only known at
+	// runtime.
+	if strings.HasPrefix(source, "@") {
+		f.declareImportedAny(sc, node)
+		return
+	}
+
+	path := resolvePath(f.path, source)
+	module, err := f.checker.module(path)
+	if err != nil {
+		f.checker.report(exception.From(err, node.Debug, fmt.Sprintf("failed to import %s: @err", path)))
+		f.declareImportedAny(sc, node)
+		return
+	}
+
+	switch node.Kind {
+	case "SINGLE":
+		sc.declare(node.Content, &symbol{typ: anyType(), constant: true, module: module})
+	case "MULTIPLE":
+		for _, child := range node.Children {
+			name, _ := child.Value.(string)
+			sym, ok := module.symbols[child.Content]
+			if !ok {
+				f.report(semanticError("failed to import (%q) from %s", child.Content, path), node.Debug)
+				sym = &symbol{typ: anyType()}
+			}
+			sc.declare(name, sym)
+		}
+	}
+}
+
+func (f *file) declareImportedAny(sc *scope, node *astnode.Node) {
+	switch node.Kind {
+	case "SINGLE":
+		sc.declare(node.Content, &symbol{typ: anyType(), constant: true})
+	case "MULTIPLE":
+		for _, child := range node.Children {
+			name, _ := child.Value.(string)
+			sc.declare(name, &symbol{typ: anyType(), constant: true})
+		}
+	}
+}
+
+func (f *file) handleInclude(sc *scope, node *astnode.Node) {
+	value, ok := node.Value.(*astnode.Node)
+	if !ok {
+		return
+	}
+	f.typeOf(sc, value, nil)
+
+	name, ok := constantString(value)
+	if !ok {
+		f.root.dynamic = true
+		return
+	}
+
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(f.path), path)
+	}
+	path = filepath.Clean(path)
+	if filepath.Ext(path) == "" {
+		path += ".nubo"
+	}
+
+	if _, ok := f.checker.included[path]; ok {
+		return
+	}
+	f.checker.included[path] = struct{}{}
+
+	nodes, err := native.NodesFromFile(path, path)
+	if err != nil {
+		f.checker.report(exception.From(err, node.Debug, fmt.Sprintf("failed to include %s: @err", path)))
+		return
+	}
+
+	inc := newScope(sc)
+	f.root.includes = append(f.root.includes, inc)
+
+	included := &file{checker: f.checker, path: path, root: f.root}
+	included.block(inc, nodes)
+}
+
+// constantString returns the value of an expression made of a single string
+// literal.
+func constantString(node *astnode.Node) (string, bool) {
+	if node.Type != astnode.NodeTypeExpression || len(node.Body) != 1 {
+		return "", false
+	}
+	value := node.Body[0]
+	if value.Type != astnode.NodeTypeValue || value.Kind != "STRING" || value.Flags.Contains("TEMPLATE") {
+		return "", false
+	}
+	s, ok := value.Value.(string)
+	return s, ok
+}
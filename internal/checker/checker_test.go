@@ -0,0 +1,39 @@
+package checker_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/checker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.nubo")
+	err := os.WriteFile(path, []byte(`fn add(a: int, b: int) int {
+	return a + b
+	println("never")
+}
+
+let s: string = "x"
+let x: int = add(1, s)
+add(1, 2, 3)
+println(missing)
+`), 0o644)
+	assert.NoError(t, err)
+
+	ch := checker.New()
+	assert.NoError(t, ch.CheckFile(path))
+
+	var messages []string
+	for _, diagnostic := range ch.Diagnostics() {
+		messages = append(messages, diagnostic.GetMessage(false))
+	}
+	assert.Len(t, messages, 4)
+	assert.Contains(t, messages[0], "unreachable code")
+	assert.Contains(t, messages[1], "argument 2 (b) expected type int, got string")
+	assert.Contains(t, messages[2], "expected 2 arguments, got 3")
+	assert.Contains(t, messages[3], "undefined variable 'missing'")
+}
@@ -0,0 +1,621 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// typeOf returns the static type of a value node. expected is the declared
+// type of the destination, used for empty list and dict literals.
+func (f *file) typeOf(sc *scope, node *astnode.Node, expected *language.Type) *language.Type {
+	if node == nil {
+		return anyType()
+	}
+
+	switch node.Type {
+	case astnode.NodeTypeElement:
+		f.element(sc, node)
+		return language.TypeHtml
+	case astnode.NodeTypeList:
+		return f.list(sc, node, expected)
+	case astnode.NodeTypeDict:
+		return f.dict(sc, node, expected)
+	case astnode.NodeTypeInclude:
+		f.handleInclude(sc, node)
+		return anyType()
+	case astnode.NodeTypeSpawn:
+		for _, child := range node.Children {
+			f.typeOf(sc, child, nil)
+		}
+		return anyType()
+	}
+
+	if node.Body == nil {
+		return language.TypeNil
+	}
+	if len(node.Body) == 1 {
+		return f.single(sc, node.Body[0], expected)
+	}
+	return f.expression(sc, node)
+}
+
+func (f *file) single(sc *scope, node *astnode.Node, expected *language.Type) *language.Type {
+	switch node.Type {
+	case astnode.NodeTypeValue, astnode.NodeTypeFunctionArgument:
+		if node.IsReference {
+			return f.reference(sc, node)
+		}
+		return literalType(node)
+	case astnode.NodeTypeFunctionCall:
+		return f.typeOfCall(sc, node)
+	case astnode.NodeTypeInlineFunction:
+		return sigType(f.handleFunction(sc, node))
+	case astnode.NodeTypeTemplateLiteral:
+		f.template(sc, node)
+		return language.TypeString
+	case astnode.NodeTypeElement, astnode.NodeTypeList, astnode.NodeTypeDict:
+		return f.typeOf(sc, node, expected)
+	}
+	return anyType()
+}
+
+func literalType(node *astnode.Node) *language.Type {
+	switch node.Kind {
+	case "INTEGER":
+		return language.TypeInt
+	case "FLOAT":
+		return language.TypeFloat
+	case "STRING":
+		return language.TypeString
+	case "BOOLEAN":
+		return language.TypeBool
+	case "NIL":
+		return language.TypeNil
+	}
+	return anyType()
+}
+
+func (f *file) reference(sc *scope, node *astnode.Node) *language.Type {
+	name, _ := node.Value.(string)
+
+	for _, access := range node.ArrayAccess {
+		if access.Type == astnode.NodeTypeExpression {
+			f.typeOf(sc, access, nil)
+		}
+	}
+
+	sym, ok := f.resolve(sc, name, node.Debug)
+	if !ok {
+		f.report(semanticError("undefined variable '%s'", name), node.Debug)
+		return anyType()
+	}
+
+	switch len(node.ArrayAccess) {
+	case 0:
+		return sym.typ
+	case 1:
+		if node.ArrayAccess[0].Type == astnode.NodeTypeExpression {
+			return elementType(sym.typ)
+		}
+	}
+	return anyType()
+}
+
+// resolve looks up a possibly dotted name. It returns false when the first
+// part of the name is not declared; unknown members are reported here and
+// resolve to any.
+func (f *file) resolve(sc *scope, name string, dg *debug.Debug) (*symbol, bool) {
+	parts := strings.Split(name, ".")
+
+	sym, ok := sc.lookup(parts[0])
+	if !ok {
+		// A file including a computed path may get any name from it.
+		if f.root.dynamic {
+			return &symbol{typ: anyType()}, true
+		}
+		return nil, false
+	}
+
+	for _, part := range parts[1:] {
+		next, ok := f.member(sym, part, dg)
+		if !ok {
+			return &symbol{typ: anyType()}, true
+		}
+		sym = next
+	}
+	return sym, true
+}
+
+// member returns the member name of sym. Missing members are reported when
+// the full set of members is known statically.
+func (f *file) member(sym *symbol, name string, dg *debug.Debug) (*symbol, bool) {
+	if sym.module != nil {
+		member, ok := sym.module.symbols[name]
+		if !ok {
+			f.report(semanticError("undefined variable '%s' in imported module", name), dg)
+		}
+		return member, ok
+	}
+
+	if sym.object != nil {
+		if proto := sym.object.GetPrototype(); proto != nil {
+			if obj, ok := proto.GetObject(context.Background(), name); ok {
+				return f.checker.objectSymbol(obj), true
+			}
+		}
+		if pkg, ok := sym.object.(*n.Package); ok {
+			f.report(semanticError("package %s has no member %q", pkg.Name, name), dg)
+		}
+		return nil, false
+	}
+
+	if sym.enum != nil {
+		variant, ok := sym.enum.variant(name)
+		if !ok {
+			f.report(typeError("enum %s has no variant %s", sym.enum.name, name), dg)
+			return nil, false
+		}
+		if len(variant.fields) == 0 {
+			return &symbol{typ: sym.enum.typ, constant: true}, true
+		}
+		sig := &signature{args: variant.fields, ret: sym.enum.typ}
+		return &symbol{typ: sigType(sig), constant: true, sig: sig}, true
+	}
+
+	typ := sym.typ
+	if typ == nil || typ.Next != nil {
+		return nil, false
+	}
+
+	if typ.BaseType == language.ObjectTypeEnum {
+		info, ok := f.checker.enums[typ.ID]
+		if !ok {
+			return nil, false
+		}
+		// Fields depend on the variant held at runtime.
+		for _, variant := range info.variants {
+			for _, field := range variant.fields {
+				if field.name == name {
+					return nil, false
+				}
+			}
+		}
+		f.report(semanticError("%s has no field %q", info.name, name), dg)
+		return nil, false
+	}
+
+	if typ.BaseType == language.ObjectTypeStructInstance {
+		info, ok := f.checker.structs[typ.ID]
+		if !ok {
+			return nil, false
+		}
+		if info.instance != nil {
+			return f.member(&symbol{object: info.instance}, name, dg)
+		}
+		if field, ok := info.fields[name]; ok {
+			return &symbol{typ: field}, true
+		}
+		if method, ok := info.methods[name]; ok {
+			return &symbol{typ: sigType(method), constant: true, sig: method}, true
+		}
+		if _, ok := info.methods["__get__"]; ok {
+			return nil, false
+		}
+		f.report(semanticError("%s has no field or method %q", info.name, name), dg)
+		return nil, false
+	}
+
+	if !definite(typ) {
+		return nil, false
+	}
+	switch typ.BaseType {
+	case language.ObjectTypeString, language.ObjectTypeList, language.ObjectTypeDict,
+		language.ObjectTypeInt, language.ObjectTypeFloat, language.ObjectTypeBool:
+		return f.member(&symbol{object: language.DefaultValue(typ)}, name, dg)
+	}
+	return nil, false
+}
+
+func (f *file) typeOfCall(sc *scope, node *astnode.Node) *language.Type {
+	args := make([]*language.Type, len(node.Args))
+	for i, arg := range node.Args {
+		args[i] = f.typeOf(sc, arg, nil)
+	}
+
+	if node.Content == "xdbg" {
+		return anyType()
+	}
+
+	sym, ok := f.resolve(sc, node.Content, node.Debug)
+	if !ok {
+		f.report(semanticError("undefined function: %s(...)", node.Content), node.Debug)
+		return anyType()
+	}
+
+	var ret *language.Type
+	switch {
+	case sym.strct != nil:
+		ret = sym.strct.typ
+		if init, ok := sym.strct.methods["init"]; ok {
+			f.checkArgs(node, init, node.Args, args)
+		}
+	case sym.sig != nil && !sym.event:
+		ret = f.checkArgs(node, sym.sig, node.Args, args)
+	case sym.typ.Base() == language.ObjectTypeFunction && sym.typ.Next == nil:
+		ret = sym.typ.Value
+		if ret == nil {
+			ret = anyType()
+		}
+	case definite(sym.typ) && sym.typ.BaseType != language.ObjectTypeStructDefinition:
+		f.report(typeError("expected function, got %s", sym.typ), node.Debug)
+		ret = anyType()
+	default:
+		ret = anyType()
+	}
+
+	if len(node.Children) > 0 {
+		return anyType()
+	}
+	return ret
+}
+
+// checkArgs compares the arguments of a call with sig and returns the type
+// of the result.
+func (f *file) checkArgs(node *astnode.Node, sig *signature, args []*astnode.Node, types []*language.Type) *language.Type {
+	var (
+		positional int
+		named      = make(map[string]struct{})
+		typeArgs   = make(map[string]*language.Type)
+	)
+
+	var (
+		variadic = len(sig.args) > 0 && sig.args[len(sig.args)-1].variadic
+		spread   bool
+	)
+
+	check := func(inx, pos int, arg *astnode.Node, got *language.Type) {
+		p := sig.args[inx]
+		want := p.typ
+		if p.variadic && !arg.Flags.Contains("SPREAD") {
+			want = language.VariadicElement(p.typ)
+		}
+		if want.HasParams() {
+			want.Infer(got, typeArgs)
+			return
+		}
+		if !assignable(want, got) {
+			f.report(typeError("argument %d (%s) expected type %s, got %s", pos+1, p.name, want, got), arg.Debug)
+		}
+	}
+
+	for i, arg := range args {
+		if arg.Kind == "NAMED_ARG" {
+			found := false
+			for inx, p := range sig.args {
+				if p.name == arg.ArgName {
+					check(inx, inx, arg, types[i])
+					named[p.name] = struct{}{}
+					found = true
+					break
+				}
+			}
+			if !found {
+				f.report(typeError("unknown argument %q", arg.ArgName), arg.Debug)
+			}
+			continue
+		}
+
+		if arg.Flags.Contains("SPREAD") {
+			// The number of spread values is only known at runtime.
+			spread = true
+			f.spread(arg, types[i])
+			if positional < len(sig.args) && sig.args[positional].variadic && types[i].Base() == language.ObjectTypeList {
+				check(positional, positional, arg, types[i])
+			}
+			continue
+		}
+
+		inx := positional
+		if variadic && inx >= len(sig.args) {
+			inx = len(sig.args) - 1
+		}
+		if inx < len(sig.args) {
+			check(inx, positional, arg, types[i])
+		}
+		positional++
+	}
+
+	if positional > len(sig.args) && !variadic && !spread {
+		f.report(typeError("expected %d arguments, got %d", len(sig.args), positional), node.Debug)
+	}
+
+	for inx := positional; inx < len(sig.args) && !spread; inx++ {
+		p := sig.args[inx]
+		if _, ok := named[p.name]; !ok && !p.optional {
+			f.report(typeError("missing required argument %d (%s)", inx+1, p.name), node.Debug)
+		}
+	}
+
+	if sig.ret == nil {
+		return language.TypeVoid
+	}
+	if len(typeArgs) > 0 {
+		return sig.ret.Substitute(typeArgs)
+	}
+	return sig.ret
+}
+
+func (f *file) template(sc *scope, node *astnode.Node) {
+	for _, child := range node.Children {
+		if value, ok := child.Value.(*astnode.Node); ok {
+			f.typeOf(sc, value, nil)
+		}
+	}
+}
+
+func (f *file) element(sc *scope, node *astnode.Node) {
+	for _, arg := range node.Args {
+		if value, ok := arg.Value.(*astnode.Node); ok && arg.Kind == "DYNAMIC" {
+			f.typeOf(sc, value, nil)
+		}
+	}
+
+	for _, child := range node.Children {
+		switch child.Type {
+		case astnode.NodeTypeElement:
+			f.element(sc, child)
+		case astnode.NodeTypeElementDynamicText:
+			if value, ok := child.Value.(*astnode.Node); ok {
+				f.typeOf(sc, value, nil)
+			}
+		}
+	}
+}
+
+func (f *file) list(sc *scope, node *astnode.Node, expected *language.Type) *language.Type {
+	var elem *language.Type
+	for _, child := range node.Children {
+		typ := f.typeOf(sc, child, nil)
+		if child.Flags.Contains("SPREAD") {
+			typ = f.spread(child, typ)
+		}
+		if elem == nil {
+			elem = typ
+		} else if !elem.Compare(typ) {
+			elem = anyType()
+		}
+	}
+
+	if elem == nil {
+		elem = anyType()
+		if expected != nil && expected.Next == nil && expected.BaseType == language.ObjectTypeList {
+			elem = expected.Element
+		}
+	}
+	return language.NewListType(elem)
+}
+
+func (f *file) dict(sc *scope, node *astnode.Node, expected *language.Type) *language.Type {
+	var key, value *language.Type
+	if expected != nil && expected.Next == nil && expected.BaseType == language.ObjectTypeDict {
+		key, value = expected.Key, expected.Value
+	}
+	if node.ValueType != nil {
+		typ := f.resolveType(sc, node.ValueType)
+		key, value = typ.Key, typ.Value
+	}
+
+	var inferredKey, inferredValue *language.Type
+	for _, pair := range node.Children {
+		if len(pair.Children) != 1 {
+			continue
+		}
+
+		var k, v *language.Type
+		if pair.Flags.Contains("SPREAD") {
+			k, v = f.spreadDict(pair.Children[0], f.typeOf(sc, pair.Children[0], nil))
+		} else {
+			if keyNode, ok := pair.Value.(*astnode.Node); ok {
+				k = f.typeOf(sc, keyNode, nil)
+			}
+			v = f.typeOf(sc, pair.Children[0], nil)
+		}
+
+		if key != nil && !assignable(key, k) {
+			f.report(typeError("dict key expected type %s, got %s", key, k), pair.Debug)
+		}
+		if value != nil && !assignable(value, v) {
+			f.report(typeError("dict value expected type %s, got %s", value, v), pair.Debug)
+		}
+
+		if inferredKey == nil {
+			inferredKey = k
+		} else if k == nil || !inferredKey.Compare(k) {
+			inferredKey = anyType()
+		}
+		if inferredValue == nil {
+			inferredValue = v
+		} else if !inferredValue.Compare(v) {
+			inferredValue = anyType()
+		}
+	}
+
+	if key == nil {
+		key = inferredKey
+	}
+	if value == nil {
+		value = inferredValue
+	}
+	if key == nil {
+		key = anyType()
+	}
+	if value == nil {
+		value = anyType()
+	}
+	return language.NewDictType(key, value)
+}
+
+var mismatchRe = regexp.MustCompile(`mismatched types ([a-z0-9]+) and ([a-z0-9]+)`)
+
+// expression types an infix expression by compiling it with expr, the same
+// engine the interpreter evaluates it with, using placeholders of the static
+// operand types.
+func (f *file) expression(sc *scope, node *astnode.Node) *language.Type {
+	var (
+		sb  strings.Builder
+		env = make(map[string]any)
+	)
+
+	for inx, child := range node.Body {
+		id := "var_" + strconv.Itoa(inx)
+
+		switch child.Type {
+		case astnode.NodeTypeValue, astnode.NodeTypeFunctionArgument:
+			if child.IsReference {
+				sb.WriteString(id + "()")
+				env[id] = placeholder(f.reference(sc, child))
+			} else {
+				sb.WriteString(id)
+				env[id] = child.Value
+			}
+		case astnode.NodeTypeOperator:
+			sb.WriteString(child.Kind)
+		case astnode.NodeTypeFunctionCall:
+			sb.WriteString(id + "()")
+			env[id] = placeholder(f.typeOfCall(sc, child))
+		case astnode.NodeTypeTemplateLiteral:
+			f.template(sc, child)
+			sb.WriteString(id + "()")
+			env[id] = placeholder(language.TypeString)
+		case astnode.NodeTypeInlineFunction, astnode.NodeTypeElement:
+			f.single(sc, child, nil)
+			f.report(typeError("cannot operate on type '%s'", humanNode(child)), node.Debug)
+			return anyType()
+		default:
+			if s, ok := child.Value.(string); ok {
+				sb.WriteString(s)
+			}
+		}
+	}
+
+	program, err := expr.Compile(sb.String(), expr.Env(env))
+	if err != nil {
+		if m := mismatchRe.FindStringSubmatch(err.Error()); len(m) == 3 && m[1] != "nil" && m[2] != "nil" {
+			f.report(typeError("failed to evaluate expression: %s (type mismatch: %s and %s)", humanExpr(node.Body), goTypeName(m[1]), goTypeName(m[2])), node.Debug)
+		}
+		return anyType()
+	}
+
+	switch program.Node().Type().Kind() {
+	case reflect.Bool:
+		return language.TypeBool
+	case reflect.String:
+		return language.TypeString
+	case reflect.Float32, reflect.Float64:
+		return language.TypeFloat
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return language.TypeInt
+	}
+	return anyType()
+}
+
+var anyReflectType = reflect.TypeOf((*any)(nil)).Elem()
+
+// placeholder returns a function standing in for a value of type t.
+func placeholder(t *language.Type) any {
+	rt := anyReflectType
+	if t != nil && t.Next == nil {
+		switch t.BaseType {
+		case language.ObjectTypeInt:
+			rt = reflect.TypeOf(int64(0))
+		case language.ObjectTypeFloat:
+			rt = reflect.TypeOf(float64(0))
+		case language.ObjectTypeString:
+			rt = reflect.TypeOf("")
+		case language.ObjectTypeBool:
+			rt = reflect.TypeOf(false)
+		}
+	}
+
+	fnType := reflect.FuncOf(nil, []reflect.Type{rt}, false)
+	return reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.Zero(rt)}
+	}).Interface()
+}
+
+func goTypeName(name string) string {
+	switch name {
+	case "int", "int64":
+		return "int"
+	case "float64":
+		return "float"
+	}
+	return name
+}
+
+func humanExpr(nodes []*astnode.Node) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = humanNode(node)
+	}
+	return strings.Join(parts, " ")
+}
+
+func humanNode(node *astnode.Node) string {
+	switch node.Type {
+	case astnode.NodeTypeValue:
+		if node.Kind == "STRING" {
+			return strconv.Quote(fmt.Sprint(node.Value))
+		}
+		return fmt.Sprint(node.Value)
+	case astnode.NodeTypeFunctionCall:
+		args := make([]string, len(node.Args))
+		for i, arg := range node.Args {
+			args[i] = humanNode(arg)
+		}
+		return fmt.Sprintf("%s(%s)", node.Content, strings.Join(args, ", "))
+	case astnode.NodeTypeExpression:
+		return humanExpr(node.Body)
+	case astnode.NodeTypeOperator:
+		return node.Kind
+	case astnode.NodeTypeInlineFunction:
+		return "<inline function>"
+	case astnode.NodeTypeElement:
+		return "<element>"
+	}
+	return ""
+}
+
+// spread checks that a value spread by node, e.g. ...args, is a list and
+// returns the type of its items.
+func (f *file) spread(node *astnode.Node, typ *language.Type) *language.Type {
+	if typ.Next == nil && typ.BaseType == language.ObjectTypeList {
+		return elementType(typ)
+	}
+	if definite(typ) {
+		f.report(typeError("cannot spread %s, expected a list", typ), node.Debug)
+	}
+	return anyType()
+}
+
+// spreadDict checks that a value spread into a dict literal is a dict and
+// returns the types of its keys and values.
+func (f *file) spreadDict(node *astnode.Node, typ *language.Type) (*language.Type, *language.Type) {
+	if typ.Next == nil && typ.BaseType == language.ObjectTypeDict && typ.Key != nil && typ.Value != nil {
+		return typ.Key, typ.Value
+	}
+	if definite(typ) {
+		f.report(typeError("cannot spread %s into a dict", typ), node.Debug)
+	}
+	return anyType(), anyType()
+}
@@ -0,0 +1,101 @@
+package checker
+
+import (
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/language"
+)
+
+// declarePattern declares the names bound by a destructuring pattern
+// matched against a value of type typ.
+func (f *file) declarePattern(sc *scope, pattern *astnode.Node, typ *language.Type, constant bool) {
+	if typ == nil {
+		typ = anyType()
+	}
+
+	switch pattern.Kind {
+	case "LIST":
+		element := anyType()
+		switch {
+		case typ.Next == nil && typ.BaseType == language.ObjectTypeList:
+			element = elementType(typ)
+		case definite(typ):
+			f.report(typeError("cannot destructure %s as a list", typ), pattern.Debug)
+		}
+
+		for _, child := range pattern.Children {
+			if child.Flags.Contains("REST") {
+				f.declarePattern(sc, child, typ, constant)
+				continue
+			}
+			f.declarePattern(sc, child, f.patternDefault(sc, child, element), constant)
+		}
+
+	case "DICT":
+		switch {
+		case typ.Next != nil:
+		case typ.BaseType == language.ObjectTypeDict, typ.BaseType == language.ObjectTypeStructInstance, typ.BaseType == language.ObjectTypeEnum:
+		case definite(typ):
+			f.report(typeError("cannot destructure %s as a dict", typ), pattern.Debug)
+			typ = anyType()
+		}
+
+		rest := language.NewDictType(language.TypeString, language.TypeAny)
+		if typ.Next == nil && typ.BaseType == language.ObjectTypeDict {
+			rest = typ
+		}
+
+		for _, child := range pattern.Children {
+			if child.Flags.Contains("REST") {
+				f.declarePattern(sc, child, rest, constant)
+				continue
+			}
+			f.declarePattern(sc, child, f.patternDefault(sc, child, f.patternField(child, typ)), constant)
+		}
+
+	default:
+		if pattern.Content != "_" {
+			sc.declare(pattern.Content, &symbol{typ: typ, constant: constant})
+		}
+	}
+}
+
+// patternField returns the type of the field bound by child when
+// destructuring a value of type typ as a dict.
+func (f *file) patternField(child *astnode.Node, typ *language.Type) *language.Type {
+	if typ.Next != nil {
+		return anyType()
+	}
+
+	switch typ.BaseType {
+	case language.ObjectTypeDict:
+		if typ.Value != nil {
+			return typ.Value
+		}
+	case language.ObjectTypeStructInstance:
+		info, ok := f.checker.structs[typ.ID]
+		if !ok || info.instance != nil {
+			break
+		}
+		if field, ok := info.fields[child.ArgName]; ok {
+			return field
+		}
+		if child.FallbackValue == nil {
+			f.report(semanticError("%s has no field %q", info.name, child.ArgName), child.Debug)
+		}
+	}
+	return anyType()
+}
+
+// patternDefault checks the default value of child and widens typ to
+// include it.
+func (f *file) patternDefault(sc *scope, child *astnode.Node, typ *language.Type) *language.Type {
+	if child.FallbackValue == nil {
+		return typ
+	}
+
+	fallback := f.typeOf(sc, child.FallbackValue, nil)
+	if definite(typ) && !assignable(typ, fallback) {
+		return anyType()
+	}
+	return typ
+}
@@ -0,0 +1,171 @@
+package checker
+
+import (
+	"context"
+
+	"github.com/nubolang/nubo/language"
+)
+
+// symbol is the static view of a declared name.
+type symbol struct {
+	typ      *language.Type
+	constant bool
+
+	sig    *signature      // set for callables with a known signature
+	object language.Object // set for builtins and package members
+	module *scope          // set for imported Nubo files
+	strct  *structInfo     // set for struct definitions
+	enum   *enumInfo       // set for enum definitions
+	alias  *language.Type  // set for type aliases
+	event  bool            // set for events
+}
+
+// signature describes the arguments and the return type of a callable.
+type signature struct {
+	args []param
+	ret  *language.Type
+}
+
+type param struct {
+	name     string
+	typ      *language.Type
+	optional bool
+	variadic bool
+}
+
+// structInfo describes a struct. Structs declared in checked source list
+// their fields and methods; native structs keep an instance whose prototype
+// is queried instead.
+type structInfo struct {
+	name     string
+	typ      *language.Type
+	fields   map[string]*language.Type
+	methods  map[string]*signature
+	instance language.Object
+}
+
+// enumInfo describes an enum declared in checked source.
+type enumInfo struct {
+	name     string
+	typ      *language.Type
+	variants []*variantInfo
+}
+
+type variantInfo struct {
+	name   string
+	fields []param
+}
+
+func (e *enumInfo) variant(name string) (*variantInfo, bool) {
+	for _, variant := range e.variants {
+		if variant.name == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+type scope struct {
+	parent  *scope
+	symbols map[string]*symbol
+
+	// fn is the signature of the enclosing function, nil at file level.
+	fn *signature
+	// loop is true inside for and while bodies.
+	loop bool
+	// pending holds function bodies checked once the block is done, so
+	// they can refer to names declared after them.
+	pending []func()
+	// includes are the top level scopes of included files, whose names
+	// are visible from the including file.
+	includes []*scope
+	// dynamic is set once a file includes a path that is only known at
+	// runtime.
+	dynamic bool
+}
+
+func newScope(parent *scope) *scope {
+	sc := &scope{
+		parent:  parent,
+		symbols: make(map[string]*symbol),
+	}
+	if parent != nil {
+		sc.fn = parent.fn
+		sc.loop = parent.loop
+	}
+	return sc
+}
+
+func (s *scope) declare(name string, sym *symbol) {
+	s.symbols[name] = sym
+}
+
+func (s *scope) lookup(name string) (*symbol, bool) {
+	for sc := s; sc != nil; sc = sc.parent {
+		if sym, ok := sc.symbols[name]; ok {
+			return sym, true
+		}
+		for _, inc := range sc.includes {
+			if sym, ok := inc.symbols[name]; ok {
+				return sym, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// objectSymbol creates a symbol from a runtime object such as a builtin or a
+// package member.
+func (c *Checker) objectSymbol(obj language.Object) *symbol {
+	sym := &symbol{
+		typ:      obj.Type().DeepClone(),
+		constant: true,
+		object:   obj,
+	}
+
+	switch value := obj.(type) {
+	case *language.Function:
+		sym.sig = functionSignature(value)
+	case *language.Struct:
+		instance, err := value.NewInstance()
+		if err != nil {
+			break
+		}
+		info := &structInfo{
+			name:     value.Name,
+			typ:      instance.Type().DeepClone(),
+			methods:  make(map[string]*signature),
+			instance: instance,
+		}
+		if init, ok := instance.GetPrototype().GetObject(context.Background(), "init"); ok {
+			if fn, ok := init.(*language.Function); ok {
+				if sig := functionSignature(fn); sig != nil {
+					info.methods["init"] = sig
+				}
+			}
+		}
+		c.structs[info.typ.ID] = info
+		sym.strct = info
+	}
+
+	return sym
+}
+
+// functionSignature returns the signature of a typed function, or nil for
+// functions accepting any arguments.
+func functionSignature(fn *language.Function) *signature {
+	if fn.ArgTypes == nil {
+		return nil
+	}
+
+	sig := &signature{ret: fn.ReturnType}
+	for _, arg := range fn.ArgTypes {
+		sig.args = append(sig.args, param{
+			name:     arg.Name(),
+			typ:      arg.Type(),
+			optional: arg.Default() != nil || language.IsVariadic(arg),
+			variadic: language.IsVariadic(arg),
+		})
+	}
+	return sig
+}
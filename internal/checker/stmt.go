@@ -0,0 +1,537 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/language"
+)
+
+// file checks the nodes of a single source file.
+type file struct {
+	checker *Checker
+	path    string
+	root    *scope
+	structs int
+
+	// pos is the position of the statement being checked, used for nodes
+	// the parser leaves without one.
+	pos *debug.Debug
+}
+
+func (f *file) report(excp *exception.Expection, dg *debug.Debug) {
+	if dg == nil {
+		dg = f.pos
+	}
+	f.checker.report(excp.WithDebug(dg))
+}
+
+func (f *file) block(sc *scope, nodes []*astnode.Node) {
+	var terminated, reported bool
+
+	for _, node := range nodes {
+		if terminated && !reported {
+			f.report(semanticError("unreachable code"), node.Debug)
+			reported = true
+		}
+
+		f.statement(sc, node)
+
+		if node.Type == astnode.NodeTypeReturn || node.Type == astnode.NodeTypeSignal {
+			terminated = true
+		}
+	}
+
+	for len(sc.pending) > 0 {
+		pending := sc.pending
+		sc.pending = nil
+		for _, check := range pending {
+			check()
+		}
+	}
+}
+
+func (f *file) statement(sc *scope, node *astnode.Node) {
+	if node.Debug != nil {
+		f.pos = node.Debug
+	}
+
+	switch node.Type {
+	case astnode.NodeTypeImport:
+		f.handleImport(sc, node)
+	case astnode.NodeTypeInclude:
+		f.handleInclude(sc, node)
+	case astnode.NodeTypeVariableDecl:
+		f.handleVariableDecl(sc, node)
+	case astnode.NodeTypeAssign:
+		f.handleAssignment(sc, node)
+	case astnode.NodeTypeFunctionCall:
+		f.typeOfCall(sc, node)
+	case astnode.NodeTypeEvent:
+		f.handleEvent(sc, node)
+	case astnode.NodeTypeSubscribe:
+		f.handleSubscribe(sc, node)
+	case astnode.NodeTypePublish:
+		f.handlePublish(sc, node)
+	case astnode.NodeTypeFunction:
+		sig := f.handleFunction(sc, node)
+		sc.declare(node.Content, &symbol{typ: sigType(sig), constant: true, sig: sig})
+	case astnode.NodeTypeWhile:
+		f.condition(sc, node)
+		body := newScope(sc)
+		body.loop = true
+		f.block(body, node.Body)
+	case astnode.NodeTypeIncrement, astnode.NodeTypeDecrement:
+		if _, ok := f.resolve(sc, node.Content, node.Debug); !ok {
+			f.report(semanticError("variable %q not declared", node.Content), node.Debug)
+		}
+	case astnode.NodeTypeIf:
+		f.condition(sc, node)
+		f.block(newScope(sc), node.Body)
+		if len(node.Children) > 0 {
+			f.block(newScope(sc), node.Children)
+		}
+	case astnode.NodeTypeReturn:
+		f.handleReturn(sc, node)
+	case astnode.NodeTypeFor:
+		f.handleFor(sc, node)
+	case astnode.NodeTypeStruct:
+		f.handleStruct(sc, node)
+	case astnode.NodeTypeEnum:
+		f.handleEnum(sc, node)
+	case astnode.NodeTypeMatch:
+		f.handleMatch(sc, node)
+	case astnode.NodeTypeImpl:
+		f.handleImpl(sc, node)
+	case astnode.NodeTypeTry:
+		f.block(newScope(sc), node.Body)
+		sc.declare(node.Content, &symbol{typ: anyType()})
+	case astnode.NodeTypeDefer, astnode.NodeTypeSpawn:
+		for _, child := range node.Children {
+			f.typeOf(sc, child, nil)
+		}
+	case astnode.NodeTypeBlock:
+		f.block(newScope(sc), node.Body)
+	case astnode.NodeTypeTypeKW:
+		f.handleTypeDecl(sc, node)
+	case astnode.NodeTypeSignal:
+		if !sc.loop {
+			f.report(semanticError("%s can only be used within a for or while loop", node.Content), node.Debug)
+		}
+	}
+}
+
+func (f *file) condition(sc *scope, node *astnode.Node) {
+	if len(node.Args) > 0 {
+		f.typeOf(sc, node.Args[0], nil)
+	}
+}
+
+// valueNode returns the node holding the value of a declaration or an
+// assignment.
+func valueNode(node *astnode.Node) *astnode.Node {
+	if node.Flags.Contains("NODEVALUE") {
+		if value, ok := node.Value.(*astnode.Node); ok {
+			return value
+		}
+	}
+	return node
+}
+
+func (f *file) handleVariableDecl(sc *scope, node *astnode.Node) {
+	var typ *language.Type
+	if node.ValueType != nil {
+		typ = f.resolveType(sc, node.ValueType)
+	}
+
+	value := f.typeOf(sc, valueNode(node), typ)
+	if value != nil && value.BaseType == language.ObjectTypeVoid {
+		f.report(typeError("void is not assignable to a variable"), node.Debug)
+		value = anyType()
+	}
+
+	if typ == nil {
+		typ = value
+	} else if !assignable(typ, value) {
+		f.report(typeError("expected %s, got %s", typ, value), node.Debug)
+	}
+
+	if len(node.Args) > 0 {
+		f.declarePattern(sc, node.Args[0], typ, node.Kind == "CONST")
+		return
+	}
+	sc.declare(node.Content, &symbol{typ: typ, constant: node.Kind == "CONST"})
+}
+
+func (f *file) handleAssignment(sc *scope, node *astnode.Node) {
+	value := f.typeOf(sc, valueNode(node), nil)
+
+	dg := node.Debug
+	if dg == nil {
+		dg = valueNode(node).Debug
+	}
+
+	for _, access := range node.ArrayAccess {
+		f.typeOf(sc, access, nil)
+	}
+
+	sym, ok := f.resolve(sc, node.Content, dg)
+	if !ok {
+		f.report(semanticError("undefined variable '%s'", node.Content), dg)
+		return
+	}
+	if len(node.ArrayAccess) > 0 {
+		return
+	}
+
+	if sym.constant && !strings.Contains(node.Content, ".") {
+		f.report(semanticError("cannot reassign constant %q", node.Content), dg)
+		return
+	}
+
+	if !assignable(sym.typ, value) {
+		f.report(typeError("type mismatch: expected '%s', got '%s'", sym.typ, value), dg)
+	}
+}
+
+// handleFunction declares the signature of a function and schedules the
+// check of its body.
+func (f *file) handleFunction(sc *scope, node *astnode.Node) *signature {
+	params := f.typeParams(sc, node.TypeParams)
+
+	sig := &signature{ret: language.NewUnionType(language.TypeAny, language.TypeVoid)}
+	if node.ValueType != nil {
+		sig.ret = f.resolveType(params, node.ValueType)
+	}
+
+	for _, arg := range node.Args {
+		p := param{name: arg.Content, typ: f.resolveType(params, arg.ValueType)}
+		if arg.Flags.Contains("VARIADIC") {
+			p.variadic, p.optional = true, true
+			if arg.ValueType == nil {
+				p.typ = language.NewListType(language.TypeAny)
+			} else if definite(p.typ) && p.typ.BaseType != language.ObjectTypeList {
+				f.report(typeError("variadic argument %s must be a list, got %s", arg.Content, p.typ), arg.Debug)
+			}
+		}
+		if arg.FallbackValue != nil {
+			fallback := f.typeOf(params, arg.FallbackValue, nil)
+			if arg.ValueType == nil {
+				p.typ = fallback
+			} else if !assignable(p.typ, fallback) {
+				f.report(typeError("expected %s but got %s", p.typ, fallback), arg.Debug)
+			}
+			p.optional = true
+		}
+		sig.args = append(sig.args, p)
+	}
+
+	sc.pending = append(sc.pending, func() {
+		f.pos = node.Debug
+		body := newScope(params)
+		body.fn = sig
+		body.loop = false
+		for j, arg := range sig.args {
+			if pattern := node.Args[j]; len(pattern.Args) > 0 {
+				f.declarePattern(body, pattern.Args[0], arg.typ, false)
+				continue
+			}
+			body.declare(arg.name, &symbol{typ: arg.typ})
+		}
+		f.block(body, node.Body)
+	})
+
+	return sig
+}
+
+// typeParams returns a scope declaring the type parameters of a generic
+// declaration.
+func (f *file) typeParams(sc *scope, nodes []*astnode.Node) *scope {
+	if len(nodes) == 0 {
+		return sc
+	}
+
+	params := newScope(sc)
+	for _, node := range nodes {
+		params.declare(node.Content, &symbol{typ: language.TypeTypeObj, alias: language.NewTypeParam(node.Content)})
+	}
+	return params
+}
+
+func sigType(sig *signature) *language.Type {
+	args := make([]*language.Type, len(sig.args))
+	for i, arg := range sig.args {
+		args[i] = arg.typ
+	}
+	return language.NewFunctionType(sig.ret, args...)
+}
+
+func (f *file) handleReturn(sc *scope, node *astnode.Node) {
+	value := language.TypeVoid
+	if ret := valueNode(node); !ret.Flags.Contains("VOID") {
+		value = f.typeOf(sc, ret, nil)
+	}
+
+	if sc.fn == nil || sc.fn.ret == nil {
+		return
+	}
+
+	if !assignable(sc.fn.ret, value) {
+		dg := node.Debug
+		if dg == nil {
+			dg = valueNode(node).Debug
+		}
+		f.report(typeError("expected return type %s, got %s", sc.fn.ret, value), dg)
+	}
+}
+
+func (f *file) handleFor(sc *scope, node *astnode.Node) {
+	body := newScope(sc)
+	body.loop = true
+
+	var source *language.Type
+	if len(node.Args) > 0 {
+		source = f.typeOf(sc, node.Args[0], nil)
+	}
+
+	key, value := anyType(), anyType()
+	if source != nil && source.Next == nil {
+		switch source.BaseType {
+		case language.ObjectTypeList:
+			key, value = language.TypeInt, elementType(source)
+		case language.ObjectTypeDict:
+			key, value = source.Key, source.Value
+		}
+	}
+
+	if kv, ok := node.Value.(*astnode.ForValue); ok {
+		if kv.Iterator != nil {
+			if kv.Iterator.Type == astnode.NodeTypePattern {
+				f.declarePattern(body, kv.Iterator, key, false)
+			} else if name, ok := kv.Iterator.Value.(string); ok {
+				body.declare(name, &symbol{typ: key})
+			}
+		}
+		if kv.Value != nil {
+			if kv.Value.Type == astnode.NodeTypePattern {
+				f.declarePattern(body, kv.Value, value, false)
+			} else if name, ok := kv.Value.Value.(string); ok {
+				body.declare(name, &symbol{typ: value})
+			}
+		}
+	}
+
+	f.block(body, node.Body)
+}
+
+func (f *file) handleStruct(sc *scope, node *astnode.Node) {
+	f.structs++
+	id := fmt.Sprintf("%s#%d:%s", f.path, f.structs, node.Content)
+
+	info := &structInfo{
+		name:    node.Content,
+		typ:     &language.Type{BaseType: language.ObjectTypeStructInstance, Content: node.Content, ID: id},
+		fields:  make(map[string]*language.Type, len(node.Body)),
+		methods: make(map[string]*signature),
+	}
+	f.checker.structs[id] = info
+
+	sc.declare(node.Content, &symbol{
+		typ:      &language.Type{BaseType: language.ObjectTypeStructDefinition, Content: node.Content, ID: id},
+		constant: true,
+		strct:    info,
+	})
+
+	params := f.typeParams(sc, node.TypeParams)
+	for _, field := range node.Body {
+		info.fields[field.Content] = f.resolveType(params, field.ValueType)
+	}
+}
+
+func (f *file) handleEnum(sc *scope, node *astnode.Node) {
+	f.structs++
+	id := fmt.Sprintf("%s#%d:%s", f.path, f.structs, node.Content)
+
+	info := &enumInfo{
+		name: node.Content,
+		typ:  &language.Type{BaseType: language.ObjectTypeEnum, Content: node.Content, ID: id},
+	}
+	f.checker.enums[id] = info
+
+	sc.declare(node.Content, &symbol{
+		typ:      &language.Type{BaseType: language.ObjectTypeEnumDefinition, Content: node.Content, ID: id},
+		constant: true,
+		enum:     info,
+	})
+
+	for _, variantNode := range node.Body {
+		if _, ok := info.variant(variantNode.Content); ok {
+			f.report(semanticError("duplicate variant %s in enum %s", variantNode.Content, node.Content), variantNode.Debug)
+			continue
+		}
+
+		variant := &variantInfo{name: variantNode.Content}
+		for _, field := range variantNode.Body {
+			variant.fields = append(variant.fields, param{name: field.Content, typ: f.resolveType(sc, field.ValueType)})
+		}
+		info.variants = append(info.variants, variant)
+	}
+}
+
+// handleMatch checks the arms of a match against the enum being matched and
+// reports the variants no arm covers.
+func (f *file) handleMatch(sc *scope, node *astnode.Node) {
+	var info *enumInfo
+	if len(node.Args) == 1 {
+		typ := f.typeOf(sc, node.Args[0], nil)
+		if typ != nil && typ.Next == nil && typ.BaseType == language.ObjectTypeEnum {
+			info = f.checker.enums[typ.ID]
+		} else if definite(typ) {
+			f.report(typeError("match expects an enum value, got %s", typ), node.Args[0].Debug)
+		}
+	}
+
+	var (
+		covered  = make(map[string]struct{})
+		wildcard bool
+	)
+	for _, arm := range node.Body {
+		armScope := newScope(sc)
+
+		var variant *variantInfo
+		switch {
+		case arm.Content == "_":
+			wildcard = true
+		case info == nil:
+		case arm.Kind != "" && arm.Kind != info.name:
+			f.report(typeError("expected a variant of %s, got %s.%s", info.name, arm.Kind, arm.Content), arm.Debug)
+		default:
+			var ok bool
+			variant, ok = info.variant(arm.Content)
+			if !ok {
+				f.report(typeError("enum %s has no variant %s", info.name, arm.Content), arm.Debug)
+				break
+			}
+			if _, ok := covered[variant.name]; ok {
+				f.report(semanticError("variant %s.%s is matched more than once", info.name, variant.name), arm.Debug)
+			}
+			covered[variant.name] = struct{}{}
+			if arm.Flags.Contains("FIELDS") && len(arm.Args) != len(variant.fields) {
+				f.report(typeError("variant %s.%s has %d fields, got %d bindings", info.name, variant.name, len(variant.fields), len(arm.Args)), arm.Debug)
+				variant = nil
+			}
+		}
+
+		for j, binding := range arm.Args {
+			typ := anyType()
+			if variant != nil {
+				typ = variant.fields[j].typ
+			}
+			armScope.declare(binding.Content, &symbol{typ: typ})
+		}
+		f.block(armScope, arm.Body)
+	}
+
+	if info == nil || wildcard {
+		return
+	}
+
+	var missing []string
+	for _, variant := range info.variants {
+		if _, ok := covered[variant.name]; !ok {
+			missing = append(missing, variant.name)
+		}
+	}
+	if len(missing) > 0 {
+		f.report(typeError("non-exhaustive match on %s: missing %s", info.name, strings.Join(missing, ", ")), node.Debug)
+	}
+}
+
+func (f *file) handleImpl(sc *scope, node *astnode.Node) {
+	sym, ok := sc.lookup(node.Content)
+	if !ok || sym.strct == nil || sym.strct.fields == nil {
+		f.report(semanticError("cannot implement object %q", node.Content), node.Debug)
+		return
+	}
+	info := sym.strct
+
+	for _, child := range node.Body {
+		sig := f.handleFunction(sc, child)
+
+		// Methods taking their own struct first receive it as self.
+		method := sig
+		if len(sig.args) > 0 && sig.args[0].typ.Base() != language.ObjectTypeAny && sig.args[0].typ.Compare(info.typ) {
+			method = &signature{args: sig.args[1:], ret: sig.ret}
+		}
+		info.methods[child.Content] = method
+	}
+}
+
+func (f *file) handleTypeDecl(sc *scope, node *astnode.Node) {
+	params := f.typeParams(sc, node.TypeParams)
+	sc.declare(node.Content, &symbol{typ: language.TypeTypeObj, constant: true, alias: f.resolveType(params, node.ValueType)})
+}
+
+func (f *file) handleEvent(sc *scope, node *astnode.Node) {
+	sig := &signature{ret: language.TypeVoid}
+	for _, arg := range node.Args {
+		sig.args = append(sig.args, param{name: arg.Content, typ: f.resolveType(sc, arg.ValueType)})
+	}
+	sc.declare(node.Content, &symbol{typ: anyType(), constant: true, sig: sig, event: true})
+}
+
+func (f *file) event(sc *scope, node *astnode.Node) *symbol {
+	sym, ok := f.resolve(sc, node.Content, node.Debug)
+	if !ok {
+		f.report(semanticError("undefined event %q", node.Content), node.Debug)
+		return nil
+	}
+	if !sym.event {
+		return nil
+	}
+	return sym
+}
+
+func (f *file) handleSubscribe(sc *scope, node *astnode.Node) {
+	sym := f.event(sc, node)
+
+	body := newScope(sc)
+	body.fn = nil
+	body.loop = false
+	for i, arg := range node.Args {
+		name := ""
+		if len(arg.Body) == 1 {
+			name, _ = arg.Body[0].Value.(string)
+		}
+		if name == "" {
+			continue
+		}
+
+		typ := anyType()
+		if sym != nil && i < len(sym.sig.args) {
+			typ = sym.sig.args[i].typ
+		}
+		body.declare(name, &symbol{typ: typ})
+	}
+
+	sc.pending = append(sc.pending, func() {
+		f.pos = node.Debug
+		f.block(body, node.Body)
+	})
+}
+
+func (f *file) handlePublish(sc *scope, node *astnode.Node) {
+	sym := f.event(sc, node)
+
+	args := make([]*language.Type, len(node.Args))
+	for i, arg := range node.Args {
+		args[i] = f.typeOf(sc, arg, nil)
+	}
+
+	if sym != nil {
+		f.checkArgs(node, sym.sig, node.Args, args)
+	}
+}
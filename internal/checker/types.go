@@ -0,0 +1,164 @@
+package checker
+
+import (
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/language"
+)
+
+func anyType() *language.Type {
+	return language.TypeAny.DeepClone()
+}
+
+// definite reports whether t is known well enough to report a mismatch
+// against it. Unions, type parameters and anything containing any are left
+// to the runtime.
+func definite(t *language.Type) bool {
+	if t == nil {
+		return true
+	}
+	if t.Next != nil || t.Param || t.BaseType == language.ObjectTypeAny || t.BaseType == language.ObjectTypeIface {
+		return false
+	}
+	if t.BaseType == language.ObjectTypeStructDefinition || t.BaseType == language.ObjectTypeStructInstance {
+		return true
+	}
+
+	for _, inner := range []*language.Type{t.Key, t.Value, t.Element} {
+		if inner != nil && !definite(inner) {
+			return false
+		}
+	}
+	for _, arg := range t.Args {
+		if !definite(arg) {
+			return false
+		}
+	}
+
+	switch t.BaseType {
+	case language.ObjectTypeList:
+		return t.Element != nil
+	case language.ObjectTypeDict:
+		return t.Key != nil && t.Value != nil
+	case language.ObjectTypeFunction:
+		return t.Value != nil
+	}
+	return true
+}
+
+// assignable reports whether a value of type got can be stored where want is
+// expected. Only definite types are compared.
+func assignable(want, got *language.Type) bool {
+	if want == nil || got == nil || !definite(got) {
+		return true
+	}
+	return want.Compare(got)
+}
+
+var basicTypes = map[string]*language.Type{
+	"void":   language.TypeVoid,
+	"int":    language.TypeInt,
+	"string": language.TypeString,
+	"bool":   language.TypeBool,
+	"float":  language.TypeFloat,
+	"byte":   language.TypeByte,
+	"char":   language.TypeChar,
+	"any":    language.TypeAny,
+	"html":   language.TypeHtml,
+	"nil":    language.TypeNil,
+	"number": language.TypeNumber,
+}
+
+// resolveType turns a type node into a type the same way the interpreter
+// does. Names it cannot resolve are reported and treated as any.
+func (f *file) resolveType(sc *scope, n *astnode.Node) *language.Type {
+	if n == nil {
+		return anyType()
+	}
+
+	t := &language.Type{Content: n.Content}
+
+	switch n.Kind {
+	case "LIST":
+		if len(n.Body) != 1 {
+			return anyType()
+		}
+		t.BaseType = language.ObjectTypeList
+		t.Element = f.resolveType(sc, n.Body[0])
+		return f.unionType(sc, t, n)
+	case "DICT":
+		if len(n.Body) != 2 {
+			return f.unionType(sc, language.TypeDict.DeepClone(), n)
+		}
+		t.BaseType = language.ObjectTypeDict
+		t.Key = f.resolveType(sc, n.Body[0])
+		t.Value = f.resolveType(sc, n.Body[1])
+		return f.unionType(sc, t, n)
+	case "FUNCTION":
+		t.BaseType = language.ObjectTypeFunction
+		t.Value = f.resolveType(sc, n.ValueType)
+		for _, arg := range n.Args {
+			t.Args = append(t.Args, f.resolveType(sc, arg))
+		}
+		return f.unionType(sc, t, n)
+	case "IFACE":
+		return f.unionType(sc, &language.Type{BaseType: language.ObjectTypeIface, Iface: &language.IfaceType{}}, n)
+	}
+
+	if basic, ok := basicTypes[n.Content]; ok {
+		t.BaseType = basic.Base()
+	} else {
+		t = f.namedType(sc, n)
+	}
+
+	if n.Flags.Contains("OPTIONAL") {
+		t = language.Nullable(t)
+	}
+	return f.unionType(sc, t, n)
+}
+
+func (f *file) namedType(sc *scope, n *astnode.Node) *language.Type {
+	sym, ok := f.resolve(sc, n.Content, n.Debug)
+	if !ok {
+		f.report(semanticError("unknown type: %q", n.Content), n.Debug)
+		return anyType()
+	}
+
+	switch {
+	case sym.alias != nil:
+		return sym.alias.DeepClone()
+	case sym.enum != nil:
+		return sym.enum.typ.DeepClone()
+	case sym.strct != nil:
+		t := sym.strct.typ.DeepClone()
+		for _, arg := range n.TypeParams {
+			t.Args = append(t.Args, f.resolveType(sc, arg))
+		}
+		return t
+	}
+	return anyType()
+}
+
+func (f *file) unionType(sc *scope, t *language.Type, n *astnode.Node) *language.Type {
+	if len(n.Children) > 0 {
+		return language.NewUnionType(t, f.resolveType(sc, n.Children[0]))
+	}
+	return t
+}
+
+// elementType returns the type produced by indexing a value of type t.
+func elementType(t *language.Type) *language.Type {
+	if t == nil || t.Next != nil {
+		return anyType()
+	}
+	switch t.BaseType {
+	case language.ObjectTypeList:
+		if t.Element != nil {
+			return t.Element
+		}
+	case language.ObjectTypeDict:
+		if t.Value != nil {
+			return t.Value
+		}
+	}
+	return anyType()
+}
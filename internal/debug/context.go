@@ -0,0 +1,20 @@
+package debug
+
+import "context"
+
+type fileKey struct{}
+
+// WithFile returns a copy of ctx that records file as the script the code
+// called with ctx is written in.
+func WithFile(ctx context.Context, file string) context.Context {
+	return context.WithValue(ctx, fileKey{}, file)
+}
+
+// FileFrom returns the script file stored in ctx by WithFile, or "".
+func FileFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	file, _ := ctx.Value(fileKey{}).(string)
+	return file
+}
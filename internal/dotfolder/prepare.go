@@ -48,26 +48,7 @@ func PrepareFiles(dir string, sameDir bool) error {
 			}
 		}
 
-		file, err := os.Open(path)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
-
-		lx, err := lexer.New(file, path)
-		if err != nil {
-			return err
-		}
-		tokens, err := lx.Parse()
-		if err != nil {
-			return err
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		parser := ast.New(ctx)
-		nodes, err := parser.Parse(tokens)
+		nodes, err := PrepareFile(path)
 		if err != nil {
 			return err
 		}
@@ -82,6 +63,30 @@ func PrepareFiles(dir string, sameDir bool) error {
 	})
 }
 
+// PrepareFile parses the Nubo source at path into its syntax tree, ready to
+// be gob-encoded as a .nuboc file.
+func PrepareFile(path string) ([]*astnode.Node, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lx, err := lexer.New(file, path)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := lx.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return ast.New(ctx).Parse(tokens)
+}
+
 func HasPrepared(file string) ([]*astnode.Node, bool) {
 	dir := filepath.Dir(file)
 	base := strings.TrimSuffix(filepath.Base(file), ".nubo")
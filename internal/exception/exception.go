@@ -48,7 +48,9 @@ func Create(format string, args ...any) *Expection {
 func From(err error, dg *debug.Debug, otherwise ...string) *Expection {
 	var exception *Expection
 	if errors.As(err, &exception) {
-		return exception.WithTrace(dg)
+		// Exceptions raised without a location take the first one they
+		// pass through.
+		return exception.WithDebug(dg)
 	}
 
 	if len(otherwise) > 0 {
@@ -0,0 +1,31 @@
+package exception
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromLocation(t *testing.T) {
+	raised := &debug.Debug{File: "a.nubo", Line: 3}
+	caller := &debug.Debug{File: "a.nubo", Line: 7}
+
+	// An exception raised without a location takes the first one.
+	e := From(Create("failed"), caller)
+	assert.Same(t, caller, e.debug)
+	assert.Empty(t, e.trace)
+
+	// A located exception keeps its location and traces the caller.
+	e = From(Create("failed").WithDebug(raised), caller)
+	assert.Same(t, raised, e.debug)
+	assert.Equal(t, []*debug.Debug{caller}, e.trace)
+
+	// Wrapped exceptions are found, plain errors get a new exception.
+	e = From(fmt.Errorf("call: %w", Create("failed")), caller)
+	assert.Same(t, caller, e.debug)
+	e = From(fmt.Errorf("plain"), caller)
+	assert.Equal(t, "plain", e.msg)
+	assert.Same(t, caller, e.debug)
+}
@@ -0,0 +1,186 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/language"
+	"go.uber.org/zap"
+)
+
+// destructure binds the names of pattern to the matching parts of value. It
+// declares the names in i, or assigns them when assign is set.
+func (i *Interpreter) destructure(pattern *astnode.Node, value language.Object, mutable bool, assign bool) error {
+	switch pattern.Kind {
+	case "LIST":
+		return i.destructureList(pattern, value, mutable, assign)
+	case "DICT":
+		return i.destructureDict(pattern, value, mutable, assign)
+	}
+
+	if pattern.Content == "_" {
+		return nil
+	}
+
+	zap.L().Debug("interpreter.destructure.bind", zap.Uint("id", i.ID), zap.String("name", pattern.Content), zap.Bool("assign", assign))
+
+	if assign {
+		if err := i.Assign(pattern.Content, value); err != nil {
+			return wrapRunExc(err, pattern.Debug)
+		}
+		return nil
+	}
+
+	if err := i.Declare(pattern.Content, value.Clone(), value.Type(), mutable); err != nil {
+		return wrapRunExc(err, pattern.Debug)
+	}
+	return nil
+}
+
+// destructureElement binds child to value, falling back to the default of
+// child when the value is missing.
+func (i *Interpreter) destructureElement(child *astnode.Node, value language.Object, missing string, mutable bool, assign bool) error {
+	if value == nil {
+		if child.FallbackValue == nil {
+			return runExc("cannot destructure: %s", missing).WithDebug(child.Debug)
+		}
+
+		fallback, err := i.eval(child.FallbackValue)
+		if err != nil {
+			return wrapRunExc(err, child.FallbackValue.Debug)
+		}
+		value = fallback
+	}
+
+	return i.destructure(child, value, mutable, assign)
+}
+
+func (i *Interpreter) destructureList(pattern *astnode.Node, value language.Object, mutable bool, assign bool) error {
+	list, ok := value.(*language.List)
+	if !ok {
+		return runExc("cannot destructure %s as a list", value.Type()).WithDebug(pattern.Debug)
+	}
+
+	for j, child := range pattern.Children {
+		if child.Flags.Contains("REST") {
+			var rest []language.Object
+			if j < len(list.Data) {
+				rest = append(rest, list.Data[j:]...)
+			}
+			return i.destructure(child, language.NewList(rest, list.ItemType, list.Debug()), mutable, assign)
+		}
+
+		var element language.Object
+		if j < len(list.Data) {
+			element = list.Data[j]
+		}
+
+		missing := fmt.Sprintf("expected at least %d elements, got %d", j+1, len(list.Data))
+		if err := i.destructureElement(child, element, missing, mutable, assign); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Interpreter) destructureDict(pattern *astnode.Node, value language.Object, mutable bool, assign bool) error {
+	dict, err := i.destructureSource(pattern, value)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]struct{}, len(pattern.Children))
+	for _, child := range pattern.Children {
+		if child.Flags.Contains("REST") {
+			var keys, values []language.Object
+			dict.Data.Iterate(func(key, value language.Object) bool {
+				if _, ok := used[key.String()]; !ok {
+					keys = append(keys, key)
+					values = append(values, value)
+				}
+				return true
+			})
+
+			rest, err := language.NewDict(keys, values, dict.KeyType, dict.ValueType, dict.Debug())
+			if err != nil {
+				return wrapRunExc(err, child.Debug)
+			}
+			return i.destructure(child, rest, mutable, assign)
+		}
+
+		used[child.ArgName] = struct{}{}
+
+		var field language.Object
+		dict.Data.Iterate(func(key, value language.Object) bool {
+			if key.String() == child.ArgName {
+				field = value
+				return false
+			}
+			return true
+		})
+
+		if err := i.destructureElement(child, field, fmt.Sprintf("missing key %q in %s", child.ArgName, value.Type()), mutable, assign); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destructureSource returns the fields of value as a dict: a dict itself,
+// the public fields of a struct instance or the fields of an enum variant.
+func (i *Interpreter) destructureSource(pattern *astnode.Node, value language.Object) (*language.Dict, error) {
+	switch value := value.(type) {
+	case *language.Dict:
+		return value, nil
+
+	case *language.StructInstance:
+		convout, ok := value.GetPrototype().GetObject(i.ctx, "$convout")
+		if !ok {
+			return nil, runExc("cannot destructure %s as a dict", value.Type()).WithDebug(pattern.Debug)
+		}
+		fn, ok := convout.(*language.Function)
+		if !ok {
+			return nil, runExc("cannot destructure %s as a dict", value.Type()).WithDebug(pattern.Debug)
+		}
+
+		fields, err := fn.Data(i.ctx, nil)
+		if err != nil {
+			return nil, wrapRunExc(err, pattern.Debug)
+		}
+		dict, ok := fields.(*language.Dict)
+		if !ok {
+			return nil, runExc("cannot destructure %s as a dict", value.Type()).WithDebug(pattern.Debug)
+		}
+		return dict, nil
+
+	case *language.EnumValue:
+		keys := make([]language.Object, len(value.Fields))
+		for j, field := range value.Variant.Fields {
+			keys[j] = language.NewString(field.Name, value.Debug())
+		}
+
+		dict, err := language.NewDict(keys, value.Fields, language.TypeString, language.TypeAny, value.Debug())
+		if err != nil {
+			return nil, wrapRunExc(err, pattern.Debug)
+		}
+		return dict, nil
+	}
+
+	return nil, runExc("cannot destructure %s as a dict", value.Type()).WithDebug(pattern.Debug)
+}
+
+// patternNames returns the names bound by pattern in declaration order.
+func patternNames(pattern *astnode.Node) []string {
+	if pattern.Kind == "LIST" || pattern.Kind == "DICT" {
+		var names []string
+		for _, child := range pattern.Children {
+			names = append(names, patternNames(child)...)
+		}
+		return names
+	}
+
+	if pattern.Content == "_" {
+		return nil
+	}
+	return []string{pattern.Content}
+}
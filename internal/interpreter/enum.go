@@ -0,0 +1,53 @@
+package interpreter
+
+import (
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/language"
+	"go.uber.org/zap"
+)
+
+func (i *Interpreter) handleEnum(node *astnode.Node) error {
+	zap.L().Debug("interpreter.enum.declare.start", zap.Uint("id", i.ID), zap.String("name", node.Content))
+
+	name := node.Content
+	definition := language.NewEnum(name, node.Debug)
+
+	if err := i.Declare(name, definition, definition.Type(), false); err != nil {
+		zap.L().Error("interpreter.enum.declare.store", zap.Uint("id", i.ID), zap.String("name", name), zap.Error(err))
+		return wrapRunExc(err, node.Debug)
+	}
+
+	var (
+		variants = make([]*language.EnumVariant, len(node.Body))
+		seen     = make(map[string]struct{}, len(node.Body))
+	)
+	for inx, variantNode := range node.Body {
+		if _, ok := seen[variantNode.Content]; ok {
+			return runExc("duplicate variant %s in enum %s", variantNode.Content, name).WithDebug(variantNode.Debug)
+		}
+		seen[variantNode.Content] = struct{}{}
+
+		fields := make([]language.StructField, len(variantNode.Body))
+		for j, field := range variantNode.Body {
+			typ, err := i.parseTypeNode(field.ValueType)
+			if err != nil {
+				zap.L().Error("interpreter.enum.declare.typeError", zap.Uint("id", i.ID), zap.String("name", name), zap.String("variant", variantNode.Content), zap.Error(err))
+				return wrapRunExc(err, field.Debug)
+			}
+			fields[j] = language.StructField{
+				Name: field.Content,
+				Type: typ,
+			}
+		}
+
+		variants[inx] = &language.EnumVariant{
+			Name:   variantNode.Content,
+			Fields: fields,
+		}
+	}
+
+	definition.DefineVariants(variants)
+
+	zap.L().Debug("interpreter.enum.declare.success", zap.Uint("id", i.ID), zap.String("name", name))
+	return nil
+}
@@ -28,6 +28,9 @@ func (i *Interpreter) eval(node *astnode.Node) (language.Object, error) {
 	case astnode.NodeTypeInclude:
 		zap.L().Debug("interpreter.eval.include", zap.Uint("id", i.ID))
 		return i.includeValue(node)
+	case astnode.NodeTypeSpawn:
+		zap.L().Debug("interpreter.eval.spawn", zap.Uint("id", i.ID))
+		return i.handleSpawn(node)
 	}
 }
 
@@ -292,26 +295,39 @@ func humanNode(node *astnode.Node) string {
 func (i *Interpreter) evalList(node *astnode.Node, typ *language.Type) (language.Object, error) {
 	var (
 		baseTyp *language.Type
-		list    = make([]language.Object, len(node.Children))
+		list    = make([]language.Object, 0, len(node.Children))
 	)
 
-	for j, child := range node.Children {
+	for _, child := range node.Children {
 		obj, err := i.eval(child)
 		if err != nil {
 			return nil, exception.From(err, child.Debug, "failed to evaluate list element: @err")
 		}
-		list[j] = obj
 
-		if baseTyp == nil {
-			baseTyp = obj.Type()
-		} else if !baseTyp.Compare(obj.Type()) {
-			baseTyp = language.TypeAny
+		items := []language.Object{obj}
+		if child.Flags.Contains("SPREAD") {
+			if items, err = spreadList(obj, child); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, obj := range items {
+			list = append(list, obj)
+
+			if baseTyp == nil {
+				baseTyp = obj.Type()
+			} else if !baseTyp.Compare(obj.Type()) {
+				baseTyp = language.TypeAny
+			}
 		}
 	}
 
 	if baseTyp != nil {
 		typ = baseTyp
 	}
+	if typ == nil {
+		typ = language.TypeAny
+	}
 
 	return language.NewList(list, typ, node.Debug), nil
 }
@@ -335,11 +351,29 @@ func (i *Interpreter) evalDict(node *astnode.Node, keyType, valueType *language.
 		valueType = typ.Value
 	}
 
+	var entries []dictEntry
 	for _, pair := range node.Children {
 		if len(pair.Children) != 1 {
 			return nil, runExc("invalid dict entry").WithDebug(pair.Debug)
 		}
 
+		if pair.Flags.Contains("SPREAD") {
+			spread, err := i.eval(pair.Children[0])
+			if err != nil {
+				return nil, exception.From(err, pair.Debug, "failed to evaluate dict spread: @err")
+			}
+
+			source, ok := spread.(*language.Dict)
+			if !ok {
+				return nil, typeError("cannot spread %s into a dict", spread.Type()).WithDebug(pair.Children[0].Debug)
+			}
+			source.Data.Iterate(func(key, value language.Object) bool {
+				entries = append(entries, dictEntry{key, value})
+				return true
+			})
+			continue
+		}
+
 		keyNode, ok := pair.Value.(*astnode.Node)
 		if !ok {
 			return nil, runExc("invalid dict entry").WithDebug(pair.Debug)
@@ -355,8 +389,26 @@ func (i *Interpreter) evalDict(node *astnode.Node, keyType, valueType *language.
 			return nil, exception.From(err, pair.Debug, "failed to evaluate dict value: @err")
 		}
 
-		keys = append(keys, keyObj)
-		values = append(values, valueObj)
+		entries = append(entries, dictEntry{keyObj, valueObj})
+	}
+
+	for _, entry := range entries {
+		keyObj, valueObj := entry.key, entry.value
+
+		// Later entries replace earlier ones with the same key, so spread
+		// defaults can be overridden.
+		replaced := false
+		for j, key := range keys {
+			if keyObj.Type().Base().Hashable() && key.Value() == keyObj.Value() {
+				values[j] = valueObj
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			keys = append(keys, keyObj)
+			values = append(values, valueObj)
+		}
 
 		if inferredKeyType == nil {
 			inferredKeyType = keyObj.Type()
@@ -431,3 +483,16 @@ func (i *Interpreter) checkGetter(obj language.Object, node *astnode.Node) (lang
 
 	return obj, nil
 }
+
+type dictEntry struct {
+	key, value language.Object
+}
+
+// spreadList returns the items of value spread by node, e.g. ...args.
+func spreadList(value language.Object, node *astnode.Node) ([]language.Object, error) {
+	list, ok := value.(*language.List)
+	if !ok {
+		return nil, typeError("cannot spread %s, expected a list", value.Type()).WithDebug(node.Debug)
+	}
+	return list.Data, nil
+}
@@ -23,6 +23,7 @@ func (i *Interpreter) handleEventDecl(node *astnode.Node) (language.Object, erro
 	eventProvider := i.runtime.GetEventProvider()
 	event := &events.Event{
 		ID:   fmt.Sprintf("%d_%s", iid, name),
+		Name: name,
 		Args: make([]language.FnArg, len(node.Args)),
 	}
 
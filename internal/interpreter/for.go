@@ -51,28 +51,54 @@ func (i *Interpreter) handleFor(node *astnode.Node) (language.Object, error) {
 		zap.L().Debug("interpreter.for.iterator.custom", zap.Uint("id", i.ID), zap.String("exprType", logObjectType(expr)))
 	}
 
+	// Let the iterable release its resources when the loop stops early.
+	exhausted := false
+	defer func() {
+		if !exhausted {
+			i.closeIterable(expr)
+		}
+	}()
+
 	// Create loop scope only once
 	ir := NewWithParent(i, ScopeBlock, "for")
 
-	var keyName, valName string
+	var (
+		keyName, valName       string
+		keyPattern, valPattern *astnode.Node
+	)
 	if kv.Iterator != nil {
-		keyName = kv.Iterator.Value.(string)
-		// declare once
-		if err := ir.Declare(keyName, language.Nil, n.TAny, true); err != nil {
-			zap.L().Error("interpreter.for.declare.key", zap.Uint("id", ir.ID), zap.String("name", keyName), zap.Error(err))
-			return nil, wrapRunExc(err, expr.Debug())
+		if kv.Iterator.Type == astnode.NodeTypePattern {
+			keyPattern = kv.Iterator
+			if err := ir.declareLoopPattern(keyPattern); err != nil {
+				return nil, wrapRunExc(err, expr.Debug())
+			}
+		} else {
+			keyName = kv.Iterator.Value.(string)
+			// declare once
+			if err := ir.Declare(keyName, language.Nil, n.TAny, true); err != nil {
+				zap.L().Error("interpreter.for.declare.key", zap.Uint("id", ir.ID), zap.String("name", keyName), zap.Error(err))
+				return nil, wrapRunExc(err, expr.Debug())
+			}
 		}
 	}
 	if kv.Value != nil {
-		valName = kv.Value.Value.(string)
-		// declare once
-		if err := ir.Declare(valName, language.Nil, n.TAny, true); err != nil {
-			zap.L().Error("interpreter.for.declare.value", zap.Uint("id", ir.ID), zap.String("name", valName), zap.Error(err))
-			return nil, wrapRunExc(err, expr.Debug())
+		if kv.Value.Type == astnode.NodeTypePattern {
+			valPattern = kv.Value
+			if err := ir.declareLoopPattern(valPattern); err != nil {
+				return nil, wrapRunExc(err, expr.Debug())
+			}
+		} else {
+			valName = kv.Value.Value.(string)
+			// declare once
+			if err := ir.Declare(valName, language.Nil, n.TAny, true); err != nil {
+				zap.L().Error("interpreter.for.declare.value", zap.Uint("id", ir.ID), zap.String("name", valName), zap.Error(err))
+				return nil, wrapRunExc(err, expr.Debug())
+			}
 		}
 	}
 
 	iterations := 0
+loop:
 	for {
 		key, value, ok, err := iterate()
 		if err != nil {
@@ -81,6 +107,7 @@ func (i *Interpreter) handleFor(node *astnode.Node) (language.Object, error) {
 		}
 		if !ok {
 			zap.L().Debug("interpreter.for.iterate.complete", zap.Uint("id", i.ID), zap.Int("iterations", iterations))
+			exhausted = true
 			break
 		}
 
@@ -99,6 +126,16 @@ func (i *Interpreter) handleFor(node *astnode.Node) (language.Object, error) {
 				return nil, wrapRunExc(err, value.Debug())
 			}
 		}
+		if keyPattern != nil {
+			if err := ir.destructure(keyPattern, key, true, true); err != nil {
+				return nil, err
+			}
+		}
+		if valPattern != nil {
+			if err := ir.destructure(valPattern, value, true, true); err != nil {
+				return nil, err
+			}
+		}
 
 		ob, err := ir.Run(node.Body)
 		if err != nil {
@@ -110,6 +147,7 @@ func (i *Interpreter) handleFor(node *astnode.Node) (language.Object, error) {
 				switch ob.String() {
 				case "break":
 					zap.L().Debug("interpreter.for.break", zap.Uint("id", i.ID), zap.Int("iteration", iterations))
+					break loop
 				case "continue":
 					zap.L().Debug("interpreter.for.continue", zap.Uint("id", i.ID), zap.Int("iteration", iterations))
 					continue
@@ -130,6 +168,18 @@ func (i *Interpreter) handleFor(node *astnode.Node) (language.Object, error) {
 	return nil, nil
 }
 
+// declareLoopPattern declares the names bound by a destructuring loop
+// variable once, so each iteration only has to assign them.
+func (i *Interpreter) declareLoopPattern(pattern *astnode.Node) error {
+	for _, name := range patternNames(pattern) {
+		if err := i.Declare(name, language.Nil, n.TAny, true); err != nil {
+			zap.L().Error("interpreter.for.declare.pattern", zap.Uint("id", i.ID), zap.String("name", name), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
 func (i *Interpreter) getIterator(expr language.Object) (func() (language.Object, language.Object, bool, error), bool) {
 	zap.L().Debug("interpreter.for.iterator.lookup", zap.Uint("id", i.ID), zap.String("exprType", logObjectType(expr)))
 
@@ -222,3 +272,27 @@ func (i *Interpreter) getIterator(expr language.Object) (func() (language.Object
 		return key, value, true, nil
 	}, true
 }
+
+// closeIterable calls the optional __close__ method of an iterable that was
+// not iterated to the end, e.g. because of break, return or an error.
+func (i *Interpreter) closeIterable(expr language.Object) {
+	proto := expr.GetPrototype()
+	if proto == nil {
+		return
+	}
+
+	closer, ok := proto.GetObject(i.ctx, "__close__")
+	if !ok {
+		return
+	}
+
+	fn, ok := closer.(*language.Function)
+	if !ok {
+		return
+	}
+
+	zap.L().Debug("interpreter.for.iterator.close", zap.Uint("id", i.ID), zap.String("exprType", logObjectType(expr)))
+	if _, err := fn.Data(language.StructAllowPrivateCtx(i.ctx), nil); err != nil {
+		zap.L().Error("interpreter.for.iterator.close.error", zap.Uint("id", i.ID), zap.Error(err))
+	}
+}
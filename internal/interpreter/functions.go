@@ -7,6 +7,7 @@ import (
 
 	"github.com/nubolang/nubo/internal/ast/astnode"
 	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"go.uber.org/zap"
 )
@@ -17,8 +18,14 @@ func (i *Interpreter) handleFunctionDecl(node *astnode.Node, ret ...bool) (langu
 	var args = make([]language.FnArg, len(node.Args))
 	var returnType *language.Type
 
+	scope, err := i.typeParamScope(node.TypeParams)
+	if err != nil {
+		zap.L().Error("interpreter.function.declare.typeParams", zap.Uint("id", i.ID), zap.String("name", node.Content), zap.Error(err))
+		return nil, err
+	}
+
 	if node.ValueType != nil {
-		rt, err := i.parseTypeNode(node.ValueType)
+		rt, err := scope.parseTypeNode(node.ValueType)
 		if err != nil {
 			zap.L().Error("interpreter.function.declare.returnType", zap.Uint("id", i.ID), zap.String("name", node.Content), zap.Error(err))
 			return nil, exception.From(err, node.Debug, "failed to parse type: @err")
@@ -31,7 +38,7 @@ func (i *Interpreter) handleFunctionDecl(node *astnode.Node, ret ...bool) (langu
 	}
 
 	for j, arg := range node.Args {
-		typ, err := i.parseTypeNode(arg.ValueType)
+		typ, err := scope.parseArgType(arg)
 		if err != nil {
 			zap.L().Error("interpreter.function.declare.argType", zap.Uint("id", i.ID), zap.String("name", node.Content), zap.String("arg", arg.Content), zap.Error(err))
 			return nil, exception.From(err, arg.Debug, "failed to parse type: @err")
@@ -61,8 +68,9 @@ func (i *Interpreter) handleFunctionDecl(node *astnode.Node, ret ...bool) (langu
 			}
 		} else {
 			args[j] = &language.BasicFnArg{
-				NameVal: arg.Content,
-				TypeVal: typ,
+				NameVal:     arg.Content,
+				TypeVal:     typ,
+				VariadicVal: arg.Flags.Contains("VARIADIC"),
 			}
 		}
 
@@ -70,16 +78,35 @@ func (i *Interpreter) handleFunctionDecl(node *astnode.Node, ret ...bool) (langu
 	}
 
 	fn := language.NewTypedFunction(args, returnType, func(ctx context.Context, o []language.Object) (language.Object, error) {
+		ctx, depthErr := enterCall(ctx)
+		if depthErr != nil {
+			return nil, depthErr.WithDebug(node.Debug)
+		}
+
 		ir := NewWithParent(i, ScopeFunction)
-		ir.ctx = ctx
+		ir.ctx = i.callContext(ctx)
+
+		var typeArgs map[string]*language.Type
+		if len(node.TypeParams) > 0 {
+			var err error
+			if typeArgs, err = ir.inferTypeArgs(node.TypeParams, args, o); err != nil {
+				return nil, exception.From(err, node.Debug, "failed to infer type arguments: @err")
+			}
+		}
 
 		for j, arg := range args {
 			providedArg := o[j]
-			if !language.TypeCheck(arg.Type(), providedArg.Type()) {
-				return nil, typeError("expected %s but got %s", arg.Type(), providedArg.Type()).WithDebug(providedArg.Debug())
+			argType := arg.Type()
+			if typeArgs != nil {
+				argType = argType.Substitute(typeArgs)
 			}
 
-			if err := ir.Declare(arg.Name(), providedArg, arg.Type(), true); err != nil {
+			if !language.TypeCheck(argType, providedArg.Type()) {
+				return nil, typeError("expected %s but got %s", argType, providedArg.Type()).WithDebug(providedArg.Debug())
+			}
+			language.BindTypeArgs(providedArg, argType)
+
+			if err := ir.declareArg(node.Args[j], providedArg, argType); err != nil {
 				return nil, exception.From(err, node.Debug, "failed to declare argument: @err")
 			}
 		}
@@ -88,6 +115,12 @@ func (i *Interpreter) handleFunctionDecl(node *astnode.Node, ret ...bool) (langu
 		if err != nil {
 			return nil, exception.From(err, node.Debug, "function execution failed: @err")
 		}
+
+		if typeArgs != nil && ob != nil && ob.Type().Base() != language.ObjectTypeSignal {
+			if rt := returnType.Substitute(typeArgs); !rt.Compare(ob.Type()) {
+				return nil, typeError("expected return type %s, got %s", rt, ob.Type()).WithDebug(node.Debug)
+			}
+		}
 		return ob, nil
 	}, node.Debug)
 
@@ -138,6 +171,7 @@ func (i *Interpreter) handleFunctionCall(node *astnode.Node) (language.Object, e
 	var (
 		args      = make([]language.Object, 0, len(node.Args))
 		namedArgs = make(map[string]language.Object)
+		spread    bool
 	)
 	for j, arg := range node.Args {
 		value, err := i.eval(arg)
@@ -154,6 +188,15 @@ func (i *Interpreter) handleFunctionCall(node *astnode.Node) (language.Object, e
 
 		if arg.Kind == "NAMED_ARG" {
 			namedArgs[arg.ArgName] = value.Clone()
+		} else if arg.Flags.Contains("SPREAD") {
+			items, err := spreadList(value, arg)
+			if err != nil {
+				return nil, err
+			}
+			spread = true
+			for _, item := range items {
+				args = append(args, item.Clone())
+			}
 		} else {
 			args = append(args, value.Clone())
 		}
@@ -166,6 +209,12 @@ func (i *Interpreter) handleFunctionCall(node *astnode.Node) (language.Object, e
 		return nil, err
 	}
 
+	if spread && !okFn.AcceptsArgs(len(args)) {
+		err := exception.Create("too many arguments: %s takes %d, got %d", node.Content, len(okFn.ArgTypes), len(args)).WithDebug(node.Debug).WithLevel(exception.LevelRuntime)
+		zap.L().Error("interpreter.function.call.tooManyArgs", zap.Uint("id", i.ID), zap.String("name", node.Content), zap.Error(err))
+		return nil, err
+	}
+
 	value, err := okFn.Call(i.ctx, args, namedArgs)
 	if err != nil {
 		zap.L().Error("interpreter.function.call.execError", zap.Uint("id", i.ID), zap.String("name", node.Content), zap.Error(err))
@@ -331,20 +380,21 @@ func (i *Interpreter) createInlineFunction(node *astnode.Node) (language.Object,
 	}
 
 	for j, arg := range node.Args {
-		typ, err := i.parseTypeNode(arg.ValueType)
+		typ, err := i.parseArgType(arg)
 		if err != nil {
 			zap.L().Error("interpreter.function.inline.argType", zap.Uint("id", i.ID), zap.Int("index", j), zap.Error(err))
 			return nil, exception.From(err, node.Debug, "invalid type node: @err")
 		}
 		args[j] = &language.BasicFnArg{
-			NameVal: arg.Content,
-			TypeVal: typ,
+			NameVal:     arg.Content,
+			TypeVal:     typ,
+			VariadicVal: arg.Flags.Contains("VARIADIC"),
 		}
 	}
 
 	fn := language.NewTypedFunction(args, returnType, func(ctx context.Context, o []language.Object) (language.Object, error) {
 		ir := NewWithParent(i, ScopeFunction)
-		ir.ctx = ctx
+		ir.ctx = i.callContext(ctx)
 
 		for j, arg := range args {
 			providedArg := o[j]
@@ -352,7 +402,7 @@ func (i *Interpreter) createInlineFunction(node *astnode.Node) (language.Object,
 				return nil, typeError("expected %s, got %s", arg.Type(), providedArg.Type()).WithDebug(providedArg.Debug())
 			}
 
-			if err := ir.Declare(arg.Name(), providedArg, arg.Type(), true); err != nil {
+			if err := ir.declareArg(node.Args[j], providedArg, arg.Type()); err != nil {
 				return nil, exception.From(err, providedArg.Debug())
 			}
 		}
@@ -394,3 +444,51 @@ func (i *Interpreter) createInlineFunction(node *astnode.Node) (language.Object,
 	zap.L().Debug("interpreter.function.inline.success", zap.Uint("id", i.ID))
 	return fn, nil
 }
+
+type callDepthKey struct{}
+
+// enterCall returns ctx for a nested function call, failing once the
+// sandbox call depth limit is exceeded.
+func enterCall(ctx context.Context) (context.Context, *exception.Expection) {
+	policy := sandbox.FromContext(ctx)
+	if policy == nil || policy.MaxCallDepth <= 0 {
+		return ctx, nil
+	}
+	limit := policy.MaxCallDepth
+
+	depth, _ := ctx.Value(callDepthKey{}).(int)
+	if depth >= limit {
+		return nil, runExc("maximum call depth of %d exceeded", limit)
+	}
+	return context.WithValue(ctx, callDepthKey{}, depth+1), nil
+}
+
+// parseArgType returns the type of the function argument arg. Variadic
+// arguments default to []any and must be lists.
+func (i *Interpreter) parseArgType(arg *astnode.Node) (*language.Type, error) {
+	if !arg.Flags.Contains("VARIADIC") {
+		return i.parseTypeNode(arg.ValueType)
+	}
+
+	if arg.ValueType == nil {
+		return language.NewListType(language.TypeAny), nil
+	}
+
+	typ, err := i.parseTypeNode(arg.ValueType)
+	if err != nil {
+		return nil, err
+	}
+	if typ.Base() != language.ObjectTypeList {
+		return nil, typeError("variadic argument %s must be a list, got %s", arg.Content, typ).WithDebug(arg.Debug)
+	}
+	return typ, nil
+}
+
+// declareArg declares the function argument arg with the provided value,
+// destructuring it when the argument is a pattern.
+func (i *Interpreter) declareArg(arg *astnode.Node, value language.Object, typ *language.Type) error {
+	if len(arg.Args) > 0 {
+		return i.destructure(arg.Args[0], value, true, false)
+	}
+	return i.Declare(arg.Content, value, typ, true)
+}
@@ -0,0 +1,128 @@
+package interpreter
+
+import (
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+)
+
+// typeParamScope returns a scope of i that declares the type parameters of
+// a generic declaration, so its types can refer to them. It returns i for
+// declarations without type parameters.
+func (i *Interpreter) typeParamScope(params []*astnode.Node) (*Interpreter, error) {
+	if len(params) == 0 {
+		return i, nil
+	}
+
+	scope := NewWithParent(i, ScopeBlock)
+	for _, param := range params {
+		if _, err := i.stringToType(param.Content, param.Debug); err == nil {
+			return nil, typeError("type parameter %s shadows a builtin type", param.Content).WithDebug(param.Debug)
+		}
+		if err := scope.declareTypeArg(param, language.NewTypeParam(param.Content)); err != nil {
+			return nil, err
+		}
+	}
+	return scope, nil
+}
+
+// declareTypeArg makes the type parameter param refer to typ in i.
+func (i *Interpreter) declareTypeArg(param *astnode.Node, typ *language.Type) error {
+	value := language.NewTypeObject(typ, param.Debug)
+	if err := i.Declare(param.Content, value, value.Type(), false); err != nil {
+		return wrapRunExc(err, param.Debug)
+	}
+	return nil
+}
+
+// inferTypeArgs infers the type arguments of a call to a generic function
+// from the provided values and declares them in i, the scope of the call.
+// Type parameters that cannot be inferred become any.
+func (i *Interpreter) inferTypeArgs(params []*astnode.Node, args []language.FnArg, values []language.Object) (map[string]*language.Type, error) {
+	typeArgs := make(map[string]*language.Type, len(params))
+	for j, arg := range args {
+		if j < len(values) && values[j] != nil {
+			arg.Type().Infer(values[j].Type(), typeArgs)
+		}
+	}
+
+	for _, param := range params {
+		typ, ok := typeArgs[param.Content]
+		if !ok {
+			typ = language.TypeAny
+			typeArgs[param.Content] = typ
+		}
+		if err := i.declareTypeArg(param, typ); err != nil {
+			return nil, err
+		}
+	}
+	return typeArgs, nil
+}
+
+// genericType applies the type arguments of node, e.g. Box<int>, to the
+// generic struct or type alias ob.
+func (i *Interpreter) genericType(ob language.Object, node *astnode.Node) (*language.Type, error) {
+	var (
+		params []string
+		typ    *language.Type
+	)
+	switch ob := ob.(type) {
+	case *language.Struct:
+		params, typ = ob.TypeParams, ob.Type().DeepClone()
+	case *language.TypeObject:
+		params, typ = ob.Params, ob.Data
+	case *language.Enum:
+		return ob.ValueType(), nil
+	default:
+		return ob.Type(), nil
+	}
+
+	if len(node.TypeParams) == 0 {
+		if _, ok := ob.(*language.Struct); ok && len(params) > 0 {
+			// A generic struct without type arguments stands for any of its
+			// instances, and inside its impl block for the one at hand.
+			typ.Args = make([]*language.Type, len(params))
+			for j, name := range params {
+				typ.Args[j] = language.NewTypeParam(name)
+			}
+		}
+		return typ, nil
+	}
+
+	if len(node.TypeParams) != len(params) {
+		return nil, typeError("%s expects %d type arguments, got %d", node.Content, len(params), len(node.TypeParams)).WithDebug(node.Debug)
+	}
+
+	args := make(map[string]*language.Type, len(params))
+	list := make([]*language.Type, len(params))
+	for j, argNode := range node.TypeParams {
+		arg, err := i.parseTypeNode(argNode)
+		if err != nil {
+			return nil, err
+		}
+		args[params[j]] = arg
+		list[j] = arg
+	}
+
+	if _, ok := ob.(*language.Struct); ok {
+		typ.Args = list
+		return typ, nil
+	}
+	return typ.Substitute(args), nil
+}
+
+func typeParamNames(params []*astnode.Node) []string {
+	names := make([]string, len(params))
+	for j, param := range params {
+		names[j] = param.Content
+	}
+	return names
+}
+
+func typeParamNodes(names []string, dg *debug.Debug) []*astnode.Node {
+	nodes := make([]*astnode.Node, len(names))
+	for j, name := range names {
+		nodes[j] = &astnode.Node{Type: astnode.NodeTypeType, Content: name, Debug: dg}
+	}
+	return nodes
+}
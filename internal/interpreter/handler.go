@@ -43,6 +43,10 @@ func (i *Interpreter) handleNode(node *astnode.Node) (language.Object, error) {
 		return i.handleFor(node)
 	case astnode.NodeTypeStruct:
 		return nil, i.handleStruct(node)
+	case astnode.NodeTypeEnum:
+		return nil, i.handleEnum(node)
+	case astnode.NodeTypeMatch:
+		return i.handleMatch(node)
 	case astnode.NodeTypeImpl:
 		return nil, i.handleImpl(node)
 	case astnode.NodeTypeTry:
@@ -52,9 +56,9 @@ func (i *Interpreter) handleNode(node *astnode.Node) (language.Object, error) {
 		zap.L().Debug("interpreter.handler.defer", zap.Uint("id", i.ID), zap.Int("deferredCount", len(i.deferred)))
 		return nil, nil
 	case astnode.NodeTypeSpawn:
-		go i.handleSpawn(node)
 		zap.L().Debug("interpreter.handler.spawn", zap.Uint("id", i.ID))
-		return nil, nil
+		_, err := i.handleSpawn(node)
+		return nil, err
 	case astnode.NodeTypeBlock:
 		return i.handleBlock(node)
 	case astnode.NodeTypeTypeKW:
@@ -30,10 +30,22 @@ func (i *Interpreter) handleImpl(node *astnode.Node) error {
 		return err
 	}
 
+	// Methods of a generic struct are generic over its type parameters,
+	// which they infer from self.
+	var structParams []*astnode.Node
+	if def, ok := definition.(*language.Struct); ok && len(def.TypeParams) > 0 {
+		structParams = typeParamNodes(def.TypeParams, node.Debug)
+	}
+
 	proto.Unlock()
 
 	for _, child := range node.Body {
 		name := child.Content
+		if structParams != nil {
+			method := *child
+			method.TypeParams = append(structParams[:len(structParams):len(structParams)], child.TypeParams...)
+			child = &method
+		}
 		fn, err := i.handleFunctionDecl(child, true)
 		if err != nil {
 			zap.L().Error("interpreter.impl.fnError", zap.Uint("id", i.ID), zap.String("method", name), zap.Error(err))
@@ -3,7 +3,7 @@ package interpreter
 import (
 	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strings"
 
@@ -37,7 +37,7 @@ func (ir *Interpreter) handleImport(node *astnode.Node) error {
 	fileName := node.Value.(string)
 	zap.L().Debug("interpreter.import.source", zap.Uint("id", ir.ID), zap.String("name", node.Content), zap.String("source", fileName))
 
-	if strings.HasPrefix(fileName, "@std") || strings.HasPrefix(fileName, "@server") {
+	if strings.HasPrefix(fileName, "@std") || strings.HasPrefix(fileName, "@server") || ir.runtime.HasPackage(fileName) {
 		if err := ir.stdImport(node, fileName); err != nil {
 			zap.L().Error("interpreter.import.stdError", zap.Uint("id", ir.ID), zap.String("name", node.Content), zap.Error(err))
 			return exception.From(err, node.Debug, "failed to import standard library module")
@@ -87,7 +87,7 @@ func (ir *Interpreter) handleImport(node *astnode.Node) error {
 
 	zap.L().Debug("interpreter.import.path", zap.Uint("id", ir.ID), zap.String("name", node.Content), zap.String("path", path))
 
-	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+	if _, err := ir.runtime.Stat(path); errors.Is(err, fs.ErrNotExist) {
 		perr := runExc("imported file %s does not exists", path).WithDebug(node.Debug)
 		zap.L().Error("interpreter.import.missingFile", zap.Uint("id", ir.ID), zap.String("path", path), zap.Error(perr))
 		return perr
@@ -96,7 +96,7 @@ func (ir *Interpreter) handleImport(node *astnode.Node) error {
 	imported, ok := ir.runtime.FindInterpreter(path)
 	if !ok {
 		zap.L().Debug("interpreter.import.load", zap.Uint("id", ir.ID), zap.String("path", path))
-		nodes, err := native.NodesFromFile(path, path)
+		nodes, err := ir.parseFile(path)
 		if err != nil {
 			zap.L().Error("interpreter.import.parseError", zap.Uint("id", ir.ID), zap.String("path", path), zap.Error(err))
 			return exception.From(err, node.Debug, "failed to parse imported file: @err")
@@ -205,3 +205,15 @@ func (ir *Interpreter) stdImport(node *astnode.Node, fileName string) error {
 	zap.L().Error("interpreter.import.std.notFound", zap.Uint("id", ir.ID), zap.String("package", fileName), zap.Error(err))
 	return err
 }
+
+// parseFile parses the file at path, read through the runtime so apps
+// served from an fs.FS can import their own files.
+func (ir *Interpreter) parseFile(path string) ([]*astnode.Node, error) {
+	file, err := ir.runtime.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return native.NodesFromReader(file, path)
+}
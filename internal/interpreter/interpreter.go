@@ -7,6 +7,7 @@ import (
 
 	"github.com/nubolang/nubo/events"
 	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/internal/exception"
 	"github.com/nubolang/nubo/language"
 	"go.uber.org/zap"
@@ -59,6 +60,7 @@ func New(ctx context.Context, currentFile string, runtime Runtime, dependent boo
 		unsub:       make([]events.UnsubscribeFunc, 0),
 		deferred:    make([][]*astnode.Node, 0),
 	}
+	ir.ctx = debug.WithFile(events.WithDetach(ctx, ir.onDetach), ir.currentFile)
 
 	zap.L().Debug("interpreter.new", zap.Uint("id", ir.ID), zap.String("file", ir.currentFile))
 
@@ -101,7 +103,7 @@ func NewWithCustomFileParent(parent *Interpreter, scope Scope, file string, name
 	zap.L().Debug("interpreter.new.parent", zap.Uint("for_id", parent.ID), zap.String("file", parent.currentFile), zap.String("name", n))
 
 	ir := &Interpreter{
-		ctx:         parent.ctx,
+		ctx:         debug.WithFile(parent.ctx, file),
 		ID:          parent.ID,
 		currentFile: file,
 		scope:       scope,
@@ -129,6 +131,12 @@ func (i *Interpreter) Run(nodes []*astnode.Node) (language.Object, error) {
 	zap.L().Debug("interpreter.run.start", zap.Uint("id", i.ID), zap.Int("count", len(nodes)))
 
 	for _, node := range nodes {
+		// Stop when the run timed out or was cancelled by the host.
+		if err := i.ctx.Err(); err != nil {
+			zap.L().Debug("interpreter.run.cancelled", zap.Uint("id", i.ID), zap.Error(err))
+			return nil, runExc("execution stopped: %v", err).WithDebug(node.Debug)
+		}
+
 		obj, err := i.handleNode(node)
 		if err != nil {
 			zap.L().Error("interpreter.run.handleNode", zap.Uint("id", i.ID), zap.Error(err))
@@ -191,6 +199,23 @@ func (i *Interpreter) MustDetach() {
 	}
 }
 
+// callContext returns the context to run the body of a function declared in
+// i with: std functions called from it resolve relative paths against the
+// file the function is written in, not the file of its caller.
+func (i *Interpreter) callContext(ctx context.Context) context.Context {
+	if debug.FileFrom(ctx) == i.currentFile {
+		return ctx
+	}
+	return debug.WithFile(ctx, i.currentFile)
+}
+
+// onDetach registers fn to run when the interpreter detaches.
+func (i *Interpreter) onDetach(fn events.UnsubscribeFunc) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.unsub = append(i.unsub, fn)
+}
+
 func (i *Interpreter) isChildOf(scope Scope, name string) bool {
 	current := i
 	for {
@@ -0,0 +1,224 @@
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerics(t *testing.T) {
+	obj := nubotest.Run(t, `
+		fn first<T>(items: []T) T? {
+			for item in items {
+				return item
+			}
+			return nil
+		}
+
+		struct Box<T> {
+			value: T
+		}
+
+		fn unbox<T>(box: Box<T>) T {
+			return box.value
+		}
+
+		const box = Box()
+		box.value = first(["a", "b"])
+		let typed: Box<string> = box
+		return unbox(typed)
+	`, nil)
+	assert.Equal(t, "a", obj.Value())
+
+	_, err := nubotest.Exec(`
+		fn same<T>(a: T, b: T) bool {
+			return a == b
+		}
+		same(1, "x")
+	`, nil)
+	assert.Error(t, err, "type arguments should be checked")
+
+	_, err = nubotest.Exec(`
+		struct Box<T> {
+			value: T
+		}
+
+		const b: Box<int> = Box()
+		b.value = "x"
+	`, nil)
+	assert.Error(t, err, "declared type arguments should bind the instance")
+
+	_, err = nubotest.Exec(`
+		struct Pair<K, V> {
+			key: K
+			value: V
+		}
+
+		struct Box<T> {
+			value: T
+		}
+
+		const b: Box<int> = Box()
+		let p: Pair<int, int> = b
+	`, nil)
+	assert.Error(t, err, "struct types should not match other structs")
+}
+
+func TestEnums(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import json from "@std/json"
+
+		enum Status {
+			Active,
+			Suspended(reason: string)
+		}
+
+		fn describe(s: Status) string {
+			match s {
+				Active => return "active"
+				Suspended(reason) => return "suspended: " + reason
+			}
+			return ""
+		}
+
+		const text = json.stringify([Status.Active, Status.Suspended("spam")])
+		const back: Status = json.decode(Status, "{\"Suspended\":{\"reason\":\"late\"}}")
+		const results = [text, describe(Status.Active), describe(back), inspect(back)]
+		return results
+	`, nil)
+	assert.Equal(t, []string{
+		`["Active",{"Suspended":{"reason":"spam"}}]`,
+		"active",
+		"suspended: late",
+		`(enum Status) Suspended(reason: "late")`,
+	}, results)
+
+	_, err := nubotest.Exec(`
+		enum Light { Red, Green }
+		match Light.Red {
+			Red => println("stop")
+		}
+	`, nil)
+	assert.ErrorContains(t, err, "non-exhaustive match on Light: missing Green")
+}
+
+func TestDestructuring(t *testing.T) {
+	results := nubotest.Strings(t, `
+		struct Point {
+			x: int
+			y: int
+		}
+
+		fn label({ name, tags: [first, ...others], level = 1 }: dict[string, any]) string {
+			return name + ":" + first + ":" + string(len(others)) + ":" + string(level)
+		}
+
+		const p = Point()
+		p.x = 3
+		p.y = 4
+		let { x, y } = p
+
+		let [head, ...rest] = [1, 2, 3]
+		let sum = 0
+		for [a, b] in [[1, 2], [3, 4]] {
+			sum = sum + a * b
+		}
+
+		const results = [x + y, head, len(rest), sum, label({"name": "n", "tags": ["a", "b", "c"]})]
+		return results
+	`, nil)
+	assert.Equal(t, []string{"7", "1", "2", "14", "n:a:2:1"}, results)
+
+	_, err := nubotest.Exec(`
+		let [a, b, c] = [1, 2]
+	`, nil)
+	assert.ErrorContains(t, err, "expected at least 3 elements, got 2")
+
+	_, err = nubotest.Exec(`
+		let { missing } = {"name": "x"}
+	`, nil)
+	assert.ErrorContains(t, err, `missing key "missing"`)
+}
+
+func TestSpread(t *testing.T) {
+	results := nubotest.Strings(t, `
+		fn join(sep: string, ...parts: []string) string {
+			let out = ""
+			for i, part in parts {
+				if i > 0 {
+					out = out + sep
+				}
+				out = out + part
+			}
+			return out
+		}
+
+		const words = ["b", "c"]
+		const letters = ["a", ...words, "d"]
+		const defaults = {"host": "localhost", "port": 80}
+		const cfg = {...defaults, "port": 8080}
+
+		const results = [join("-", ...letters), join(","), join("+", "x", "y"), string(cfg["port"]), cfg["host"]]
+		return results
+	`, nil)
+	assert.Equal(t, []string{"a-b-c-d", "", "x+y", "8080", "localhost"}, results)
+
+	_, err := nubotest.Exec(`
+		fn count(...nums: []int) int {
+			return len(nums)
+		}
+		count(1, "two")
+	`, nil)
+	assert.ErrorContains(t, err, "argument 2 (nums) expected type int, got string")
+
+	_, err = nubotest.Exec(`
+		fn add(a: int, b: int) int {
+			return a + b
+		}
+		add(...[1])
+	`, nil)
+	assert.ErrorContains(t, err, "missing required argument 2 (b)")
+	assert.ErrorContains(t, err, "<nativeExecute>:5:")
+
+	obj := nubotest.Run(t, `
+		fn add(a: int, b: int = 10) int {
+			return a + b
+		}
+		return add(...[1])
+	`, nil)
+	assert.Equal(t, int64(11), obj.Value())
+
+	_, err = nubotest.Exec(`
+		fn add(a: int, b: int) int {
+			return a + b
+		}
+		add(...[1, 2, 3])
+	`, nil)
+	assert.ErrorContains(t, err, "too many arguments: add takes 2, got 3")
+	assert.ErrorContains(t, err, "<nativeExecute>:5:")
+}
+
+func TestForBreak(t *testing.T) {
+	results := nubotest.Strings(t, `
+		let seen = []
+		for item in [1, 2, 3, 4] {
+			if item == 3 {
+				break
+			}
+			seen = [...seen, item]
+		}
+
+		let total = 0
+		for i in [1, 2, 3] {
+			for j in [10, 20, 30] {
+				if j == 20 {
+					break
+				}
+				total = total + i * j
+			}
+		}
+		return [len(seen), seen[1], total]
+	`, nil)
+	assert.Equal(t, []string{"2", "2", "60"}, results)
+}
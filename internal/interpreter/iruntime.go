@@ -1,6 +1,8 @@
 package interpreter
 
 import (
+	"io/fs"
+
 	"github.com/nubolang/nubo/events"
 	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/language"
@@ -13,7 +15,10 @@ type Runtime interface {
 	NewID() uint
 	RemoveInterpreter(id uint)
 	ImportPackage(name string, dg *debug.Debug) (language.Object, bool)
+	HasPackage(name string) bool
 	GetPacker() (*packer.Packer, error)
 	FindInterpreter(file string) (*Interpreter, bool)
 	AddInterpreter(file string, interpreter *Interpreter)
+	Stat(file string) (fs.FileInfo, error)
+	Open(file string) (fs.File, error)
 }
@@ -0,0 +1,110 @@
+package interpreter
+
+import (
+	"strings"
+
+	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/language"
+	"go.uber.org/zap"
+)
+
+func (i *Interpreter) handleMatch(node *astnode.Node) (language.Object, error) {
+	zap.L().Debug("interpreter.match.start", zap.Uint("id", i.ID), zap.String("file", i.currentFile))
+
+	if len(node.Args) != 1 {
+		err := exception.Create("invalid or malformed match statement").WithDebug(node.Debug).WithLevel(exception.LevelSemantic)
+		zap.L().Error("interpreter.match.invalidArgs", zap.Uint("id", i.ID), zap.Error(err))
+		return nil, err
+	}
+
+	obj, err := i.eval(node.Args[0])
+	if err != nil {
+		zap.L().Error("interpreter.match.value.evalError", zap.Uint("id", i.ID), zap.Error(err))
+		return nil, exception.From(err, node.Args[0].Debug, "failed to evaluate match value: @err")
+	}
+
+	value, ok := obj.(*language.EnumValue)
+	if !ok {
+		err := typeError("match expects an enum value, got %s", obj.Type()).WithDebug(node.Args[0].Debug)
+		zap.L().Error("interpreter.match.typeMismatch", zap.Uint("id", i.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := checkMatchArms(value.Enum(), node); err != nil {
+		zap.L().Error("interpreter.match.arms", zap.Uint("id", i.ID), zap.Error(err))
+		return nil, err
+	}
+
+	for _, arm := range node.Body {
+		if arm.Content != "_" && arm.Content != value.Variant.Name {
+			continue
+		}
+
+		zap.L().Debug("interpreter.match.arm", zap.Uint("id", i.ID), zap.String("variant", arm.Content))
+
+		ir := NewWithParent(i, ScopeBlock)
+		for j, binding := range arm.Args {
+			if binding.Content == "_" {
+				continue
+			}
+			if err := ir.Declare(binding.Content, value.Fields[j], value.Variant.Fields[j].Type, true); err != nil {
+				return nil, wrapRunExc(err, binding.Debug)
+			}
+		}
+
+		ob, err := ir.Run(arm.Body)
+		if err != nil {
+			zap.L().Error("interpreter.match.body.error", zap.Uint("id", ir.ID), zap.Error(err))
+			return nil, exception.From(err, arm.Debug, "failed to execute match arm: @err")
+		}
+		return ob, nil
+	}
+
+	zap.L().Debug("interpreter.match.end", zap.Uint("id", i.ID))
+	return nil, nil
+}
+
+// checkMatchArms makes sure every arm names a variant of e with the right
+// number of bindings, and that the arms cover all variants of e.
+func checkMatchArms(e *language.Enum, node *astnode.Node) error {
+	covered := make(map[string]struct{}, len(e.Variants))
+
+	for _, arm := range node.Body {
+		if arm.Content == "_" {
+			if len(arm.Args) > 0 {
+				return runExc("'_' cannot bind fields").WithDebug(arm.Debug)
+			}
+			return nil
+		}
+
+		if arm.Kind != "" && arm.Kind != e.Name {
+			return typeError("expected a variant of %s, got %s.%s", e.Name, arm.Kind, arm.Content).WithDebug(arm.Debug)
+		}
+
+		variant, ok := e.Variant(arm.Content)
+		if !ok {
+			return typeError("enum %s has no variant %s", e.Name, arm.Content).WithDebug(arm.Debug)
+		}
+
+		if arm.Flags.Contains("FIELDS") && len(arm.Args) != len(variant.Fields) {
+			return typeError("variant %s.%s has %d fields, got %d bindings", e.Name, variant.Name, len(variant.Fields), len(arm.Args)).WithDebug(arm.Debug)
+		}
+
+		if _, ok := covered[variant.Name]; ok {
+			return runExc("variant %s.%s is matched more than once", e.Name, variant.Name).WithDebug(arm.Debug)
+		}
+		covered[variant.Name] = struct{}{}
+	}
+
+	var missing []string
+	for _, variant := range e.Variants {
+		if _, ok := covered[variant.Name]; !ok {
+			missing = append(missing, variant.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return typeError("non-exhaustive match on %s: missing %s", e.Name, strings.Join(missing, ", ")).WithDebug(node.Debug)
+	}
+	return nil
+}
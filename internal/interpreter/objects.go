@@ -105,7 +105,11 @@ func (i *Interpreter) assignNested(name string, value language.Object, depth int
 		if i.parent != nil {
 			return i.parent.assignNested(name, value, depth+1)
 		}
-		return runExc("undefined variable %q", parts[0]).WithDebug(value.Debug())
+		builtin, ok := i.runtime.GetBuiltin(parts[0])
+		if !ok {
+			return runExc("undefined variable %q", parts[0]).WithDebug(value.Debug())
+		}
+		obj = &entry{value: builtin}
 	}
 
 	current := obj.value
@@ -159,6 +163,7 @@ func (i *Interpreter) assignInCurrentScope(name string, value language.Object) e
 			if e.typ != nil && !e.typ.Compare(value.Type()) {
 				return typeError("variable %q type is expected to be %s, got %s", name, e.typ, value.Type()).WithDebug(value.Debug())
 			}
+			language.BindTypeArgs(value, e.typ)
 			e.value = value
 			return nil
 		}
@@ -218,11 +223,20 @@ func (i *Interpreter) getObject(name string, depth int) (language.Object, bool)
 		i.mu.RLock()
 		obj, ok := i.objects[hashKey(parts[0])]
 		i.mu.RUnlock()
-		if !ok || obj == nil || obj.value == nil {
-			return i.parentGetObject(name, depth+1)
+
+		var current language.Object
+		if ok && obj != nil {
+			current = obj.value
+		}
+		if current == nil {
+			// Globals set by the host behave like builtins.
+			builtin, ok := i.runtime.GetBuiltin(parts[0])
+			if !ok {
+				return i.parentGetObject(name, depth+1)
+			}
+			current = builtin
 		}
 
-		current := obj.value
 		for _, part := range parts[1 : len(parts)-1] {
 			if current == nil {
 				return i.parentGetObject(name, depth+1)
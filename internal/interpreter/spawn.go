@@ -1,23 +1,35 @@
 package interpreter
 
 import (
-	"log"
+	"context"
 
 	"github.com/nubolang/nubo/internal/ast/astnode"
+	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/internal/packages/thread"
 	"github.com/nubolang/nubo/language"
 	"go.uber.org/zap"
 )
 
-func (i *Interpreter) handleSpawn(node *astnode.Node) {
-	ir := NewWithParent(i, ScopeFunction, "nubo_concurrent")
-	ir.Declare("__concurrent__", language.NewBool(true, node.Debug), language.TypeBool, false)
-
+// handleSpawn runs the children of node in a new goroutine and returns the
+// Task controlling it. The spawned interpreter uses the task's context, so
+// cancelling the task stops it at its next statement.
+func (i *Interpreter) handleSpawn(node *astnode.Node) (language.Object, error) {
 	zap.L().Debug("interpreter.spawn.start", zap.Uint("id", i.ID))
-	for _, node := range node.Children {
-		if _, err := ir.eval(node); err != nil {
-			zap.L().Error("interpreter.spawn.error", zap.Uint("id", i.ID), zap.Error(err))
-			i.Detach()
-			log.Fatal(err)
+
+	return thread.Spawn(i.ctx, node.Debug, func(ctx context.Context) (language.Object, error) {
+		ir := NewWithParent(i, ScopeFunction, "nubo_concurrent")
+		ir.ctx = ctx
+		ir.Declare("__concurrent__", language.NewBool(true, node.Debug), language.TypeBool, false)
+
+		var result language.Object
+		for _, child := range node.Children {
+			value, err := ir.eval(child)
+			if err != nil {
+				zap.L().Debug("interpreter.spawn.error", zap.Uint("id", i.ID), zap.Error(err))
+				return nil, exception.From(err, node.Debug, "spawned task failed: @err")
+			}
+			result = value
 		}
-	}
+		return result, nil
+	})
 }
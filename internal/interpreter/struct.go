@@ -15,24 +15,32 @@ func (i *Interpreter) handleStruct(node *astnode.Node) error {
 	body := make([]language.StructField, len(node.Body))
 
 	definition := language.NewStructBetter(name, node.Debug)
+	definition.TypeParams = typeParamNames(node.TypeParams)
 
 	if err := i.Declare(name, definition, definition.Type(), false); err != nil {
 		zap.L().Error("interpreter.struct.declare.store", zap.Uint("id", i.ID), zap.String("name", name), zap.Error(err))
 		return wrapRunExc(err, node.Debug)
 	}
 
+	scope, err := i.typeParamScope(node.TypeParams)
+	if err != nil {
+		return err
+	}
+
 	for inx, field := range node.Body {
-		typ, err := i.parseTypeNode(field.ValueType)
+		typ, err := scope.parseTypeNode(field.ValueType)
 		if err != nil {
 			zap.L().Error("interpreter.struct.declare.typeError", zap.Uint("id", i.ID), zap.String("name", name), zap.String("field", field.Content), zap.Error(err))
 			return wrapRunExc(err, node.Debug)
 		}
 		priv := field.Flags.Contains("PRIVATE")
+		tag, _ := field.Value.(string)
 
 		body[inx] = language.StructField{
 			Name:    field.Content,
 			Type:    typ,
 			Private: priv,
+			Tag:     tag,
 		}
 	}
 
@@ -135,19 +135,19 @@ func (i *Interpreter) parseTypeNode(n *astnode.Node) (*language.Type, error) {
 	baseType, err := i.stringToType(n.Content, n.Debug)
 	if err != nil {
 		ob, ok := i.GetObject(n.Content)
-		if !ok || (ob.Type().Base() != language.ObjectTypeStructDefinition && ob.Type().Base() != language.ObjectTypeType) {
+		if !ok || (ob.Type().Base() != language.ObjectTypeStructDefinition && ob.Type().Base() != language.ObjectTypeType && ob.Type().Base() != language.ObjectTypeEnumDefinition) {
 			return nil, runExc("unknown type: %q", n.Content).WithDebug(n.Debug)
 		}
 
-		t := ob.Type()
-		if ob.Type().Base() == language.ObjectTypeType {
-			t = ob.Value().(*language.Type)
+		t, err := i.genericType(ob, n)
+		if err != nil {
+			return nil, err
 		}
 
 		if n.Flags.Contains("OPTIONAL") {
-			return language.Nullable(t), nil
+			t = language.Nullable(t)
 		}
-		return t, nil
+		return i.checkAddUnionType(t, n)
 	}
 
 	t.BaseType = baseType.Base()
@@ -6,14 +6,20 @@ import (
 )
 
 func (i *Interpreter) handleTypeDecl(node *astnode.Node) error {
-	typ, err := i.parseTypeNode(node.ValueType)
 	name := node.Content
 
+	scope, err := i.typeParamScope(node.TypeParams)
+	if err != nil {
+		return err
+	}
+
+	typ, err := scope.parseTypeNode(node.ValueType)
 	if err != nil {
 		return wrapRunExc(err, node.ValueType.Debug)
 	}
 
 	value := language.NewTypeObject(typ, node.Debug)
+	value.Params = typeParamNames(node.TypeParams)
 	typ.ID = name
 
 	if err := i.Declare(name, value, value.Type(), false); err != nil {
@@ -70,6 +70,11 @@ func (i *Interpreter) handleVariableDecl(parent *astnode.Node) error {
 	if !typ.Compare(value.Type()) {
 		return typeError("expected %s, got %s", typ.String(), value.Type().String()).WithDebug(parent.Debug)
 	}
+	language.BindTypeArgs(value, typ)
+
+	if len(parent.Args) > 0 {
+		return i.destructure(parent.Args[0], value, mutable, false)
+	}
 
 	zap.L().Debug("interpreter.variables.declare", zap.Uint("id", i.ID), zap.String("name", variableName), zap.Any("value", value), zap.Bool("mutable", mutable))
 
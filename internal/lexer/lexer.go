@@ -64,6 +64,16 @@ func (lx *Lexer) peek(n int) rune {
 
 func (lx *Lexer) prev() rune { return lx.peek(-1) }
 
+// afterIdentifier reports whether the last token is an identifier directly
+// before the current rune, as in `fn first<T>` or `Box<int>`, where '<'
+// opens type parameters instead of an html block.
+func (lx *Lexer) afterIdentifier() bool {
+	if len(lx.tokens) == 0 {
+		return false
+	}
+	return lx.tokens[len(lx.tokens)-1].Type == TokenIdentifier
+}
+
 func (lx *Lexer) advance() {
 	if lx.pos >= len(lx.input) {
 		return
@@ -129,7 +139,17 @@ func (lx *Lexer) Parse() ([]*Token, error) {
 		case '?':
 			lx.add(TokenQuestion, "?", nil)
 			lx.advance()
-		case ';', ':', ',', '.', '(', ')', '{', '}', '[', ']':
+		case '.':
+			if lx.peek(1) == '.' && lx.peek(2) == '.' {
+				lx.add(TokenEllipsis, "...", nil)
+				lx.advance()
+				lx.advance()
+				lx.advance()
+			} else {
+				lx.add(TokenDot, ".", nil)
+				lx.advance()
+			}
+		case ';', ':', ',', '(', ')', '{', '}', '[', ']':
 			ch := lx.curr()
 			lx.add(lx.getCharIdent(ch), string(ch), nil)
 			lx.advance()
@@ -211,7 +231,7 @@ func (lx *Lexer) Parse() ([]*Token, error) {
 				lx.advance()
 			}
 		case '<':
-			if unicode.IsLetter(lx.peek(1)) { // valódi HTML‑kezdés
+			if unicode.IsLetter(lx.peek(1)) && !lx.afterIdentifier() { // valódi HTML‑kezdés
 				if err := lx.lexHtmlBlock(); err != nil {
 					return nil, err
 				}
@@ -333,6 +353,12 @@ func (lx *Lexer) lexIdentifierOrKeyword() {
 		lx.advance()
 	}
 	value := string(lx.input[startPos:lx.pos])
+
+	// Member names such as regex.match are never keywords.
+	if n := len(lx.tokens); n > 0 && lx.tokens[n-1].Type == TokenDot {
+		lx.add(TokenIdentifier, value, nil)
+		return
+	}
 	lx.add(lx.getIdentType(value), value, nil)
 }
 
@@ -6,6 +6,7 @@ const (
 	TokenFn     TokenType = "fn"
 	TokenStruct TokenType = "struct"
 	TokenImpl   TokenType = "impl"
+	TokenEnum   TokenType = "enum"
 
 	TokenLet   TokenType = "let"
 	TokenConst TokenType = "const"
@@ -26,6 +27,7 @@ const (
 	TokenElse     TokenType = "else"
 	TokenFor      TokenType = "for"
 	TokenWhile    TokenType = "while"
+	TokenMatch    TokenType = "match"
 	TokenBreak    TokenType = "break"
 	TokenContinue TokenType = "continue"
 	TokenReturn   TokenType = "return"
@@ -49,6 +51,7 @@ const (
 	TokenColon          TokenType = ":"
 	TokenSemicolon      TokenType = ";"
 	TokenDot            TokenType = "."
+	TokenEllipsis       TokenType = "..."
 	TokenOpenParen      TokenType = "("
 	TokenCloseParen     TokenType = ")"
 	TokenOpenBrace      TokenType = "{"
@@ -106,6 +109,7 @@ func Keywords() []TokenType {
 		TokenStatic,
 		TokenImpl,
 		TokenStruct,
+		TokenEnum,
 		TokenIface,
 		TokenTypeKW,
 
@@ -127,6 +131,7 @@ func Keywords() []TokenType {
 		TokenElse,
 		TokenFor,
 		TokenWhile,
+		TokenMatch,
 		TokenBreak,
 		TokenContinue,
 		TokenReturn,
@@ -0,0 +1,87 @@
+// Package nubotest runs Nubo scripts for the tests of the interpreter and
+// the std packages.
+package nubotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/config"
+	"github.com/nubolang/nubo/events"
+	"github.com/nubolang/nubo/internal/ast"
+	"github.com/nubolang/nubo/internal/lexer"
+	"github.com/nubolang/nubo/internal/runtime"
+	"github.com/nubolang/nubo/language"
+)
+
+// File is the file name scripts run under, as for code executed through a
+// nubo.Ctx.
+const File = "<nativeExecute>"
+
+// Exec runs script with globals defined and returns its result. Globals are
+// converted with language.FromValue.
+func Exec(script string, globals map[string]any) (language.Object, error) {
+	return ExecWithOptions(runtime.DefaultOptions(), script, globals)
+}
+
+// ExecWithOptions runs script like Exec, restricted by opts. The default
+// config is used unless one was loaded.
+func ExecWithOptions(opts runtime.Options, script string, globals map[string]any) (language.Object, error) {
+	config.Verify()
+	lx, err := lexer.New(strings.NewReader(script), File)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := lx.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	nodes, err := ast.New(ctx, 5*time.Second).Parse(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	r := runtime.New(events.NewDefaultProvider(), opts)
+	for name, value := range globals {
+		obj, err := language.FromValue(value, false)
+		if err != nil {
+			return nil, err
+		}
+		r.SetGlobal(name, obj)
+	}
+	return r.Interpret(File, nodes)
+}
+
+// Run runs script like Exec and fails t if it returns an error.
+func Run(t testing.TB, script string, globals map[string]any) language.Object {
+	t.Helper()
+
+	obj, err := Exec(script, globals)
+	if err != nil {
+		t.Fatalf("script failed: %v", err)
+	}
+	return obj
+}
+
+// Strings runs script like Run and returns the string of each item of the
+// list it returns.
+func Strings(t testing.TB, script string, globals map[string]any) []string {
+	t.Helper()
+
+	obj := Run(t, script, globals)
+	items, ok := obj.Value().([]language.Object)
+	if !ok {
+		t.Fatalf("script returned %s, expected a list", obj.Type())
+	}
+
+	results := make([]string, len(items))
+	for i, item := range items {
+		results[i] = item.String()
+	}
+	return results
+}
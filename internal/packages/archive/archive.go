@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/nubolang/nubo/internal/debug"
+	nuboio "github.com/nubolang/nubo/internal/packages/io"
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var (
+	TStringByte = n.TUnion(n.TString, n.TTList(n.TByte))
+	TBytes      = n.TTList(n.TByte)
+)
+
+func NewArchive(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("archive", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "gzip", newCodec(gzipCodec, dg))
+	proto.SetObject(ctx, "zlib", newCodec(zlibCodec, dg))
+	proto.SetObject(ctx, "flate", newCodec(flateCodec, dg))
+	proto.SetObject(ctx, "zip", newFormat(zipFormat, dg))
+	proto.SetObject(ctx, "tar", newFormat(tarFormat, dg))
+
+	return pkg
+}
+
+// source is an archive opened for reading.
+type source interface {
+	io.Reader
+	io.ReaderAt
+}
+
+// openSource opens the archive given as a file path, a []byte list or a
+// readable stream. Streams are read into memory, as zip needs random access.
+func openSource(a *n.Args, obj language.Object) (source, int64, func() error, error) {
+	nop := func() error { return nil }
+
+	if obj.Type() == language.TypeString {
+		path := nuboos.ResolvePath(a.Context(), obj.String())
+		if err := sandbox.FromContext(a.Context()).CheckPath(path); err != nil {
+			return nil, 0, nil, err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, nil, err
+		}
+		return file, info.Size(), file.Close, nil
+	}
+
+	if r, ok := nuboio.ReaderOf(obj); ok {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		return bytes.NewReader(data), int64(len(data)), nop, nil
+	}
+
+	if TBytes.Compare(obj.Type()) {
+		data := n.ToBytes(obj)
+		return bytes.NewReader(data), int64(len(data)), nop, nil
+	}
+
+	return nil, 0, nil, fmt.Errorf("expected a path, []byte or stream, got %s", obj.Type())
+}
+
+// safeJoin returns the path of the archive entry name below dest. Names that
+// would land outside dest ("zip slip") are rejected.
+func safeJoin(dest, name string) (string, error) {
+	local := filepath.FromSlash(name)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("illegal entry path %q", name)
+	}
+	return filepath.Join(dest, local), nil
+}
@@ -0,0 +1,50 @@
+package archive_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	results := nubotest.Strings(t, `
+		import archive from "@std/archive"
+
+		const z = archive.zip.create({"a.txt": "A", "dir/": "", "dir/b.txt": "B"})
+		const names = []
+		for e in archive.zip.list(z) {
+			names.push(e["name"])
+		}
+
+		const evil = archive.zip.create({"../evil.txt": "x"})
+		let message = ""
+		catch e {
+			archive.zip.extract(evil, dest)
+		}
+		{
+			message = e.message
+		}
+
+		return [
+			string(archive.gzip.decompress(archive.gzip.compress("hello hello hello"))),
+			string(archive.flate.decompress(archive.flate.compress("f", 9))),
+			names,
+			string(archive.zip.read(z, "dir/b.txt")),
+			len(archive.zip.extract(z, dest)),
+			message
+		]
+	`, map[string]any{"dest": dir})
+	assert.Equal(t, []string{"hello hello hello", "f", "[a.txt, dir/, dir/b.txt]", "B", "3"}, results[:5])
+	assert.Contains(t, results[5], `[archive/zip] illegal entry path "../evil.txt"`)
+
+	data, err := os.ReadFile(filepath.Join(dir, "dir", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", string(data))
+	_, err = os.Stat(filepath.Join(filepath.Dir(dir), "evil.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
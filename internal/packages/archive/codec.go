@@ -0,0 +1,135 @@
+package archive
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/nubolang/nubo/internal/debug"
+	nuboio "github.com/nubolang/nubo/internal/packages/io"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// codec is a compression format. Levels range from 0 (none) to 9 (best),
+// -1 picks the default.
+type codec struct {
+	name   string
+	writer func(w io.Writer, level int) (io.WriteCloser, error)
+	reader func(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	gzipCodec = codec{
+		name: "gzip",
+		writer: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, level)
+		},
+		reader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	}
+	zlibCodec = codec{
+		name: "zlib",
+		writer: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return zlib.NewWriterLevel(w, level)
+		},
+		reader: zlib.NewReader,
+	}
+	flateCodec = codec{
+		name: "flate",
+		writer: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return flate.NewWriter(w, level)
+		},
+		reader: func(r io.Reader) (io.ReadCloser, error) {
+			return flate.NewReader(r), nil
+		},
+	}
+)
+
+// newCodec builds a package compressing with c, either all at once or as
+// streams. Closing a writer stream finishes the compressed data but leaves
+// the underlying stream open.
+func newCodec(c codec, dg *debug.Debug) language.Object {
+	pkg := n.NewPackage(c.name, dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	streamType := nuboio.StreamStruct(dg).Type()
+
+	proto.SetObject(ctx, "compress", n.Function(n.Describe(
+		n.Arg("data", TStringByte),
+		n.Arg("level", n.TInt, n.Int(-1, dg)),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			var buf bytes.Buffer
+			w, err := c.writer(&buf, int(a.Name("level").Value().(int64)))
+			if err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			if _, err := w.Write(n.ToBytes(a.Name("data"))); err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			if err := w.Close(); err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			return n.Bytes(buf.Bytes(), dg), nil
+		}))
+
+	proto.SetObject(ctx, "decompress", n.Function(n.Describe(
+		n.Arg("data", TStringByte),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			r, err := c.reader(bytes.NewReader(n.ToBytes(a.Name("data"))))
+			if err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			defer r.Close()
+
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	proto.SetObject(ctx, "writer", n.Function(n.Describe(
+		n.Arg("stream", n.TAny),
+		n.Arg("level", n.TInt, n.Int(-1, dg)),
+	).Returns(streamType),
+		func(a *n.Args) (any, error) {
+			target, ok := nuboio.WriterOf(a.Name("stream"))
+			if !ok {
+				return nil, fmt.Errorf("[archive/%s] expected a writable stream, got %s", c.name, a.Name("stream").Type())
+			}
+
+			w, err := c.writer(target, int(a.Name("level").Value().(int64)))
+			if err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			// The stream can only be written; reads see an empty stream.
+			return nuboio.NewIOStream(bytes.NewReader(nil), w), nil
+		}))
+
+	proto.SetObject(ctx, "reader", n.Function(n.Describe(
+		n.Arg("stream", n.TAny),
+	).Returns(streamType),
+		func(a *n.Args) (any, error) {
+			source, ok := nuboio.ReaderOf(a.Name("stream"))
+			if !ok {
+				return nil, fmt.Errorf("[archive/%s] expected a readable stream, got %s", c.name, a.Name("stream").Type())
+			}
+
+			r, err := c.reader(source)
+			if err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", c.name, err)
+			}
+			return nuboio.NewIOStream(r), nil
+		}))
+
+	return pkg
+}
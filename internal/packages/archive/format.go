@@ -0,0 +1,398 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	nuboio "github.com/nubolang/nubo/internal/packages/io"
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// TEntry describes an archive entry:
+//
+//	{"name": "dir/file.txt", "size": 12, "isDir": false, "mode": 420, "modTime": time}
+var TEntry = n.NewDictType(n.TString, n.TAny)
+
+// entry is a file, directory or link in an archive. Names always use
+// forward slashes.
+type entry struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (e entry) value() map[string]any {
+	return map[string]any{
+		"name":    e.name,
+		"size":    e.size,
+		"isDir":   e.mode.IsDir(),
+		"mode":    int64(e.mode.Perm()),
+		"modTime": e.modTime,
+	}
+}
+
+// format is an archive format.
+type format struct {
+	name string
+	// walk calls fn for every entry of src in order. open returns the
+	// content of the entry and is only valid during the call.
+	walk func(src source, size int64, fn func(e entry, open func() (io.Reader, error)) error) error
+	// writer starts writing an archive to w.
+	writer func(w io.Writer) archiveWriter
+}
+
+type archiveWriter interface {
+	// add writes an entry. data is nil for directories.
+	add(e entry, data io.Reader) error
+	// close finishes the archive without closing the underlying writer.
+	close() error
+}
+
+// newFormat builds a package reading and writing archives in format f.
+func newFormat(f format, dg *debug.Debug) language.Object {
+	pkg := n.NewPackage(f.name, dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	writerStruct := newWriterStruct(f, dg)
+	proto.SetObject(ctx, "Writer", writerStruct)
+
+	errorf := func(format string, args ...any) error {
+		return fmt.Errorf("[archive/%s] %s", f.name, fmt.Sprintf(format, args...))
+	}
+
+	// walk opens the archive given as a path, []byte or stream and walks it.
+	walk := func(a *n.Args, fn func(e entry, open func() (io.Reader, error)) error) error {
+		src, size, closeSrc, err := openSource(a, a.Name("source"))
+		if err != nil {
+			return errorf("%s", err)
+		}
+		defer closeSrc()
+
+		if err := f.walk(src, size, fn); err != nil {
+			return errorf("%s", err)
+		}
+		return nil
+	}
+
+	proto.SetObject(ctx, "list", n.Function(n.Describe(
+		n.Arg("source", n.TAny),
+	).Returns(n.TTList(TEntry)),
+		func(a *n.Args) (any, error) {
+			var entries []language.Object
+			err := walk(a, func(e entry, _ func() (io.Reader, error)) error {
+				obj, err := language.FromValue(e.value(), false, dg)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, obj)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return language.NewList(entries, TEntry, dg), nil
+		}))
+
+	proto.SetObject(ctx, "read", n.Function(n.Describe(
+		n.Arg("source", n.TAny),
+		n.Arg("name", n.TString),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			name := a.Name("name").String()
+
+			var data []byte
+			found := false
+			err := walk(a, func(e entry, open func() (io.Reader, error)) error {
+				if found || e.name != name || e.mode.IsDir() {
+					return nil
+				}
+				found = true
+
+				r, err := open()
+				if err != nil {
+					return err
+				}
+				data, err = io.ReadAll(r)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, errorf("no entry %q", name)
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	// extract writes every entry below dest and returns their paths. Entries
+	// pointing outside dest and links are rejected before anything is
+	// written for them.
+	proto.SetObject(ctx, "extract", n.Function(n.Describe(
+		n.Arg("source", n.TAny),
+		n.Arg("dest", n.TString),
+	).Returns(n.TTList(n.TString)),
+		func(a *n.Args) (any, error) {
+			destArg := a.Name("dest")
+			dest := nuboos.ResolvePath(a.Context(), destArg.String())
+			if err := sandbox.FromContext(a.Context()).CheckPath(dest); err != nil {
+				return nil, err
+			}
+
+			paths := []string{}
+			err := walk(a, func(e entry, open func() (io.Reader, error)) error {
+				if err := a.Context().Err(); err != nil {
+					return err
+				}
+
+				target, err := safeJoin(dest, e.name)
+				if err != nil {
+					return err
+				}
+
+				switch {
+				case e.mode.IsDir():
+					if err := os.MkdirAll(target, 0o755); err != nil {
+						return err
+					}
+				case e.mode.IsRegular():
+					if err := extractFile(target, e, open); err != nil {
+						return err
+					}
+				default:
+					return fmt.Errorf("unsupported entry %q: only files and directories are extracted", e.name)
+				}
+
+				paths = append(paths, filepath.Join(destArg.String(), filepath.FromSlash(e.name)))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return language.FromValue(paths, false, dg)
+		}))
+
+	// create builds an archive in memory from a dict of entry names and
+	// contents. Names ending in "/" are directories.
+	proto.SetObject(ctx, "create", n.Function(n.Describe(
+		n.Arg("files", n.NewDictType(n.TString, TStringByte)),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			files := make(map[string]language.Object)
+			err := a.Name("files").(*language.Dict).Data.IterateErr(func(key, value language.Object) error {
+				files[key.String()] = value
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			names := make([]string, 0, len(files))
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			var buf bytes.Buffer
+			w := f.writer(&buf)
+			for _, name := range names {
+				if err := addData(w, name, n.ToBytes(files[name])); err != nil {
+					return nil, errorf("%s", err)
+				}
+			}
+			if err := w.close(); err != nil {
+				return nil, errorf("%s", err)
+			}
+			return n.Bytes(buf.Bytes(), dg), nil
+		}))
+
+	// writer writes an archive to a stream, such as a file or a server
+	// response, entry by entry. Call close on the result to finish it.
+	proto.SetObject(ctx, "writer", n.Function(n.Describe(
+		n.Arg("stream", n.TAny),
+	).Returns(writerStruct.Type()),
+		func(a *n.Args) (any, error) {
+			target, ok := nuboio.WriterOf(a.Name("stream"))
+			if !ok {
+				return nil, errorf("expected a writable stream, got %s", a.Name("stream").Type())
+			}
+
+			instance, err := writerStruct.NewInstance()
+			if err != nil {
+				return nil, err
+			}
+			instance.BucketSet("archive", f.writer(target))
+			return instance, nil
+		}))
+
+	return pkg
+}
+
+func extractFile(target string, e entry, open func() (io.Reader, error)) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	r, err := open()
+	if err != nil {
+		return err
+	}
+
+	perm := e.mode.Perm()
+	if perm == 0 {
+		perm = 0o644
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// addData adds an in-memory entry. Names ending in "/" are directories.
+func addData(w archiveWriter, name string, data []byte) error {
+	if name == "" {
+		return fmt.Errorf("empty entry name")
+	}
+	if name[len(name)-1] == '/' {
+		return w.add(entry{name: name, mode: fs.ModeDir | 0o755, modTime: time.Now()}, nil)
+	}
+	return w.add(entry{name: name, size: int64(len(data)), mode: 0o644, modTime: time.Now()}, bytes.NewReader(data))
+}
+
+// addPath adds the file or directory tree at file under name.
+func addPath(ctx context.Context, w archiveWriter, file, name string) error {
+	return filepath.WalkDir(file, func(current string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(file, current)
+		if err != nil {
+			return err
+		}
+		entryName := path.Join(name, filepath.ToSlash(rel))
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		e := entry{name: entryName, size: info.Size(), mode: info.Mode(), modTime: info.ModTime()}
+
+		switch {
+		case info.IsDir():
+			e.name += "/"
+			e.size = 0
+			return w.add(e, nil)
+		case info.Mode().IsRegular():
+			data, err := os.Open(current)
+			if err != nil {
+				return err
+			}
+			defer data.Close()
+			return w.add(e, data)
+		}
+		return fmt.Errorf("unsupported file %q: only files and directories are archived", current)
+	})
+}
+
+func newWriterStruct(f format, dg *debug.Debug) *language.Struct {
+	ctx := context.Background()
+	s := language.NewStruct("Writer", nil, dg)
+
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	state := func(obj language.Object) (archiveWriter, error) {
+		bucket, ok := obj.(language.Bucketable)
+		if ok {
+			value, _ := bucket.BucketGet("archive")
+			if w, ok := value.(archiveWriter); ok {
+				return w, nil
+			}
+		}
+		return nil, fmt.Errorf("[archive/%s] expected Writer, got %s", f.name, obj.Type())
+	}
+
+	proto.SetObject(ctx, "add", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("name", n.TString),
+		n.Arg("data", TStringByte, n.String("")),
+	),
+		func(a *n.Args) (any, error) {
+			w, err := state(a.Name("self"))
+			if err != nil {
+				return nil, err
+			}
+			if err := addData(w, a.Name("name").String(), n.ToBytes(a.Name("data"))); err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", f.name, err)
+			}
+			return nil, nil
+		}))
+
+	// addFile adds a file, or a directory with everything below it, stored
+	// under name or the base name of path.
+	proto.SetObject(ctx, "addFile", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("path", n.TString),
+		n.Arg("name", n.TString, n.String("")),
+	),
+		func(a *n.Args) (any, error) {
+			w, err := state(a.Name("self"))
+			if err != nil {
+				return nil, err
+			}
+
+			file := nuboos.ResolvePath(a.Context(), a.Name("path").String())
+			if err := sandbox.FromContext(a.Context()).CheckPath(file); err != nil {
+				return nil, err
+			}
+
+			name := a.Name("name").String()
+			if name == "" {
+				name = filepath.Base(file)
+			}
+			if err := addPath(a.Context(), w, file, name); err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", f.name, err)
+			}
+			return nil, nil
+		}))
+
+	proto.SetObject(ctx, "close", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	),
+		func(a *n.Args) (any, error) {
+			w, err := state(a.Name("self"))
+			if err != nil {
+				return nil, err
+			}
+			if err := w.close(); err != nil {
+				return nil, fmt.Errorf("[archive/%s] %w", f.name, err)
+			}
+			return nil, nil
+		}))
+
+	proto.Lock()
+	proto.Implement()
+	return s
+}
@@ -0,0 +1,78 @@
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+)
+
+var tarFormat = format{
+	name: "tar",
+	// Gzip compressed archives (.tar.gz) are recognised by their header.
+	walk: func(src source, _ int64, fn func(e entry, open func() (io.Reader, error)) error) error {
+		var r io.Reader = bufio.NewReader(src)
+		if magic, _ := r.(*bufio.Reader).Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				return err
+			}
+			defer gz.Close()
+			r = gz
+		}
+
+		tr := tar.NewReader(r)
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			e := entry{
+				name:    header.Name,
+				size:    header.Size,
+				mode:    header.FileInfo().Mode(),
+				modTime: header.ModTime,
+			}
+			if err := fn(e, func() (io.Reader, error) { return tr, nil }); err != nil {
+				return err
+			}
+		}
+	},
+	writer: func(w io.Writer) archiveWriter {
+		return &tarWriter{w: tar.NewWriter(w)}
+	},
+}
+
+type tarWriter struct {
+	w *tar.Writer
+}
+
+func (t *tarWriter) add(e entry, data io.Reader) error {
+	header := &tar.Header{
+		Name:     e.name,
+		Mode:     int64(e.mode.Perm()),
+		Size:     e.size,
+		ModTime:  e.modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if data == nil {
+		header.Typeflag = tar.TypeDir
+		header.Size = 0
+	}
+
+	if err := t.w.WriteHeader(header); err != nil || data == nil {
+		return err
+	}
+	_, err := io.Copy(t.w, data)
+	return err
+}
+
+func (t *tarWriter) close() error {
+	return t.w.Close()
+}
@@ -0,0 +1,68 @@
+package archive
+
+import (
+	"archive/zip"
+	"io"
+)
+
+var zipFormat = format{
+	name: "zip",
+	walk: func(src source, size int64, fn func(e entry, open func() (io.Reader, error)) error) error {
+		r, err := zip.NewReader(src, size)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range r.File {
+			e := entry{
+				name:    file.Name,
+				size:    int64(file.UncompressedSize64),
+				mode:    file.Mode(),
+				modTime: file.Modified,
+			}
+
+			var rc io.ReadCloser
+			err := fn(e, func() (io.Reader, error) {
+				rc, err = file.Open()
+				return rc, err
+			})
+			if rc != nil {
+				rc.Close()
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	writer: func(w io.Writer) archiveWriter {
+		return &zipWriter{w: zip.NewWriter(w)}
+	},
+}
+
+type zipWriter struct {
+	w *zip.Writer
+}
+
+func (z *zipWriter) add(e entry, data io.Reader) error {
+	header := &zip.FileHeader{
+		Name:     e.name,
+		Method:   zip.Deflate,
+		Modified: e.modTime,
+	}
+	header.SetMode(e.mode)
+	if data == nil {
+		header.Method = zip.Store
+	}
+
+	w, err := z.w.CreateHeader(header)
+	if err != nil || data == nil {
+		return err
+	}
+	_, err = io.Copy(w, data)
+	return err
+}
+
+func (z *zipWriter) close() error {
+	return z.w.Close()
+}
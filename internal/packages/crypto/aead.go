@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+func aesGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chacha20(key []byte) (cipher.AEAD, error) {
+	return chacha20poly1305.New(key)
+}
+
+// sizeList formats sizes as "16, 24 or 32".
+func sizeList(sizes []int) string {
+	parts := make([]string, len(sizes))
+	for i, size := range sizes {
+		parts[i] = strconv.Itoa(size)
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " or " + parts[len(parts)-1]
+}
+
+// newAEAD builds a package sealing data with an AEAD cipher. Sealed data is
+// the random nonce followed by the ciphertext.
+func newAEAD(name string, newCipher func(key []byte) (cipher.AEAD, error), keySizes []int, dg *debug.Debug) language.Object {
+	pkg := n.NewPackage(name, dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	aead := func(key language.Object) (cipher.AEAD, error) {
+		data := n.ToBytes(key)
+		if !slices.Contains(keySizes, len(data)) {
+			return nil, fmt.Errorf("[crypto/%s] key must be %s bytes long, got %d", name, sizeList(keySizes), len(data))
+		}
+		return newCipher(data)
+	}
+
+	proto.SetObject(ctx, "key", n.Function(n.Describe().Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			key, err := randomBytes(keySizes[len(keySizes)-1])
+			if err != nil {
+				return nil, err
+			}
+			return n.Bytes(key, dg), nil
+		}))
+
+	proto.SetObject(ctx, "seal", n.Function(n.Describe(
+		n.Arg("key", TStringByte),
+		n.Arg("data", TStringByte),
+		n.Arg("aad", TStringByte, n.String("")),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			c, err := aead(a.Name("key"))
+			if err != nil {
+				return nil, err
+			}
+
+			nonce, err := randomBytes(c.NonceSize())
+			if err != nil {
+				return nil, err
+			}
+
+			sealed := c.Seal(nonce, nonce, n.ToBytes(a.Name("data")), n.ToBytes(a.Name("aad")))
+			return n.Bytes(sealed, dg), nil
+		}))
+
+	proto.SetObject(ctx, "open", n.Function(n.Describe(
+		n.Arg("key", TStringByte),
+		n.Arg("sealed", TStringByte),
+		n.Arg("aad", TStringByte, n.String("")),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			c, err := aead(a.Name("key"))
+			if err != nil {
+				return nil, err
+			}
+
+			sealed := n.ToBytes(a.Name("sealed"))
+			if len(sealed) < c.NonceSize() {
+				return nil, fmt.Errorf("[crypto/%s] sealed data is too short", name)
+			}
+
+			data, err := c.Open(nil, sealed[:c.NonceSize()], sealed[c.NonceSize():], n.ToBytes(a.Name("aad")))
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/%s] %w", name, err)
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	return pkg
+}
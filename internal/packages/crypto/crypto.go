@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var (
+	TStringByte = n.TUnion(n.TString, n.TTList(n.TByte))
+	TBytes      = n.TTList(n.TByte)
+	TKeyPair    = n.NewDictType(n.TString, TBytes)
+)
+
+func NewCrypto(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("crypto", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "randomBytes", n.Function(n.Describe(
+		n.Arg("size", n.TInt),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			size := a.Name("size").Value().(int64)
+			if size < 0 {
+				return nil, fmt.Errorf("[crypto] size must be non-negative, got %d", size)
+			}
+			data, err := randomBytes(int(size))
+			if err != nil {
+				return nil, err
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	proto.SetObject(ctx, "compare", n.Function(n.Describe(
+		n.Arg("a", TStringByte),
+		n.Arg("b", TStringByte),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			return subtle.ConstantTimeCompare(n.ToBytes(a.Name("a")), n.ToBytes(a.Name("b"))) == 1, nil
+		}))
+
+	proto.SetObject(ctx, "aes", newAEAD("aes", aesGCM, []int{16, 24, 32}, dg))
+	proto.SetObject(ctx, "chacha20", newAEAD("chacha20", chacha20, []int{32}, dg))
+	proto.SetObject(ctx, "hmac", newHMAC(dg))
+	proto.SetObject(ctx, "ed25519", newEd25519(dg))
+	proto.SetObject(ctx, "ecdsa", newECDSA(dg))
+	proto.SetObject(ctx, "jwt", newJWT(dg))
+
+	return pkg
+}
+
+func randomBytes(size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return nil, fmt.Errorf("[crypto] %w", err)
+	}
+	return data, nil
+}
+
+// keyPair returns a {"public", "private"} dict.
+func keyPair(public, private []byte, dg *debug.Debug) (language.Object, error) {
+	return language.NewDict(
+		[]language.Object{n.String("public", dg), n.String("private", dg)},
+		[]language.Object{n.Bytes(public, dg), n.Bytes(private, dg)},
+		n.TString, TBytes, dg,
+	)
+}
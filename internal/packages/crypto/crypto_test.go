@@ -0,0 +1,60 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrypto(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import crypto from "@std/crypto"
+
+		const key = crypto.chacha20.key()
+		const sealed = crypto.chacha20.seal(key, "secret")
+
+		const pair = crypto.ed25519.generate()
+		const signature = crypto.ed25519.sign(pair["private"], "hello")
+
+		const token = crypto.jwt.sign({"sub": "42", "exp": 4102444800}, "s3cret")
+		const claims = crypto.jwt.verify(token, "s3cret")
+
+		return [
+			string(crypto.chacha20.open(key, sealed)),
+			crypto.hmac.sha256("key", "The quick brown fox jumps over the lazy dog"),
+			crypto.ed25519.verify(pair["public"], "hello", signature),
+			crypto.ed25519.verify(pair["public"], "hellO", signature),
+			crypto.compare("abc", "abd"),
+			claims["sub"],
+			claims["exp"]
+		]
+	`, nil)
+	assert.Equal(t, []string{"secret", "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", "true", "false", "false", "42", "4102444800"}, results)
+
+	_, err := nubotest.Exec(`
+		import crypto from "@std/crypto"
+		crypto.jwt.verify(crypto.jwt.sign({"sub": "42"}, "s3cret"), "wrong")
+	`, nil)
+	assert.ErrorContains(t, err, "[crypto/jwt] invalid signature")
+
+	for _, claims := range []string{`{"exp": "0"}`, `{"exp": nil}`, `{"nbf": "0"}`} {
+		_, err = nubotest.Exec(`
+			import crypto from "@std/crypto"
+			crypto.jwt.verify(crypto.jwt.sign(`+claims+`, "s3cret"), "s3cret")
+		`, nil)
+		assert.ErrorContains(t, err, "is not a number", claims)
+	}
+
+	_, err = nubotest.Exec(`
+		import crypto from "@std/crypto"
+		crypto.jwt.sign({"sub": "42"}, "")
+	`, nil)
+	assert.ErrorContains(t, err, "[crypto/jwt] empty HS256 key")
+
+	_, err = nubotest.Exec(`
+		import crypto from "@std/crypto"
+		crypto.jwt.verify("eyJhbGciOiJIUzI1NiJ9.e30.AAAA", "")
+	`, nil)
+	assert.ErrorContains(t, err, "[crypto/jwt] empty HS256 key")
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var hmacHashes = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+func hmacSum(algorithm string, key, data []byte) ([]byte, error) {
+	newHash, ok := hmacHashes[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("[crypto/hmac] unknown algorithm %q", algorithm)
+	}
+
+	mac := hmac.New(newHash, key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+func newHMAC(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("hmac", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	// sha256 and sha512 return the MAC as hex, like @std/hash.
+	for algorithm := range hmacHashes {
+		proto.SetObject(ctx, algorithm, n.Function(n.Describe(
+			n.Arg("key", TStringByte),
+			n.Arg("data", TStringByte),
+		).Returns(n.TString),
+			func(a *n.Args) (any, error) {
+				sum, err := hmacSum(algorithm, n.ToBytes(a.Name("key")), n.ToBytes(a.Name("data")))
+				if err != nil {
+					return nil, err
+				}
+				return hex.EncodeToString(sum), nil
+			}))
+	}
+
+	proto.SetObject(ctx, "verify", n.Function(n.Describe(
+		n.Arg("key", TStringByte),
+		n.Arg("data", TStringByte),
+		n.Arg("mac", n.TString),
+		n.Arg("algorithm", n.TString, n.String("sha256")),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			sum, err := hmacSum(a.Name("algorithm").String(), n.ToBytes(a.Name("key")), n.ToBytes(a.Name("data")))
+			if err != nil {
+				return nil, err
+			}
+
+			mac, err := hex.DecodeString(a.Name("mac").String())
+			if err != nil {
+				return false, nil
+			}
+			return hmac.Equal(sum, mac), nil
+		}))
+
+	return pkg
+}
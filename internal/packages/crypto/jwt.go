@@ -0,0 +1,225 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var jwtEncoding = base64.RawURLEncoding
+
+// jwtSign returns the signature of input for alg.
+func jwtSign(alg string, key language.Object, input []byte) ([]byte, error) {
+	switch alg {
+	case "HS256":
+		secret := n.ToBytes(key)
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("[crypto/jwt] empty HS256 key")
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case "EdDSA":
+		private, err := ed25519Private(key)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.Sign(private, input), nil
+	}
+	return nil, fmt.Errorf("[crypto/jwt] unsupported algorithm %q", alg)
+}
+
+// jwtVerify reports whether signature is valid for input. EdDSA tokens are
+// verified with the public key.
+func jwtVerify(alg string, key language.Object, input, signature []byte) (bool, error) {
+	switch alg {
+	case "HS256":
+		expected, err := jwtSign(alg, key, input)
+		if err != nil {
+			return false, err
+		}
+		return hmac.Equal(expected, signature), nil
+	case "EdDSA":
+		public, err := ed25519Public(key)
+		if err != nil {
+			return false, err
+		}
+		return ed25519.Verify(public, input, signature), nil
+	}
+	return false, fmt.Errorf("[crypto/jwt] unsupported algorithm %q", alg)
+}
+
+// jsonNumbers turns the json.Numbers of a decoded value into ints where
+// they are whole and floats otherwise.
+func jsonNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = jsonNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = jsonNumbers(item)
+		}
+	}
+	return value
+}
+
+func jwtDecode(part string, into any) error {
+	data, err := jwtEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("[crypto/jwt] malformed token: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(into); err != nil {
+		return fmt.Errorf("[crypto/jwt] malformed token: %w", err)
+	}
+	return nil
+}
+
+// timeClaim returns the NumericDate claim name. A claim that is present but
+// not a number makes the token invalid.
+func timeClaim(claims map[string]any, name string) (int64, bool, error) {
+	raw, ok := claims[name]
+	if !ok {
+		return 0, false, nil
+	}
+
+	number, ok := raw.(json.Number)
+	if !ok {
+		return 0, false, fmt.Errorf("[crypto/jwt] invalid token: %s is not a number", name)
+	}
+	value, err := number.Float64()
+	if err != nil {
+		return 0, false, fmt.Errorf("[crypto/jwt] invalid token: %s is not a number", name)
+	}
+	return int64(value), true, nil
+}
+
+// checkTimes validates the exp and nbf claims if present.
+func checkTimes(claims map[string]any) error {
+	now := time.Now().Unix()
+
+	exp, ok, err := timeClaim(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if ok && exp <= now {
+		return fmt.Errorf("[crypto/jwt] token is expired")
+	}
+
+	nbf, ok, err := timeClaim(claims, "nbf")
+	if err != nil {
+		return err
+	}
+	if ok && nbf > now {
+		return fmt.Errorf("[crypto/jwt] token is not valid yet")
+	}
+	return nil
+}
+
+func newJWT(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("jwt", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	// HS256 tokens take a shared secret, EdDSA tokens an Ed25519 private key
+	// to sign and the public key to verify.
+	proto.SetObject(ctx, "sign", n.Function(n.Describe(
+		n.Arg("claims", n.NewDictType(n.TString, n.TAny)),
+		n.Arg("key", TStringByte),
+		n.Arg("alg", n.TString, n.String("HS256")),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			alg := a.Name("alg").String()
+
+			claims, err := language.ToValue(a.Name("claims"), true)
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/jwt] %w", err)
+			}
+
+			header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/jwt] %w", err)
+			}
+			payload, err := json.Marshal(claims)
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/jwt] %w", err)
+			}
+
+			input := jwtEncoding.EncodeToString(header) + "." + jwtEncoding.EncodeToString(payload)
+			signature, err := jwtSign(alg, a.Name("key"), []byte(input))
+			if err != nil {
+				return nil, err
+			}
+			return input + "." + jwtEncoding.EncodeToString(signature), nil
+		}))
+
+	proto.SetObject(ctx, "verify", n.Function(n.Describe(
+		n.Arg("token", n.TString),
+		n.Arg("key", TStringByte),
+		n.Arg("alg", n.TString, n.String("HS256")),
+	).Returns(n.NewDictType(n.TString, n.TAny)),
+		func(a *n.Args) (any, error) {
+			alg := a.Name("alg").String()
+
+			parts := strings.Split(a.Name("token").String(), ".")
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("[crypto/jwt] malformed token")
+			}
+
+			var header struct {
+				Alg string `json:"alg"`
+			}
+			if err := jwtDecode(parts[0], &header); err != nil {
+				return nil, err
+			}
+			// Never let the token pick its own algorithm.
+			if header.Alg != alg {
+				return nil, fmt.Errorf("[crypto/jwt] expected algorithm %s, got %s", alg, header.Alg)
+			}
+
+			signature, err := jwtEncoding.DecodeString(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/jwt] malformed token: %w", err)
+			}
+			ok, err := jwtVerify(alg, a.Name("key"), []byte(parts[0]+"."+parts[1]), signature)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("[crypto/jwt] invalid signature")
+			}
+
+			var claims map[string]any
+			if err := jwtDecode(parts[1], &claims); err != nil {
+				return nil, err
+			}
+			if err := checkTimes(claims); err != nil {
+				return nil, err
+			}
+
+			return language.FromValue(jsonNumbers(claims), false, dg)
+		}))
+
+	return pkg
+}
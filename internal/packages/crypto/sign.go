@@ -0,0 +1,164 @@
+package crypto
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+func ed25519Private(key language.Object) (ed25519.PrivateKey, error) {
+	data := n.ToBytes(key)
+	if len(data) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("[crypto/ed25519] private key must be %d bytes long, got %d", ed25519.PrivateKeySize, len(data))
+	}
+	return ed25519.PrivateKey(data), nil
+}
+
+func ed25519Public(key language.Object) (ed25519.PublicKey, error) {
+	data := n.ToBytes(key)
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("[crypto/ed25519] public key must be %d bytes long, got %d", ed25519.PublicKeySize, len(data))
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+func newEd25519(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("ed25519", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "generate", n.Function(n.Describe().Returns(TKeyPair),
+		func(a *n.Args) (any, error) {
+			public, private, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ed25519] %w", err)
+			}
+			return keyPair(public, private, dg)
+		}))
+
+	proto.SetObject(ctx, "sign", n.Function(n.Describe(
+		n.Arg("private", TBytes),
+		n.Arg("message", TStringByte),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			key, err := ed25519Private(a.Name("private"))
+			if err != nil {
+				return nil, err
+			}
+			return n.Bytes(ed25519.Sign(key, n.ToBytes(a.Name("message"))), dg), nil
+		}))
+
+	proto.SetObject(ctx, "verify", n.Function(n.Describe(
+		n.Arg("public", TBytes),
+		n.Arg("message", TStringByte),
+		n.Arg("signature", TBytes),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			key, err := ed25519Public(a.Name("public"))
+			if err != nil {
+				return nil, err
+			}
+			return ed25519.Verify(key, n.ToBytes(a.Name("message")), n.ToBytes(a.Name("signature"))), nil
+		}))
+
+	return pkg
+}
+
+var ecdsaCurves = map[string]elliptic.Curve{
+	"P256": elliptic.P256(),
+	"P384": elliptic.P384(),
+	"P521": elliptic.P521(),
+}
+
+// ecdsaDigest hashes message with the hash matching the size of curve.
+func ecdsaDigest(curve elliptic.Curve, message []byte) []byte {
+	switch curve.Params().BitSize {
+	case 256:
+		sum := sha256.Sum256(message)
+		return sum[:]
+	case 384:
+		sum := sha512.Sum384(message)
+		return sum[:]
+	}
+	sum := sha512.Sum512(message)
+	return sum[:]
+}
+
+func newECDSA(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("ecdsa", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	// Keys are DER encoded: SEC 1 for private and PKIX for public keys.
+	proto.SetObject(ctx, "generate", n.Function(n.Describe(
+		n.Arg("curve", n.TString, n.String("P256")),
+	).Returns(TKeyPair),
+		func(a *n.Args) (any, error) {
+			curve, ok := ecdsaCurves[a.Name("curve").String()]
+			if !ok {
+				return nil, fmt.Errorf("[crypto/ecdsa] unknown curve %q", a.Name("curve").String())
+			}
+
+			key, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ecdsa] %w", err)
+			}
+
+			private, err := x509.MarshalECPrivateKey(key)
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ecdsa] %w", err)
+			}
+			public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ecdsa] %w", err)
+			}
+			return keyPair(public, private, dg)
+		}))
+
+	proto.SetObject(ctx, "sign", n.Function(n.Describe(
+		n.Arg("private", TBytes),
+		n.Arg("message", TStringByte),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			key, err := x509.ParseECPrivateKey(n.ToBytes(a.Name("private")))
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ecdsa] invalid private key: %w", err)
+			}
+
+			signature, err := ecdsa.SignASN1(rand.Reader, key, ecdsaDigest(key.Curve, n.ToBytes(a.Name("message"))))
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ecdsa] %w", err)
+			}
+			return n.Bytes(signature, dg), nil
+		}))
+
+	proto.SetObject(ctx, "verify", n.Function(n.Describe(
+		n.Arg("public", TBytes),
+		n.Arg("message", TStringByte),
+		n.Arg("signature", TBytes),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			parsed, err := x509.ParsePKIXPublicKey(n.ToBytes(a.Name("public")))
+			if err != nil {
+				return nil, fmt.Errorf("[crypto/ecdsa] invalid public key: %w", err)
+			}
+			key, ok := parsed.(*ecdsa.PublicKey)
+			if !ok {
+				return nil, fmt.Errorf("[crypto/ecdsa] public key is not an ECDSA key")
+			}
+
+			return ecdsa.VerifyASN1(key, ecdsaDigest(key.Curve, n.ToBytes(a.Name("message"))), n.ToBytes(a.Name("signature"))), nil
+		}))
+
+	return pkg
+}
@@ -0,0 +1,292 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nubolang/nubo/internal/debug"
+	nuboio "github.com/nubolang/nubo/internal/packages/io"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var TRow = n.TTList(n.TString)
+
+func NewCSV(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("csv", dg)
+	proto := instance.GetPrototype()
+
+	readerStruct := newReaderStruct(dg)
+	writerStruct := newWriterStruct(dg)
+
+	ctx := context.Background()
+
+	// With header set, the first row names the fields and every other row
+	// is returned as a dict.
+	proto.SetObject(ctx, "parse", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+		n.Arg("sep", n.TString, n.String(",")),
+		n.Arg("header", n.TBool, n.Bool(false)),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			reader, err := newReader(strings.NewReader(a.Name("text").String()), a.Name("sep").String())
+			if err != nil {
+				return nil, err
+			}
+
+			rows, err := reader.ReadAll()
+			if err != nil {
+				return nil, parseError(err)
+			}
+			if rows == nil {
+				rows = [][]string{}
+			}
+
+			if !a.Name("header").Value().(bool) {
+				return language.FromValue(rows, false, a.Name("text").Debug())
+			}
+
+			records := make([]map[string]any, 0, max(len(rows)-1, 0))
+			if len(rows) > 0 {
+				for _, row := range rows[1:] {
+					record := make(map[string]any, len(rows[0]))
+					for i, name := range rows[0] {
+						record[name] = row[i]
+					}
+					records = append(records, record)
+				}
+			}
+			return language.FromValue(records, false, a.Name("text").Debug())
+		}))
+
+	// decode reads the rows below the header into instances of a struct.
+	// Columns match fields by their csv keys; cells of int, float and bool
+	// fields are parsed and empty cells of nullable fields are nil.
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("type", n.TAny),
+		n.Arg("text", n.TString),
+		n.Arg("sep", n.TString, n.String(",")),
+	).Returns(n.TTList(n.TAny)),
+		func(a *n.Args) (any, error) {
+			typ, ok := a.Name("type").(*language.Struct)
+			if !ok {
+				return nil, fmt.Errorf("[csv] cannot decode into %s", a.Name("type").Type())
+			}
+
+			reader, err := newReader(strings.NewReader(a.Name("text").String()), a.Name("sep").String())
+			if err != nil {
+				return nil, err
+			}
+			return decode(typ, reader, a.Name("text").Debug())
+		}))
+
+	proto.SetObject(ctx, "stringify", n.Function(n.Describe(
+		n.Arg("rows", n.TTList(n.TTList(n.TAny))),
+		n.Arg("sep", n.TString, n.String(",")),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			var buf bytes.Buffer
+			writer, err := newWriter(&buf, a.Name("sep").String())
+			if err != nil {
+				return nil, err
+			}
+			if err := writeAll(writer, a.Name("rows")); err != nil {
+				return nil, err
+			}
+			return buf.String(), nil
+		}))
+
+	proto.SetObject(ctx, "reader", n.Function(n.Describe(
+		n.Arg("stream", n.TAny),
+		n.Arg("sep", n.TString, n.String(",")),
+	).Returns(readerStruct.Type()),
+		func(a *n.Args) (any, error) {
+			r, ok := nuboio.ReaderOf(a.Name("stream"))
+			if !ok {
+				return nil, fmt.Errorf("[csv] expected a readable stream, got %s", a.Name("stream").Type())
+			}
+
+			reader, err := newReader(r, a.Name("sep").String())
+			if err != nil {
+				return nil, err
+			}
+			return withState(readerStruct, reader)
+		}))
+
+	proto.SetObject(ctx, "writer", n.Function(n.Describe(
+		n.Arg("stream", n.TAny),
+		n.Arg("sep", n.TString, n.String(",")),
+	).Returns(writerStruct.Type()),
+		func(a *n.Args) (any, error) {
+			w, ok := nuboio.WriterOf(a.Name("stream"))
+			if !ok {
+				return nil, fmt.Errorf("[csv] expected a writable stream, got %s", a.Name("stream").Type())
+			}
+
+			writer, err := newWriter(w, a.Name("sep").String())
+			if err != nil {
+				return nil, err
+			}
+			return withState(writerStruct, writer)
+		}))
+
+	return instance
+}
+
+func separator(sep string) (rune, error) {
+	r, size := utf8.DecodeRuneInString(sep)
+	if size == 0 || size != len(sep) || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("[csv] invalid separator %q", sep)
+	}
+	return r, nil
+}
+
+func newReader(r io.Reader, sep string) (*csv.Reader, error) {
+	comma, err := separator(sep)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	return reader, nil
+}
+
+func newWriter(w io.Writer, sep string) (*csv.Writer, error) {
+	comma, err := separator(sep)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	return writer, nil
+}
+
+// decode reads a header row and decodes every following row into an
+// instance of s.
+func decode(s *language.Struct, reader *csv.Reader, dg *debug.Debug) (language.Object, error) {
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return language.NewList([]language.Object{}, s.Type(), dg), nil
+	}
+	if err != nil {
+		return nil, parseError(err)
+	}
+
+	types := make(map[string]*language.Type, len(s.Data))
+	for _, field := range s.Data {
+		if key, ok := field.Key("csv"); ok {
+			types[key] = field.Type
+		}
+	}
+
+	items := []language.Object{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, parseError(err)
+		}
+
+		data := make(map[string]any, len(header))
+		for i, name := range header {
+			data[name] = cellValue(types[name], record[i])
+		}
+
+		instance, err := s.FromValue(data, "csv", dg)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("[csv] line %d: %w", line, err)
+		}
+		items = append(items, instance)
+	}
+	return language.NewList(items, s.Type(), dg), nil
+}
+
+// cellValue converts the text of a cell to a value of type t. Cells that
+// fit no member of t stay strings, so decoding reports the mismatch.
+func cellValue(t *language.Type, text string) any {
+	if t == nil {
+		return text
+	}
+	if text == "" && t.Compare(language.TypeNil) {
+		return nil
+	}
+
+	for member := t; member != nil; member = member.Next {
+		switch member.BaseType {
+		case language.ObjectTypeInt:
+			if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+				return i
+			}
+		case language.ObjectTypeFloat:
+			if f, err := strconv.ParseFloat(text, 64); err == nil {
+				return f
+			}
+		case language.ObjectTypeBool:
+			if b, err := strconv.ParseBool(text); err == nil {
+				return b
+			}
+		case language.ObjectTypeString, language.ObjectTypeAny:
+			return text
+		}
+	}
+	return text
+}
+
+// parseError rewrites csv.ParseError as "[csv] line L, column C: msg".
+func parseError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("[csv] line %d, column %d: %s", parseErr.Line, parseErr.Column, parseErr.Err)
+	}
+	return fmt.Errorf("[csv] %w", err)
+}
+
+// row converts a list of cells to strings. Cells that are not strings are
+// written with their string form.
+func row(obj language.Object, index int) ([]string, error) {
+	list, ok := obj.(*language.List)
+	if !ok {
+		return nil, fmt.Errorf("[csv] rows[%d]: expected a list, got %s", index, obj.Type())
+	}
+
+	cells := make([]string, len(list.Data))
+	for i, cell := range list.Data {
+		cells[i] = cell.String()
+	}
+	return cells, nil
+}
+
+func writeAll(writer *csv.Writer, rows language.Object) error {
+	list, ok := rows.(*language.List)
+	if !ok {
+		return fmt.Errorf("[csv] expected a list of rows, got %s", rows.Type())
+	}
+
+	for i, item := range list.Data {
+		cells, err := row(item, i)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(cells); err != nil {
+			return fmt.Errorf("[csv] %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("[csv] %w", err)
+	}
+	return nil
+}
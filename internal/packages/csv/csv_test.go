@@ -0,0 +1,44 @@
+package csv_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCsv(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import csv from "@std/csv"
+
+		const people = csv.parse("name,age\nada,36\n", ",", true)
+		const person = people[0]
+
+		return [person["age"], csv.stringify([["a,b", 1]])]
+	`, nil)
+	assert.Equal(t, []string{"36", "\"a,b\",1\n"}, results)
+}
+
+func TestCsvDecode(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import csv from "@std/csv"
+
+		struct Person {
+			name: string
+			age: int
+			score: float `+"`"+`csv:"points"`+"`"+`
+			admin: bool
+			team: string?
+		}
+
+		const people = csv.decode(Person, "name;age;points;admin;team\nada;36;9.5;true;core\nbob;7;3;false;\n", ";")
+		const ada = people[0]
+		const bob = people[1]
+
+		catch e {
+			csv.decode(Person, "name,age,points,admin,team\nada,36,1,true,x\nbob,old,1,true,x\n")
+		}
+		return [len(people), ada.name, ada.age, ada.score, ada.admin, ada.team, bob.score, isNil(bob.team), e.message]
+	`, nil)
+	assert.Equal(t, []string{"2", "ada", "36", "9.5", "true", "core", "3", "true", "[csv] line 3: age: expected int, got string"}, results)
+}
@@ -0,0 +1,150 @@
+package csv
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// withState returns a new instance of s holding the Go reader or writer.
+func withState(s *language.Struct, state any) (language.Object, error) {
+	instance, err := s.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+	instance.BucketSet("csv", state)
+	return instance, nil
+}
+
+func stateOf[T any](obj language.Object, name string) (T, error) {
+	var zero T
+
+	bucket, ok := obj.(language.Bucketable)
+	if !ok {
+		return zero, fmt.Errorf("[csv/%s] expected %s, got %s", name, name, obj.Type())
+	}
+	value, _ := bucket.BucketGet("csv")
+	state, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("[csv/%s] expected %s, got %s", name, name, obj.Type())
+	}
+	return state, nil
+}
+
+func newReaderStruct(dg *debug.Debug) *language.Struct {
+	ctx := context.Background()
+	s := language.NewStruct("Reader", nil, dg)
+
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	// read returns the next row, or nil once the stream is exhausted.
+	proto.SetObject(ctx, "read", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	).Returns(n.Nullable(TRow)),
+		func(a *n.Args) (any, error) {
+			reader, err := stateOf[*csv.Reader](a.Name("self"), "Reader")
+			if err != nil {
+				return nil, err
+			}
+
+			row, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return language.Nil, nil
+			}
+			if err != nil {
+				return nil, parseError(err)
+			}
+			return language.FromValue(row, false, dg)
+		}))
+
+	proto.SetObject(ctx, "readAll", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	).Returns(n.TTList(TRow)),
+		func(a *n.Args) (any, error) {
+			reader, err := stateOf[*csv.Reader](a.Name("self"), "Reader")
+			if err != nil {
+				return nil, err
+			}
+
+			rows, err := reader.ReadAll()
+			if err != nil {
+				return nil, parseError(err)
+			}
+			if rows == nil {
+				rows = [][]string{}
+			}
+			return language.FromValue(rows, false, dg)
+		}))
+
+	proto.Lock()
+	proto.Implement()
+	return s
+}
+
+func newWriterStruct(dg *debug.Debug) *language.Struct {
+	ctx := context.Background()
+	s := language.NewStruct("Writer", nil, dg)
+
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	// write buffers a row; call flush to write buffered rows to the stream.
+	proto.SetObject(ctx, "write", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("row", n.TTList(n.TAny)),
+	),
+		func(a *n.Args) (any, error) {
+			writer, err := stateOf[*csv.Writer](a.Name("self"), "Writer")
+			if err != nil {
+				return nil, err
+			}
+
+			cells, err := row(a.Name("row"), 0)
+			if err != nil {
+				return nil, err
+			}
+			if err := writer.Write(cells); err != nil {
+				return nil, fmt.Errorf("[csv/Writer] %w", err)
+			}
+			return nil, nil
+		}))
+
+	proto.SetObject(ctx, "writeAll", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("rows", n.TTList(n.TTList(n.TAny))),
+	),
+		func(a *n.Args) (any, error) {
+			writer, err := stateOf[*csv.Writer](a.Name("self"), "Writer")
+			if err != nil {
+				return nil, err
+			}
+			return nil, writeAll(writer, a.Name("rows"))
+		}))
+
+	proto.SetObject(ctx, "flush", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	),
+		func(a *n.Args) (any, error) {
+			writer, err := stateOf[*csv.Writer](a.Name("self"), "Writer")
+			if err != nil {
+				return nil, err
+			}
+
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return nil, fmt.Errorf("[csv/Writer] %w", err)
+			}
+			return nil, nil
+		}))
+
+	proto.Lock()
+	proto.Implement()
+	return s
+}
@@ -0,0 +1,140 @@
+package encoding
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var base64Variants = map[string]*base64.Encoding{
+	"std":    base64.StdEncoding,
+	"url":    base64.URLEncoding,
+	"raw":    base64.RawStdEncoding,
+	"rawurl": base64.RawURLEncoding,
+}
+
+var base32Variants = map[string]*base32.Encoding{
+	"std":    base32.StdEncoding,
+	"hex":    base32.HexEncoding,
+	"raw":    base32.StdEncoding.WithPadding(base32.NoPadding),
+	"rawhex": base32.HexEncoding.WithPadding(base32.NoPadding),
+}
+
+func variant[T any](pkg string, variants map[string]T, name language.Object) (T, error) {
+	enc, ok := variants[name.String()]
+	if !ok {
+		return enc, fmt.Errorf("[encoding/%s] unknown variant %q", pkg, name.String())
+	}
+	return enc, nil
+}
+
+func newBase64(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("base64", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "encode", n.Function(n.Describe(
+		n.Arg("data", TStringByte),
+		n.Arg("variant", n.TString, n.String("std")),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			enc, err := variant("base64", base64Variants, a.Name("variant"))
+			if err != nil {
+				return nil, err
+			}
+			return enc.EncodeToString(n.ToBytes(a.Name("data"))), nil
+		}))
+
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+		n.Arg("variant", n.TString, n.String("std")),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			enc, err := variant("base64", base64Variants, a.Name("variant"))
+			if err != nil {
+				return nil, err
+			}
+			data, err := enc.DecodeString(a.Name("text").String())
+			if err != nil {
+				return nil, fmt.Errorf("[encoding/base64] %w", err)
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	return pkg
+}
+
+func newBase32(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("base32", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "encode", n.Function(n.Describe(
+		n.Arg("data", TStringByte),
+		n.Arg("variant", n.TString, n.String("std")),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			enc, err := variant("base32", base32Variants, a.Name("variant"))
+			if err != nil {
+				return nil, err
+			}
+			return enc.EncodeToString(n.ToBytes(a.Name("data"))), nil
+		}))
+
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+		n.Arg("variant", n.TString, n.String("std")),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			enc, err := variant("base32", base32Variants, a.Name("variant"))
+			if err != nil {
+				return nil, err
+			}
+			data, err := enc.DecodeString(a.Name("text").String())
+			if err != nil {
+				return nil, fmt.Errorf("[encoding/base32] %w", err)
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	return pkg
+}
+
+func newHex(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("hex", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "encode", n.Function(n.Describe(
+		n.Arg("data", TStringByte),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			return hex.EncodeToString(n.ToBytes(a.Name("data"))), nil
+		}))
+
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			data, err := hex.DecodeString(a.Name("text").String())
+			if err != nil {
+				return nil, fmt.Errorf("[encoding/hex] %w", err)
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	proto.SetObject(ctx, "dump", n.Function(n.Describe(
+		n.Arg("data", TStringByte),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			return hex.Dump(n.ToBytes(a.Name("data"))), nil
+		}))
+
+	return pkg
+}
@@ -0,0 +1,353 @@
+package encoding
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// packField is one item of a pack format: count times code, or a single
+// count byte long string for 's'.
+type packField struct {
+	code  byte
+	count int
+}
+
+// byteOrder reads and appends integers in one byte order.
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+var packSizes = map[byte]int{
+	'x': 1, '?': 1, 'b': 1, 'B': 1,
+	'h': 2, 'H': 2,
+	'i': 4, 'I': 4, 'f': 4,
+	'q': 8, 'Q': 8, 'd': 8,
+	's': 1,
+}
+
+// maxPackSize bounds the number of bytes a pack format may describe, so a
+// format cannot make pack allocate without bound.
+const maxPackSize = 1 << 24
+
+// parseFormat parses a pack format such as "<HI4s". The optional first
+// character selects the byte order: '<' or '=' little endian, '>' or '!'
+// big endian. Without one the data is little endian.
+func parseFormat(format string) (byteOrder, []packField, error) {
+	var order byteOrder = binary.LittleEndian
+	if len(format) > 0 {
+		switch format[0] {
+		case '<', '=':
+			format = format[1:]
+		case '>', '!':
+			order = binary.BigEndian
+			format = format[1:]
+		}
+	}
+
+	var fields []packField
+	size := 0
+	for i := 0; i < len(format); i++ {
+		count := -1
+		for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+			if count < 0 {
+				count = 0
+			}
+			count = count*10 + int(format[i]-'0')
+			if count > maxPackSize {
+				return nil, nil, fmt.Errorf("[encoding/binary] count in format %q exceeds %d", format, maxPackSize)
+			}
+		}
+		if i >= len(format) {
+			return nil, nil, fmt.Errorf("[encoding/binary] format %q ends with a count", format)
+		}
+
+		code := format[i]
+		if code == ' ' {
+			continue
+		}
+		if _, ok := packSizes[code]; !ok {
+			return nil, nil, fmt.Errorf("[encoding/binary] unknown format code '%c'", code)
+		}
+		if count < 0 {
+			count = 1
+		}
+		size += packSizes[code] * count
+		if size > maxPackSize {
+			return nil, nil, fmt.Errorf("[encoding/binary] format %q describes more than %d bytes", format, maxPackSize)
+		}
+		fields = append(fields, packField{code: code, count: count})
+	}
+
+	return order, fields, nil
+}
+
+// formatSize returns the number of bytes described by fields and the
+// number of values they take.
+func formatSize(fields []packField) (size, values int) {
+	for _, field := range fields {
+		size += packSizes[field.code] * field.count
+		switch field.code {
+		case 'x':
+		case 's':
+			values++
+		default:
+			values += field.count
+		}
+	}
+	return size, values
+}
+
+func packInt(value language.Object, code byte, min, max int64) (int64, error) {
+	v, ok := value.Value().(int64)
+	if !ok {
+		return 0, fmt.Errorf("[encoding/binary] '%c' expected int, got %s", code, value.Type())
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("[encoding/binary] %d is out of range for '%c'", v, code)
+	}
+	return v, nil
+}
+
+func packFloat(value language.Object, code byte) (float64, error) {
+	switch v := value.Value().(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	}
+	return 0, fmt.Errorf("[encoding/binary] '%c' expected float, got %s", code, value.Type())
+}
+
+func pack(format string, values []language.Object) ([]byte, error) {
+	order, fields, err := parseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	size, want := formatSize(fields)
+	if want != len(values) {
+		return nil, fmt.Errorf("[encoding/binary] format %q expects %d values, got %d", format, want, len(values))
+	}
+
+	out := make([]byte, 0, size)
+	next := 0
+	for _, field := range fields {
+		if field.code == 's' {
+			var data []byte
+			switch values[next].Type().Base() {
+			case language.ObjectTypeString:
+				data = []byte(values[next].String())
+			case language.ObjectTypeList:
+				data = n.ToBytes(values[next])
+			default:
+				return nil, fmt.Errorf("[encoding/binary] 's' expected string or []byte, got %s", values[next].Type())
+			}
+			next++
+
+			// Strings are cut or zero padded to the count.
+			padded := make([]byte, field.count)
+			copy(padded, data)
+			out = append(out, padded...)
+			continue
+		}
+
+		for range field.count {
+			if field.code == 'x' {
+				out = append(out, 0)
+				continue
+			}
+
+			value := values[next]
+			next++
+
+			switch field.code {
+			case '?':
+				b, ok := value.Value().(bool)
+				if !ok {
+					return nil, fmt.Errorf("[encoding/binary] '?' expected bool, got %s", value.Type())
+				}
+				if b {
+					out = append(out, 1)
+				} else {
+					out = append(out, 0)
+				}
+			case 'b':
+				v, err := packInt(value, field.code, math.MinInt8, math.MaxInt8)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, byte(int8(v)))
+			case 'B':
+				if b, ok := value.(*language.Byte); ok {
+					out = append(out, b.Data)
+					break
+				}
+				v, err := packInt(value, field.code, 0, math.MaxUint8)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, byte(v))
+			case 'h':
+				v, err := packInt(value, field.code, math.MinInt16, math.MaxInt16)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint16(out, uint16(int16(v)))
+			case 'H':
+				v, err := packInt(value, field.code, 0, math.MaxUint16)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint16(out, uint16(v))
+			case 'i':
+				v, err := packInt(value, field.code, math.MinInt32, math.MaxInt32)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint32(out, uint32(int32(v)))
+			case 'I':
+				v, err := packInt(value, field.code, 0, math.MaxUint32)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint32(out, uint32(v))
+			case 'q':
+				v, err := packInt(value, field.code, math.MinInt64, math.MaxInt64)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint64(out, uint64(v))
+			case 'Q':
+				v, err := packInt(value, field.code, 0, math.MaxInt64)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint64(out, uint64(v))
+			case 'f':
+				v, err := packFloat(value, field.code)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint32(out, math.Float32bits(float32(v)))
+			case 'd':
+				v, err := packFloat(value, field.code)
+				if err != nil {
+					return nil, err
+				}
+				out = order.AppendUint64(out, math.Float64bits(v))
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func unpack(format string, data []byte, dg *debug.Debug) ([]language.Object, error) {
+	order, fields, err := parseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	size, want := formatSize(fields)
+	if size != len(data) {
+		return nil, fmt.Errorf("[encoding/binary] format %q needs %d bytes, got %d", format, size, len(data))
+	}
+
+	values := make([]language.Object, 0, want)
+	for _, field := range fields {
+		if field.code == 's' {
+			values = append(values, n.Bytes(data[:field.count], dg))
+			data = data[field.count:]
+			continue
+		}
+
+		for range field.count {
+			width := packSizes[field.code]
+			chunk := data[:width]
+			data = data[width:]
+
+			switch field.code {
+			case 'x':
+			case '?':
+				values = append(values, n.Bool(chunk[0] != 0, dg))
+			case 'b':
+				values = append(values, n.Int64(int64(int8(chunk[0])), dg))
+			case 'B':
+				values = append(values, n.Int64(int64(chunk[0]), dg))
+			case 'h':
+				values = append(values, n.Int64(int64(int16(order.Uint16(chunk))), dg))
+			case 'H':
+				values = append(values, n.Int64(int64(order.Uint16(chunk)), dg))
+			case 'i':
+				values = append(values, n.Int64(int64(int32(order.Uint32(chunk))), dg))
+			case 'I':
+				values = append(values, n.Int64(int64(order.Uint32(chunk)), dg))
+			case 'q':
+				values = append(values, n.Int64(int64(order.Uint64(chunk)), dg))
+			case 'Q':
+				v := order.Uint64(chunk)
+				if v > math.MaxInt64 {
+					return nil, fmt.Errorf("[encoding/binary] %d overflows int", v)
+				}
+				values = append(values, n.Int64(int64(v), dg))
+			case 'f':
+				values = append(values, n.Float(float64(math.Float32frombits(order.Uint32(chunk))), dg))
+			case 'd':
+				values = append(values, n.Float(math.Float64frombits(order.Uint64(chunk)), dg))
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func newBinary(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("binary", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "pack", n.Function(n.Describe(
+		n.Arg("format", n.TString),
+		n.Variadic("values", n.TAny),
+	).Returns(TBytes),
+		func(a *n.Args) (any, error) {
+			data, err := pack(a.Name("format").String(), a.Name("values").(*language.List).Data)
+			if err != nil {
+				return nil, err
+			}
+			return n.Bytes(data, dg), nil
+		}))
+
+	proto.SetObject(ctx, "unpack", n.Function(n.Describe(
+		n.Arg("format", n.TString),
+		n.Arg("data", TStringByte),
+	).Returns(n.TTList(n.TAny)),
+		func(a *n.Args) (any, error) {
+			values, err := unpack(a.Name("format").String(), n.ToBytes(a.Name("data")), dg)
+			if err != nil {
+				return nil, err
+			}
+			return language.NewList(values, n.TAny, dg), nil
+		}))
+
+	proto.SetObject(ctx, "size", n.Function(n.Describe(
+		n.Arg("format", n.TString),
+	).Returns(n.TInt),
+		func(a *n.Args) (any, error) {
+			_, fields, err := parseFormat(a.Name("format").String())
+			if err != nil {
+				return nil, err
+			}
+			size, _ := formatSize(fields)
+			return size, nil
+		}))
+
+	return pkg
+}
@@ -0,0 +1,28 @@
+package encoding
+
+import (
+	"context"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var (
+	TStringByte = n.TUnion(n.TString, n.TTList(n.TByte))
+	TBytes      = n.TTList(n.TByte)
+)
+
+func NewEncoding(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("encoding", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "base64", newBase64(dg))
+	proto.SetObject(ctx, "base32", newBase32(dg))
+	proto.SetObject(ctx, "hex", newHex(dg))
+	proto.SetObject(ctx, "url", newURL(dg))
+	proto.SetObject(ctx, "binary", newBinary(dg))
+
+	return pkg
+}
@@ -0,0 +1,58 @@
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoding(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import encoding from "@std/encoding"
+
+		const packed = encoding.binary.pack(">Hb2s", 258, -2, "ok")
+		const unpacked = encoding.binary.unpack(">Hb2s", packed)
+		const query = encoding.url.decode("tag=x&tag=y")
+
+		return [
+			encoding.base64.encode("hello?>"),
+			encoding.base64.encode("hello?>", "rawurl"),
+			string(encoding.base64.decode("aGk=")),
+			encoding.base32.encode("hi"),
+			encoding.hex.encode("Az"),
+			encoding.url.encode({"q": "a b", "tag": ["x", "y"]}),
+			query["tag"][1],
+			encoding.hex.encode(packed),
+			unpacked[0],
+			unpacked[1]
+		]
+	`, nil)
+	assert.Equal(t, []string{"aGVsbG8/Pg==", "aGVsbG8_Pg", "hi", "NBUQ====", "417a", "q=a+b&tag=x&tag=y", "y", "0102fe6f6b", "258", "-2"}, results)
+
+	_, err := nubotest.Exec(`
+		import encoding from "@std/encoding"
+		encoding.binary.pack("B", 300)
+	`, nil)
+	assert.ErrorContains(t, err, "[encoding/binary] 300 is out of range for 'B'")
+}
+
+func TestBinaryFormatLimits(t *testing.T) {
+	for format, message := range map[string]string{
+		"9999999999999999999B": "count in format \"9999999999999999999B\" exceeds 16777216",
+		"16777217x":            "count in format \"16777217x\" exceeds 16777216",
+		"8388608H2B":           "format \"8388608H2B\" describes more than 16777216 bytes",
+	} {
+		_, err := nubotest.Exec(`
+			import encoding from "@std/encoding"
+			encoding.binary.size(format)
+		`, map[string]any{"format": format})
+		assert.ErrorContains(t, err, message, format)
+	}
+
+	results := nubotest.Strings(t, `
+		import encoding from "@std/encoding"
+		return [encoding.binary.size("16777216x")]
+	`, nil)
+	assert.Equal(t, []string{"16777216"}, results)
+}
@@ -0,0 +1,109 @@
+package encoding
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var TQuery = n.NewDictType(n.TString, n.TTList(n.TString))
+
+func newURL(dg *debug.Debug) language.Object {
+	pkg := n.NewPackage("url", dg)
+	proto := pkg.GetPrototype()
+	ctx := context.Background()
+
+	proto.SetObject(ctx, "encode", n.Function(n.Describe(
+		n.Arg("values", n.NewDictType(n.TString, n.TAny)),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			values := url.Values{}
+
+			next := a.Name("values").(*language.Dict).Iterator()
+			for key, value, ok := next(); ok; key, value, ok = next() {
+				// Lists become repeated keys, e.g. tag=a&tag=b.
+				if list, isList := value.(*language.List); isList {
+					for _, item := range list.Data {
+						values.Add(key.String(), item.String())
+					}
+					continue
+				}
+				values.Add(key.String(), value.String())
+			}
+
+			return values.Encode(), nil
+		}))
+
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("query", n.TString),
+	).Returns(TQuery),
+		func(a *n.Args) (any, error) {
+			values, err := url.ParseQuery(a.Name("query").String())
+			if err != nil {
+				return nil, fmt.Errorf("[encoding/url] %w", err)
+			}
+
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			keys := make([]language.Object, len(names))
+			items := make([]language.Object, len(names))
+			for i, name := range names {
+				keys[i] = n.String(name, dg)
+
+				list := make([]language.Object, len(values[name]))
+				for j, value := range values[name] {
+					list[j] = n.String(value, dg)
+				}
+				items[i] = language.NewList(list, n.TString, dg)
+			}
+
+			return language.NewDict(keys, items, n.TString, n.TTList(n.TString), dg)
+		}))
+
+	proto.SetObject(ctx, "escape", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			return url.QueryEscape(a.Name("text").String()), nil
+		}))
+
+	proto.SetObject(ctx, "unescape", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			text, err := url.QueryUnescape(a.Name("text").String())
+			if err != nil {
+				return nil, fmt.Errorf("[encoding/url] %w", err)
+			}
+			return text, nil
+		}))
+
+	proto.SetObject(ctx, "pathEscape", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			return url.PathEscape(a.Name("text").String()), nil
+		}))
+
+	proto.SetObject(ctx, "pathUnescape", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			text, err := url.PathUnescape(a.Name("text").String())
+			if err != nil {
+				return nil, fmt.Errorf("[encoding/url] %w", err)
+			}
+			return text, nil
+		}))
+
+	return pkg
+}
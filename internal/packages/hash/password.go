@@ -15,7 +15,7 @@ var hashBcrypt = n.Function(
 		n.Arg("cost", n.TInt, n.Int(bcrypt.DefaultCost, nil)),
 	).Returns(n.TString),
 	func(a *n.Args) (any, error) {
-		password := toBytes(a.Name("password"))
+		password := n.ToBytes(a.Name("password"))
 		cost := int(a.Name("cost").Value().(int64))
 		hash, err := bcrypt.GenerateFromPassword(password, cost)
 		if err != nil {
@@ -32,8 +32,8 @@ var checkBcrypt = n.Function(
 		n.Arg("hash", TStringByte),
 	).Returns(n.TBool),
 	func(a *n.Args) (any, error) {
-		password := toBytes(a.Name("password"))
-		hash := toBytes(a.Name("hash"))
+		password := n.ToBytes(a.Name("password"))
+		hash := n.ToBytes(a.Name("hash"))
 		err := bcrypt.CompareHashAndPassword(hash, password)
 		return err == nil, nil
 	},
@@ -43,8 +43,8 @@ var checkBcrypt = n.Function(
 var hashArgon2 = n.Function(
 	n.Describe(n.Arg("password", TStringByte), n.Arg("salt", TStringByte)).Returns(n.TString),
 	func(a *n.Args) (any, error) {
-		password := toBytes(a.Name("password"))
-		salt := toBytes(a.Name("salt"))
+		password := n.ToBytes(a.Name("password"))
+		salt := n.ToBytes(a.Name("salt"))
 
 		hash := argon2.IDKey(password, salt, 1, 64*1024, 4, 32)
 		return base64.RawStdEncoding.EncodeToString(hash), nil
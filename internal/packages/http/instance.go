@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 	"github.com/nubolang/nubo/version"
@@ -74,6 +75,10 @@ func NewInstance(dg *debug.Debug) (language.Object, error) {
 			}
 		}
 
+		if err := sandbox.FromContext(a.Context()).CheckNetwork(url); err != nil {
+			return nil, err
+		}
+
 		req, err := http.NewRequest(method, url, body)
 		if err != nil {
 			return nil, err
@@ -2,12 +2,14 @@ package packages
 
 import (
 	"context"
-	"slices"
 	"strings"
 
-	"github.com/nubolang/nubo/config"
 	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/packages/archive"
 	"github.com/nubolang/nubo/internal/packages/component"
+	"github.com/nubolang/nubo/internal/packages/crypto"
+	"github.com/nubolang/nubo/internal/packages/csv"
+	"github.com/nubolang/nubo/internal/packages/encoding"
 	"github.com/nubolang/nubo/internal/packages/hash"
 	"github.com/nubolang/nubo/internal/packages/http"
 	"github.com/nubolang/nubo/internal/packages/io"
@@ -15,10 +17,12 @@ import (
 	"github.com/nubolang/nubo/internal/packages/json"
 	"github.com/nubolang/nubo/internal/packages/log"
 	"github.com/nubolang/nubo/internal/packages/math"
+	"github.com/nubolang/nubo/internal/packages/net"
 	"github.com/nubolang/nubo/internal/packages/net/serial"
 	"github.com/nubolang/nubo/internal/packages/net/ssh"
 	"github.com/nubolang/nubo/internal/packages/net/telnet"
 	"github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/packages/path"
 	"github.com/nubolang/nubo/internal/packages/plugp"
 	"github.com/nubolang/nubo/internal/packages/process"
 	"github.com/nubolang/nubo/internal/packages/random"
@@ -26,6 +30,10 @@ import (
 	"github.com/nubolang/nubo/internal/packages/system"
 	"github.com/nubolang/nubo/internal/packages/thread"
 	"github.com/nubolang/nubo/internal/packages/time"
+	"github.com/nubolang/nubo/internal/packages/toml"
+	"github.com/nubolang/nubo/internal/packages/xml"
+	"github.com/nubolang/nubo/internal/packages/yaml"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
@@ -37,21 +45,18 @@ const (
 var packageList = []string{
 	"io", "math", "json", "log", "thread", "random",
 	"process", "sql", "time", "http", "system",
-	"hash", "component", "os", "iter",
-	"net/serial", "net/telnet", "net/ssh",
+	"hash", "crypto", "encoding", "component", "os", "path", "archive", "iter",
+	"yaml", "csv", "toml", "xml",
+	"net", "net/serial", "net/telnet", "net/ssh",
 	"plug",
 }
 
-var (
-	allowList    []string
-	disallowList []string
-)
-
-func ImportPackage(name string, dg *debug.Debug) (language.Object, bool) {
+// ImportPackage returns the std package called name if policy allows it.
+func ImportPackage(name string, policy *sandbox.Policy, dg *debug.Debug) (language.Object, bool) {
 	if name == "@std" {
 		pkg := n.NewPackage("@std", dg)
 		for _, pkgName := range packageList {
-			getPkg, ok := ImportPackage("@std/"+pkgName, dg)
+			getPkg, ok := ImportPackage("@std/"+pkgName, policy, dg)
 			if !ok {
 				continue
 			}
@@ -65,31 +70,10 @@ func ImportPackage(name string, dg *debug.Debug) (language.Object, bool) {
 	}
 
 	name = strings.TrimPrefix(name, BuiltInModulePrefix)
-
-	if config.Current.Runtime.Std.Disallow == ":all" || config.Current.Runtime.Std.Allow == "-" {
+	if !policy.AllowStd(name) {
 		return nil, false
 	}
 
-	if config.Current.Runtime.Std.Allow != ":all" {
-		if allowList == nil {
-			allowList = strings.Split(config.Current.Runtime.Std.Allow, ",")
-		}
-
-		if !slices.Contains(allowList, name) {
-			return nil, false
-		}
-	}
-
-	if config.Current.Runtime.Std.Disallow != "-" {
-		if disallowList == nil {
-			disallowList = strings.Split(config.Current.Runtime.Std.Disallow, ",")
-		}
-
-		if slices.Contains(disallowList, name) {
-			return nil, false
-		}
-	}
-
 	switch name {
 	case "io":
 		return io.NewIO(dg), true
@@ -121,12 +105,30 @@ func ImportPackage(name string, dg *debug.Debug) (language.Object, bool) {
 		return system.NewSystem(dg), true
 	case "hash":
 		return hash.NewHash(dg), true
+	case "crypto":
+		return crypto.NewCrypto(dg), true
+	case "encoding":
+		return encoding.NewEncoding(dg), true
+	case "yaml":
+		return yaml.NewYAML(dg), true
+	case "csv":
+		return csv.NewCSV(dg), true
+	case "toml":
+		return toml.NewTOML(dg), true
+	case "xml":
+		return xml.NewXML(dg), true
 	case "component":
 		return component.NewComponent(dg), true
 	case "os":
 		return os.NewOS(dg), true
+	case "path":
+		return path.NewPath(dg), true
+	case "archive":
+		return archive.NewArchive(dg), true
 	case "iter":
 		return iter.NewIter(dg), true
+	case "net":
+		return net.NewNet(dg), true
 	case "net/serial":
 		return serial.NewSerial(dg), true
 	case "net/telnet":
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/language"
@@ -13,15 +14,25 @@ import (
 	"github.com/nubolang/nubo/native/n"
 )
 
-var streamStruct *language.Struct
+var (
+	streamStruct     *language.Struct
+	streamStructOnce sync.Once
+)
+
+// StreamStruct returns the Stream struct, defining it on first use so that
+// streams can be created without importing @std/io.
+func StreamStruct(dg *debug.Debug) *language.Struct {
+	streamStructOnce.Do(func() {
+		streamStruct = language.NewStruct("Stream", nil, dg)
+	})
+	return streamStruct
+}
 
 func NewIO(dg *debug.Debug) language.Object {
 	instance := n.NewPackage("io", dg)
 	proto := instance.GetPrototype()
 
-	if streamStruct == nil {
-		streamStruct = language.NewStruct("Stream", nil, dg)
-	}
+	StreamStruct(dg)
 
 	ctx := context.Background()
 	proto.SetObject(ctx, "Stream", streamStruct)
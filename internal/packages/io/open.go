@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"strings"
 
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native"
 	"golang.org/x/text/encoding"
@@ -42,9 +43,10 @@ func openFn(ctx native.FnCtx) (language.Object, error) {
 	}
 	perm := os.FileMode(rawPerm.Value().(int64))
 
-	fileRealPath := fileName.String()
-	if !filepath.IsAbs(fileRealPath) && fileName.Debug() != nil {
-		fileRealPath = filepath.Join(filepath.Dir(fileName.Debug().File), fileRealPath)
+	fileRealPath := nuboos.ResolvePath(ctx.Context(), fileName.String())
+
+	if err := sandbox.FromContext(ctx.Context()).CheckPath(fileRealPath); err != nil {
+		return nil, err
 	}
 
 	enc, err := getEncoding(encodingName.String())
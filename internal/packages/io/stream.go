@@ -32,11 +32,21 @@ func NewIOStream(r io.Reader, optWriter ...io.Writer) language.Object {
 		w = optWriter[0]
 	}
 
-	instance, err := streamStruct.NewInstance()
+	instance, err := StreamStruct(nil).NewInstance()
 	if err != nil {
 		return nil
 	}
 
+	// All reads share one buffer, so readLine does not drop what it read
+	// past the line end.
+	closer := r
+	r = bufio.NewReader(r)
+
+	instance.BucketSet("reader", r)
+	if w != nil {
+		instance.BucketSet("writer", w)
+	}
+
 	proto := instance.GetPrototype().(*language.StructPrototype)
 	proto.Unlock()
 	defer proto.Lock()
@@ -49,7 +59,7 @@ func NewIOStream(r io.Reader, optWriter ...io.Writer) language.Object {
 	proto.SetObject(ctx, "readLines", native.NewTypedFunction(ctx, nil, n.TTList(n.TString), streamReadLinesFn(r)))
 
 	mc := &multiCloser{}
-	if rc, ok := r.(io.Closer); ok {
+	if rc, ok := closer.(io.Closer); ok {
 		mc.closers = append(mc.closers, rc)
 	}
 
@@ -61,7 +71,8 @@ func NewIOStream(r io.Reader, optWriter ...io.Writer) language.Object {
 			&language.BasicFnArg{TypeVal: language.TypeByte, NameVal: "content"},
 		}, language.TypeInt, streamWriteByteFn(w)))
 
-		if wc, ok := w.(io.Closer); ok {
+		// Files opened for reading and writing are both reader and writer.
+		if wc, ok := w.(io.Closer); ok && any(wc) != any(closer) {
 			mc.closers = append(mc.closers, wc)
 		}
 	}
@@ -71,6 +82,28 @@ func NewIOStream(r io.Reader, optWriter ...io.Writer) language.Object {
 	return instance
 }
 
+// ReaderOf returns the reader behind a Stream.
+func ReaderOf(obj language.Object) (io.Reader, bool) {
+	bucket, ok := obj.(language.Bucketable)
+	if !ok {
+		return nil, false
+	}
+	r, _ := bucket.BucketGet("reader")
+	reader, ok := r.(io.Reader)
+	return reader, ok
+}
+
+// WriterOf returns the writer behind a Stream, if it has one.
+func WriterOf(obj language.Object) (io.Writer, bool) {
+	bucket, ok := obj.(language.Bucketable)
+	if !ok {
+		return nil, false
+	}
+	w, _ := bucket.BucketGet("writer")
+	writer, ok := w.(io.Writer)
+	return writer, ok
+}
+
 func streamReadFn(r io.Reader) native.FunctionWrapper {
 	return func(ctx native.FnCtx) (language.Object, error) {
 		buf := make([]byte, 1024)
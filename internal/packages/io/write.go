@@ -3,13 +3,19 @@ package io
 import (
 	"os"
 
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/native/n"
 )
 
 func writeFile(args *n.Args) (any, error) {
-	file := args.Name("file").String()
+	file := nuboos.ResolvePath(args.Context(), args.Name("file").String())
 	data := args.Name("data").String()
 	perm := args.Name("perm").Value().(int64)
 
+	if err := sandbox.FromContext(args.Context()).CheckPath(file); err != nil {
+		return nil, err
+	}
+
 	return nil, os.WriteFile(file, []byte(data), os.FileMode(perm))
 }
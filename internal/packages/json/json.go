@@ -3,6 +3,10 @@ package json
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/language"
@@ -17,6 +21,10 @@ func NewJSON(dg *debug.Debug) language.Object {
 	ctx := context.Background()
 	proto.SetObject(ctx, "parse", native.NewTypedFunction(ctx, native.OneArg("string", language.TypeString), language.TypeAny, parseFn))
 	proto.SetObject(ctx, "stringify", native.NewTypedFunction(ctx, native.OneArg("object", language.TypeAny), language.TypeString, stringifyFn))
+	proto.SetObject(ctx, "decode", native.NewTypedFunction(ctx, []language.FnArg{
+		native.NewArg("type", language.TypeAny),
+		native.NewArg("string", language.TypeString),
+	}, language.TypeAny, decodeFn))
 
 	return instance
 }
@@ -47,7 +55,7 @@ func stringifyFn(ctx native.FnCtx) (language.Object, error) {
 		return nil, err
 	}
 
-	goValue, err := language.ToValue(value, true)
+	goValue, err := language.EncodeValue(value, "json")
 	if err != nil {
 		return nil, err
 	}
@@ -59,3 +67,78 @@ func stringifyFn(ctx native.FnCtx) (language.Object, error) {
 
 	return language.NewString(string(data), value.Debug()), nil
 }
+
+func decodeFn(ctx native.FnCtx) (language.Object, error) {
+	typ, err := ctx.Get("type")
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := ctx.Get("string")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := unmarshalExact(value.Value().(string))
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ := typ.(type) {
+	case *language.Enum:
+		return typ.FromValue(data, value.Debug())
+	case *language.Struct:
+		return typ.FromValue(data, "json", value.Debug())
+	default:
+		return nil, fmt.Errorf("cannot decode into %s", typ.Type())
+	}
+}
+
+// unmarshalExact parses text like json.Unmarshal, but keeps integers that
+// fit an int64 exact instead of rounding them through a float64.
+func unmarshalExact(text string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return exactNumbers(data)
+}
+
+// exactNumbers replaces the json.Number values in data with an int64, or a
+// float64 for fractions and integers outside of the int64 range.
+func exactNumbers(data any) (any, error) {
+	switch data := data.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s: %w", data, err)
+		}
+		return f, nil
+	case []any:
+		for i, item := range data {
+			value, err := exactNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			data[i] = value
+		}
+	case map[string]any:
+		for key, item := range data {
+			value, err := exactNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			data[key] = value
+		}
+	}
+	return data, nil
+}
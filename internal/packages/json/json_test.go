@@ -0,0 +1,59 @@
+package json_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import json from "@std/json"
+
+		struct User {
+			name: string
+			email: string `+"`"+`json:"email_address"`+"`"+`
+			private token: string
+		}
+
+		struct Team {
+			users: []User
+		}
+
+		const team = json.decode(Team, "{\"users\": [{\"name\": \"ada\", \"email_address\": \"ada@example.com\"}]}")
+		const users = team.users
+		const ada = users[0]
+
+		catch e {
+			json.decode(Team, "{\"users\": [{\"name\": \"ada\", \"email_address\": 3}, {\"email_address\": \"x\"}]}")
+		}
+
+		return [ada.email, json.stringify(ada), e.message]
+	`, nil)
+	assert.Equal(t, []string{
+		"ada@example.com",
+		`{"email_address":"ada@example.com","name":"ada"}`,
+		"error calling function json.decode: users[0].email_address: expected string, got int; users[1].name: missing field",
+	}, results)
+}
+
+func TestDecodeNumbers(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import json from "@std/json"
+
+		struct Person {
+			age: int
+			height: float
+			extra: any
+		}
+
+		const p = json.decode(Person, "{\"age\": 9007199254740993, \"height\": 2, \"extra\": 1.5}")
+
+		catch e {
+			json.decode(Person, "{\"age\": 1} {}")
+		}
+		return [p.age, p.height, p.extra, e.message]
+	`, nil)
+	assert.Equal(t, []string{"9007199254740993", "2", "1.5", "error calling function json.decode: invalid character after top-level value"}, results)
+}
@@ -0,0 +1,126 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	nuboio "github.com/nubolang/nubo/internal/packages/io"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// withState returns a new instance of s holding the Go listener or
+// connection.
+func withState(s *language.Struct, state any) (language.Object, error) {
+	instance, err := s.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+	instance.BucketSet("net", state)
+	return instance, nil
+}
+
+func stateOf[T any](obj language.Object, name string) (T, error) {
+	var zero T
+
+	bucket, ok := obj.(language.Bucketable)
+	if !ok {
+		return zero, fmt.Errorf("[net/%s] expected %s, got %s", name, name, obj.Type())
+	}
+	value, _ := bucket.BucketGet("net")
+	state, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("[net/%s] expected %s, got %s", name, name, obj.Type())
+	}
+	return state, nil
+}
+
+// acceptContext accepts a connection, giving up when ctx is done or after
+// timeout if it is positive.
+func acceptContext(ctx context.Context, listener net.Listener, timeout time.Duration) (net.Conn, error) {
+	dl, ok := listener.(interface{ SetDeadline(t time.Time) error })
+	if !ok {
+		return listener.Accept()
+	}
+
+	if timeout > 0 {
+		_ = dl.SetDeadline(time.Now().Add(timeout))
+	}
+	// Cancelling a spawned task stops a pending accept.
+	stop := context.AfterFunc(ctx, func() {
+		_ = dl.SetDeadline(time.Now())
+	})
+	defer func() {
+		stop()
+		_ = dl.SetDeadline(time.Time{})
+	}()
+
+	conn, err := listener.Accept()
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return conn, err
+}
+
+func newListenerStruct(dg *debug.Debug) *language.Struct {
+	ctx := context.Background()
+	s := language.NewStruct("Listener", nil, dg)
+
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	// accept waits for the next connection. Run an accept loop with spawn to
+	// serve connections while doing other work.
+	proto.SetObject(ctx, "accept", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("timeout", n.TInt, n.Int(0, dg)),
+	).Returns(nuboio.StreamStruct(dg).Type()),
+		func(a *n.Args) (any, error) {
+			listener, err := stateOf[net.Listener](a.Name("self"), "Listener")
+			if err != nil {
+				return nil, err
+			}
+
+			conn, err := acceptContext(a.Context(), listener, millis(a.Name("timeout")))
+			if errors.Is(err, net.ErrClosed) {
+				return nil, fmt.Errorf("[net/Listener] listener is closed")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("[net/Listener] %w", err)
+			}
+			return NewConn(conn), nil
+		}))
+
+	proto.SetObject(ctx, "addr", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			listener, err := stateOf[net.Listener](a.Name("self"), "Listener")
+			if err != nil {
+				return nil, err
+			}
+			return listener.Addr().String(), nil
+		}))
+
+	proto.SetObject(ctx, "close", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	),
+		func(a *n.Args) (any, error) {
+			listener, err := stateOf[net.Listener](a.Name("self"), "Listener")
+			if err != nil {
+				return nil, err
+			}
+			if err := listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+				return nil, fmt.Errorf("[net/Listener] %w", err)
+			}
+			return nil, nil
+		}))
+
+	proto.Lock()
+	proto.Implement()
+	return s
+}
@@ -0,0 +1,207 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	nuboio "github.com/nubolang/nubo/internal/packages/io"
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+func NewNet(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("net", dg)
+	proto := instance.GetPrototype()
+
+	listenerStruct := newListenerStruct(dg)
+	packetStruct := newPacketStruct(dg)
+
+	ctx := context.Background()
+	proto.SetObject(ctx, "Listener", listenerStruct)
+	proto.SetObject(ctx, "PacketConn", packetStruct)
+
+	// dial connects to address over network ("tcp", "tcp4", "tcp6", "udp",
+	// "unix"...). A timeout of 0 waits until the system gives up.
+	proto.SetObject(ctx, "dial", n.Function(n.Describe(
+		n.Arg("network", n.TString),
+		n.Arg("address", n.TString),
+		n.Arg("timeout", n.TInt, n.Int(0, dg)),
+	).Returns(nuboio.StreamStruct(dg).Type()),
+		func(a *n.Args) (any, error) {
+			network := a.Name("network").String()
+			address, err := checkAddress(a, network, a.Name("address").String())
+			if err != nil {
+				return nil, err
+			}
+
+			dialer := net.Dialer{Timeout: millis(a.Name("timeout"))}
+			conn, err := dialer.DialContext(a.Context(), network, address)
+			if err != nil {
+				return nil, fmt.Errorf("[net] %w", err)
+			}
+			return NewConn(conn), nil
+		}))
+
+	proto.SetObject(ctx, "listen", n.Function(n.Describe(
+		n.Arg("network", n.TString),
+		n.Arg("address", n.TString),
+	).Returns(listenerStruct.Type()),
+		func(a *n.Args) (any, error) {
+			network := a.Name("network").String()
+			address, err := checkAddress(a, network, a.Name("address").String())
+			if err != nil {
+				return nil, err
+			}
+
+			listener, err := net.Listen(network, address)
+			if err != nil {
+				return nil, fmt.Errorf("[net] %w", err)
+			}
+			return withState(listenerStruct, listener)
+		}))
+
+	proto.SetObject(ctx, "listenPacket", n.Function(n.Describe(
+		n.Arg("network", n.TString),
+		n.Arg("address", n.TString),
+	).Returns(packetStruct.Type()),
+		func(a *n.Args) (any, error) {
+			network := a.Name("network").String()
+			address, err := checkAddress(a, network, a.Name("address").String())
+			if err != nil {
+				return nil, err
+			}
+
+			conn, err := net.ListenPacket(network, address)
+			if err != nil {
+				return nil, fmt.Errorf("[net] %w", err)
+			}
+			return withState(packetStruct, conn)
+		}))
+
+	return instance
+}
+
+// isUnix reports whether network is a unix domain socket network.
+func isUnix(network string) bool {
+	return network == "unix" || network == "unixgram" || network == "unixpacket"
+}
+
+// checkAddress returns an error unless the sandbox of the call allows
+// connecting to address. Unix socket paths resolve like every std path and
+// must lie below the roots; abstract sockets ("@name") are not files.
+func checkAddress(a *n.Args, network, address string) (string, error) {
+	policy := sandbox.FromContext(a.Context())
+	if err := policy.CheckNetwork(address); err != nil {
+		return "", err
+	}
+	if !isUnix(network) || strings.HasPrefix(address, "@") {
+		return address, nil
+	}
+
+	address = nuboos.ResolvePath(a.Context(), address)
+	if err := policy.CheckPath(address); err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// resolveAddr resolves address for sending datagrams over network.
+func resolveAddr(network, address string) (net.Addr, error) {
+	switch {
+	case isUnix(network):
+		return net.ResolveUnixAddr(network, address)
+	case strings.HasPrefix(network, "ip"):
+		return net.ResolveIPAddr(network, address)
+	}
+	return net.ResolveUDPAddr(network, address)
+}
+
+// millis converts a millisecond count to a duration.
+func millis(obj language.Object) time.Duration {
+	return time.Duration(obj.Value().(int64)) * time.Millisecond
+}
+
+// deadline returns the time ms milliseconds from now. 0 clears a deadline.
+func deadline(obj language.Object) time.Time {
+	if d := millis(obj); d > 0 {
+		return time.Now().Add(d)
+	}
+	return time.Time{}
+}
+
+// deadliner is implemented by net.Conn and net.PacketConn.
+type deadliner interface {
+	SetDeadline(t time.Time) error
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+	LocalAddr() net.Addr
+}
+
+// setDeadlines adds the deadline and address methods shared by connections
+// and packet connections to proto.
+func setDeadlines(proto language.Prototype, self *language.Type, conn func(a *n.Args) (deadliner, error)) {
+	ctx := context.Background()
+
+	for name, set := range map[string]func(d deadliner, t time.Time) error{
+		"setDeadline":      deadliner.SetDeadline,
+		"setReadDeadline":  deadliner.SetReadDeadline,
+		"setWriteDeadline": deadliner.SetWriteDeadline,
+	} {
+		args := []*n.FnArg{n.Arg("ms", n.TInt)}
+		if self != nil {
+			args = append([]*n.FnArg{n.Arg("self", self)}, args...)
+		}
+
+		proto.SetObject(ctx, name, n.Function(n.Describe(args...),
+			func(a *n.Args) (any, error) {
+				c, err := conn(a)
+				if err != nil {
+					return nil, err
+				}
+				if err := set(c, deadline(a.Name("ms"))); err != nil {
+					return nil, fmt.Errorf("[net] %w", err)
+				}
+				return nil, nil
+			}))
+	}
+
+	var args []*n.FnArg
+	if self != nil {
+		args = append(args, n.Arg("self", self))
+	}
+	proto.SetObject(ctx, "localAddr", n.Function(n.Describe(args...).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			c, err := conn(a)
+			if err != nil {
+				return nil, err
+			}
+			return c.LocalAddr().String(), nil
+		}))
+}
+
+// NewConn wraps conn in an io Stream with deadline and address methods, so
+// connections work wherever streams do.
+func NewConn(conn net.Conn) language.Object {
+	instance := nuboio.NewIOStream(conn, conn)
+
+	proto := instance.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+	defer proto.Lock()
+
+	setDeadlines(proto, nil, func(a *n.Args) (deadliner, error) {
+		return conn, nil
+	})
+
+	proto.SetObject(context.Background(), "remoteAddr", n.Function(n.Describe().Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			return conn.RemoteAddr().String(), nil
+		}))
+
+	return instance
+}
@@ -0,0 +1,98 @@
+package net_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/nubolang/nubo/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNet(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import net from "@std/net"
+
+		const server = net.listen("tcp", "127.0.0.1:0")
+		const task = spawn fn() string {
+			const conn = server.accept()
+			const line = conn.readLine()
+			conn.write("echo: " + line + "\n")
+			conn.close()
+			return line
+		}()
+
+		const client = net.dial("tcp", server.addr())
+		client.setDeadline(2000)
+		client.write("hello\n")
+		const reply = client.readLine()
+		client.close()
+
+		catch e {
+			server.accept(10)
+		}
+		server.close()
+
+		const a = net.listenPacket("udp", "127.0.0.1:0")
+		const b = net.listenPacket("udp", "127.0.0.1:0")
+		b.sendTo("ping", a.localAddr())
+		a.setReadDeadline(2000)
+		const packet = a.receive()
+		a.close()
+		b.close()
+
+		return [reply, task.wait(), e.message != "", packet["data"], packet["addr"] == b.localAddr()]
+	`, nil)
+	assert.Equal(t, []string{"echo: hello", "hello", "true", "ping", "true"}, results)
+}
+
+func TestUnixgram(t *testing.T) {
+	dir := t.TempDir()
+
+	results := nubotest.Strings(t, `
+		import net from "@std/net"
+		import path from "@std/path"
+
+		const a = net.listenPacket("unixgram", path.join(dir, "a.sock"))
+		const b = net.listenPacket("unixgram", path.join(dir, "b.sock"))
+		b.sendTo("ping", a.localAddr())
+		a.setReadDeadline(2000)
+		const packet = a.receive()
+
+		catch e {
+			a.receive(0)
+		}
+		a.close()
+		b.close()
+
+		return [packet["data"], path.base(packet["addr"]), e.message]
+	`, map[string]any{"dir": dir})
+	assert.Equal(t, []string{"ping", "b.sock", "[net/PacketConn] size must be positive, got 0"}, results)
+}
+
+func TestUnixRoots(t *testing.T) {
+	dir, outside := t.TempDir(), t.TempDir()
+	opts := runtime.DefaultOptions()
+	opts.Roots = []string{dir}
+
+	_, err := nubotest.ExecWithOptions(opts, `
+		import net from "@std/net"
+		import path from "@std/path"
+
+		const conn = net.listenPacket("unixgram", path.join(dir, "a.sock"))
+		conn.close()
+	`, map[string]any{"dir": dir})
+	assert.NoError(t, err)
+
+	for _, script := range []string{
+		`net.listen("unix", path.join(outside, "a.sock"))`,
+		`net.listenPacket("unixgram", path.join(outside, "a.sock"))`,
+		`net.dial("unix", path.join(outside, "a.sock"))`,
+		`net.listenPacket("unixgram", path.join(dir, "c.sock")).sendTo("x", path.join(outside, "b.sock"))`,
+	} {
+		_, err := nubotest.ExecWithOptions(opts, `
+			import net from "@std/net"
+			import path from "@std/path"
+		`+script, map[string]any{"dir": dir, "outside": outside})
+		assert.ErrorContains(t, err, "is outside of the allowed directories", script)
+	}
+}
@@ -0,0 +1,110 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// TPacket is a received datagram: {"data": "...", "addr": "host:port"}.
+var TPacket = n.NewDictType(n.TString, n.TString)
+
+func newPacketStruct(dg *debug.Debug) *language.Struct {
+	ctx := context.Background()
+	s := language.NewStruct("PacketConn", nil, dg)
+
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	proto.SetObject(ctx, "sendTo", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("data", n.TString),
+		n.Arg("address", n.TString),
+	).Returns(n.TInt),
+		func(a *n.Args) (any, error) {
+			conn, err := stateOf[net.PacketConn](a.Name("self"), "PacketConn")
+			if err != nil {
+				return nil, err
+			}
+
+			network := conn.LocalAddr().Network()
+			address, err := checkAddress(a, network, a.Name("address").String())
+			if err != nil {
+				return nil, err
+			}
+			addr, err := resolveAddr(network, address)
+			if err != nil {
+				return nil, fmt.Errorf("[net/PacketConn] %w", err)
+			}
+			written, err := conn.WriteTo([]byte(a.Name("data").String()), addr)
+			if err != nil {
+				return nil, fmt.Errorf("[net/PacketConn] %w", err)
+			}
+			return written, nil
+		}))
+
+	// receive waits for one datagram of at most size bytes.
+	proto.SetObject(ctx, "receive", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("size", n.TInt, n.Int(65535, dg)),
+	).Returns(TPacket),
+		func(a *n.Args) (any, error) {
+			conn, err := stateOf[net.PacketConn](a.Name("self"), "PacketConn")
+			if err != nil {
+				return nil, err
+			}
+
+			size := a.Name("size").Value().(int64)
+			if size <= 0 {
+				return nil, fmt.Errorf("[net/PacketConn] size must be positive, got %d", size)
+			}
+
+			buf := make([]byte, size)
+			read, addr, err := conn.ReadFrom(buf)
+			if errors.Is(err, net.ErrClosed) {
+				return nil, fmt.Errorf("[net/PacketConn] connection is closed")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("[net/PacketConn] %w", err)
+			}
+
+			// Unnamed unix sockets send without an address.
+			from := ""
+			if addr != nil {
+				from = addr.String()
+			}
+
+			return language.NewDict(
+				[]language.Object{n.String("data"), n.String("addr")},
+				[]language.Object{n.String(string(buf[:read])), n.String(from)},
+				n.TString, n.TString, dg,
+			)
+		}))
+
+	proto.SetObject(ctx, "close", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+	),
+		func(a *n.Args) (any, error) {
+			conn, err := stateOf[net.PacketConn](a.Name("self"), "PacketConn")
+			if err != nil {
+				return nil, err
+			}
+			if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+				return nil, fmt.Errorf("[net/PacketConn] %w", err)
+			}
+			return nil, nil
+		}))
+
+	setDeadlines(proto, s.Type(), func(a *n.Args) (deadliner, error) {
+		return stateOf[net.PacketConn](a.Name("self"), "PacketConn")
+	})
+
+	proto.Lock()
+	proto.Implement()
+	return s
+}
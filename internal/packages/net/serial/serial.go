@@ -7,6 +7,7 @@ import (
 	goserial "go.bug.st/serial"
 
 	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
@@ -44,6 +45,10 @@ func openPort(args *n.Args) (any, error) {
 	device := args.Name("device").String()
 	baud := int(args.Name("baud").Value().(int64))
 
+	if err := sandbox.FromContext(args.Context()).CheckPath(device); err != nil {
+		return nil, err
+	}
+
 	mode := &goserial.Mode{BaudRate: baud}
 	p, err := goserial.Open(device, mode)
 	if err != nil {
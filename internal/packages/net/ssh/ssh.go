@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 	"golang.org/x/crypto/ssh"
@@ -97,6 +98,9 @@ func initSSH(args *n.Args) (any, error) {
 			}
 
 			addr := fmt.Sprintf("%s:%d", host, port)
+			if err := sandbox.FromContext(a.Context()).CheckNetwork(addr); err != nil {
+				return nil, err
+			}
 
 			var err error
 			client, err = ssh.Dial("tcp", addr, config)
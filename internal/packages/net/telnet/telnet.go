@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
@@ -45,6 +46,10 @@ func connectTelnet(args *n.Args) (any, error) {
 	port := args.Name("port").Value().(int64)
 	address := net.JoinHostPort(host, strconv.Itoa(int(port)))
 
+	if err := sandbox.FromContext(args.Context()).CheckNetwork(address); err != nil {
+		return nil, err
+	}
+
 	conn, err := net.Dial("tcp", address)
 	if err != nil {
 		return nil, err
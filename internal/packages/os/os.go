@@ -4,29 +4,43 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/internal/packages/time"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
 
-var dirEntry *language.Struct
-var fileInfo *language.Struct
-
-func NewOS(dg *debug.Debug) language.Object {
-	instance := n.NewPackage("os", dg)
-	proto := instance.GetPrototype()
+var (
+	dirEntry     *language.Struct
+	fileInfo     *language.Struct
+	fileInfoOnce sync.Once
+)
 
-	if fileInfo == nil {
+// FileInfoStruct returns the FileInfo struct, defining it on first use.
+func FileInfoStruct(dg *debug.Debug) *language.Struct {
+	fileInfoOnce.Do(func() {
 		fileInfo = language.NewStruct("FileInfo", []language.StructField{
 			{Name: "name", Type: n.TString},
+			{Name: "path", Type: n.TString},
 			{Name: "isDir", Type: n.TBool},
 			{Name: "size", Type: n.TInt},
 			{Name: "mode", Type: n.TInt},
 			{Name: "modTime", Type: time.GetInstance().Type()},
 		}, dg)
-	}
+	})
+	return fileInfo
+}
+
+func NewOS(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("os", dg)
+	proto := instance.GetPrototype()
+
+	FileInfoStruct(dg)
+	watchEvent, watcher := watchStructs(dg)
 
 	if dirEntry == nil {
 		dirEntry = language.NewStruct("DirEntry", []language.StructField{
@@ -40,6 +54,8 @@ func NewOS(dg *debug.Debug) language.Object {
 
 	proto.SetObject(ctx, "FileInfo", fileInfo)
 	proto.SetObject(ctx, "DirEntry", dirEntry)
+	proto.SetObject(ctx, "WatchEvent", watchEvent)
+	proto.SetObject(ctx, "Watcher", watcher)
 
 	proto.SetObject(ctx, "readDir", n.Function(n.Describe(n.Arg("dir", n.TString)).Returns(n.TTList(dirEntry.Type())), readDir))
 
@@ -72,12 +88,27 @@ func NewOS(dg *debug.Debug) language.Object {
 		makeDir,
 	))
 
+	// watch reports changes below path as WatchEvent values, collapsing the
+	// changes of each path within debounce milliseconds into one event.
+	proto.SetObject(ctx, "watch", n.Function(
+		n.Describe(
+			n.Arg("path", n.TString),
+			n.Arg("recursive", n.TBool, n.Bool(false, dg)),
+			n.Arg("debounce", n.TInt, n.Int(100, dg)),
+		).Returns(watcher.Type()),
+		watchPath,
+	))
+
 	return instance
 }
 
 func readDir(args *n.Args) (any, error) {
 	dir := args.Name("dir")
-	entries, err := os.ReadDir(dir.String())
+	path := resolve(args, "dir")
+	if err := checkPaths(args, path); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(path)
 	if err != nil {
 		return nil, err
 	}
@@ -99,31 +130,7 @@ func readDir(args *n.Args) (any, error) {
 			if err != nil {
 				return nil, err
 			}
-
-			name := info.Name()
-			isDir := info.IsDir()
-			size := info.Size()
-			mode := int64(info.Mode())
-			modTime := info.ModTime()
-
-			inst, err := fileInfo.NewInstance()
-			if err != nil {
-				return nil, err
-			}
-
-			proto := inst.GetPrototype()
-			proto.SetObject(ctx, "name", n.String(name, dir.Debug()))
-			proto.SetObject(ctx, "isDir", n.Bool(isDir, dir.Debug()))
-			proto.SetObject(ctx, "size", n.Int64(size, dir.Debug()))
-			proto.SetObject(ctx, "mode", n.Int64(mode, dir.Debug()))
-
-			timeInst, err := time.NewInstance(modTime)
-			if err != nil {
-				return nil, err
-			}
-			proto.SetObject(ctx, "modTime", timeInst)
-
-			return inst, nil
+			return NewFileInfo(filepath.Join(dir.String(), entry.Name()), info, dir.Debug())
 		}))
 
 		result[i] = inst
@@ -134,8 +141,11 @@ func readDir(args *n.Args) (any, error) {
 
 // Copy file from src to dst
 func copyFile(args *n.Args) (any, error) {
-	src := args.Name("src").String()
-	dst := args.Name("dst").String()
+	src := resolve(args, "src")
+	dst := resolve(args, "dst")
+	if err := checkPaths(args, src, dst); err != nil {
+		return nil, err
+	}
 
 	in, err := os.Open(src)
 	if err != nil {
@@ -155,26 +165,49 @@ func copyFile(args *n.Args) (any, error) {
 
 // Move file or directory
 func movePath(args *n.Args) (any, error) {
-	src := args.Name("src").String()
-	dst := args.Name("dst").String()
+	src := resolve(args, "src")
+	dst := resolve(args, "dst")
+	if err := checkPaths(args, src, dst); err != nil {
+		return nil, err
+	}
 	return nil, os.Rename(src, dst)
 }
 
 // Remove file or directory recursively
 func removePath(args *n.Args) (any, error) {
-	path := args.Name("path").String()
+	path := resolve(args, "path")
+	if err := checkPaths(args, path); err != nil {
+		return nil, err
+	}
 	return nil, os.RemoveAll(path)
 }
 
 // Check if path exists
 func existsPath(args *n.Args) (any, error) {
-	path := args.Name("path").String()
+	path := resolve(args, "path")
+	if err := checkPaths(args, path); err != nil {
+		return nil, err
+	}
 	_, err := os.Stat(path)
 	return n.Bool(err == nil, args.Name("path").Debug()), nil
 }
 
 // Create directory recursively
 func makeDir(args *n.Args) (any, error) {
-	path := args.Name("path").String()
+	path := resolve(args, "path")
+	if err := checkPaths(args, path); err != nil {
+		return nil, err
+	}
 	return nil, os.MkdirAll(path, 0755)
 }
+
+// checkPaths fails unless the sandbox of the call allows every path.
+func checkPaths(args *n.Args, paths ...string) error {
+	policy := sandbox.FromContext(args.Context())
+	for _, path := range paths {
+		if err := policy.CheckPath(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
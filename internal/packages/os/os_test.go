@@ -0,0 +1,57 @@
+package os_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/nubolang/nubo/language"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("os.watch is built on inotify")
+	}
+
+	obj := nubotest.Run(t, `
+		import os from "@std/os"
+		import io from "@std/io"
+		import path from "@std/path"
+
+		const w = os.watch(root, true, 200)
+		io.writeFile(path.join(root, "a.txt"), "a")
+		os.mkdir(path.join(root, "sub"))
+		io.writeFile(path.join(root, "sub", "b.txt"), "b")
+		io.writeFile(path.join(root, "tmp.txt"), "x")
+		os.remove(path.join(root, "tmp.txt"))
+
+		const changes = []
+		let e = w.next(2000)
+		while !isNil(e) {
+			changes.push(e.op + " " + path.rel(root, e.path))
+			e = w.next(500)
+		}
+		return [changes, w]
+	`, map[string]any{"root": t.TempDir()})
+
+	values := obj.Value().([]language.Object)
+	assert.Equal(t, "[create a.txt, create sub, create sub/b.txt]", values[0].String())
+
+	// The watcher is closed once the script has finished.
+	next, ok := values[1].GetPrototype().GetObject(context.Background(), "next")
+	assert.True(t, ok)
+	done := make(chan language.Object, 1)
+	go func() {
+		value, _ := next.(*language.Function).Data(context.Background(), nil)
+		done <- value
+	}()
+	select {
+	case value := <-done:
+		assert.Equal(t, language.Nil, value)
+	case <-time.After(time.Second):
+		t.Fatal("watcher still open after the script finished")
+	}
+}
@@ -0,0 +1,57 @@
+package os
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/packages/time"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// ResolvePath is the rule every std function taking a file path follows:
+// absolute paths are used as they are, and relative paths are relative to
+// the directory of the script the call is written in, however the path was
+// built. Code without a script file, such as strings run through
+// Ctx.ExecString, resolves relative paths against the working directory.
+//
+// ctx is the context of the std function call.
+func ResolvePath(ctx context.Context, path string) string {
+	file := debug.FileFrom(ctx)
+	if filepath.IsAbs(path) || file == "" || strings.HasPrefix(file, "<") {
+		return path
+	}
+	return filepath.Join(filepath.Dir(file), path)
+}
+
+// resolve returns the resolved path of the string argument name.
+func resolve(args *n.Args, name string) string {
+	return ResolvePath(args.Context(), args.Name(name).String())
+}
+
+// NewFileInfo returns a FileInfo instance for the file at path.
+func NewFileInfo(path string, info fs.FileInfo, dg *debug.Debug) (language.Object, error) {
+	inst, err := FileInfoStruct(dg).NewInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	proto := inst.GetPrototype()
+	proto.SetObject(ctx, "name", n.String(info.Name(), dg))
+	proto.SetObject(ctx, "path", n.String(path, dg))
+	proto.SetObject(ctx, "isDir", n.Bool(info.IsDir(), dg))
+	proto.SetObject(ctx, "size", n.Int64(info.Size(), dg))
+	proto.SetObject(ctx, "mode", n.Int64(int64(info.Mode()), dg))
+
+	timeInst, err := time.NewInstance(info.ModTime())
+	if err != nil {
+		return nil, err
+	}
+	proto.SetObject(ctx, "modTime", timeInst)
+
+	return inst, nil
+}
@@ -0,0 +1,80 @@
+package os_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/config"
+	"github.com/nubolang/nubo/events"
+	"github.com/nubolang/nubo/internal/ast"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/lexer"
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePath(t *testing.T) {
+	ctx := debug.WithFile(context.Background(), filepath.Join("app", "main.nubo"))
+
+	assert.Equal(t, filepath.Join("app", "data.txt"), nuboos.ResolvePath(ctx, "data.txt"))
+	assert.Equal(t, "/abs/data.txt", nuboos.ResolvePath(ctx, "/abs/data.txt"))
+	assert.Equal(t, "data.txt", nuboos.ResolvePath(context.Background(), "data.txt"))
+	assert.Equal(t, "data.txt", nuboos.ResolvePath(debug.WithFile(context.Background(), "<nativeExecute>"), "data.txt"))
+}
+
+// Relative paths resolve against the script making the call, whether they
+// are literals or computed.
+func TestResolveFromScript(t *testing.T) {
+	config.Verify()
+	dir := t.TempDir()
+	files := map[string]string{
+		"sub/a.txt":     "",
+		"sub/lib/b.txt": "",
+		"sub/lib/check.nubo": `
+			import os from "@std/os"
+
+			fn exists(path: string) bool {
+				return os.exists(path)
+			}
+		`,
+		"sub/main.nubo": `
+			import os from "@std/os"
+			import path from "@std/path"
+			import check from "./lib/check"
+
+			const name = "a"
+			return [
+				os.exists("a.txt"),
+				os.exists(path.join(".", "a.txt")),
+				os.exists(name + ".txt"),
+				check.exists("b.txt"),
+				check.exists("a.txt")
+			]
+		`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	main := filepath.Join(dir, "sub", "main.nubo")
+	file, err := os.Open(main)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	lx, err := lexer.New(file, main)
+	assert.NoError(t, err)
+	tokens, err := lx.Parse()
+	assert.NoError(t, err)
+	nodes, err := ast.New(context.Background(), time.Second).Parse(tokens)
+	assert.NoError(t, err)
+
+	obj, err := runtime.New(events.NewDefaultProvider(), runtime.DefaultOptions()).Interpret(main, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, "[true, true, true, true, false]", obj.String())
+}
@@ -0,0 +1,395 @@
+package os
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nubolang/nubo/events"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/packages/iter"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+var (
+	watchEvent     *language.Struct
+	watcherStruct  *language.Struct
+	watchEventOnce sync.Once
+)
+
+// Watch operations, reported as the op field of a WatchEvent.
+const (
+	opCreate = "create"
+	opWrite  = "write"
+	opRemove = "remove"
+	opRename = "rename"
+)
+
+// fsEvent is a change reported by a watch backend. Renames report the old
+// path; the new path is reported as created.
+type fsEvent struct {
+	op    string
+	path  string
+	isDir bool
+}
+
+// watchBackend delivers the changes of a watched path until it is closed.
+type watchBackend interface {
+	// Events is closed when the backend stops.
+	Events() <-chan fsEvent
+	// Err returns why the backend stopped, or nil if it was closed.
+	Err() error
+	Close() error
+}
+
+// merge combines two changes of the same path seen within one debounce
+// window. It reports false if the changes cancel out, e.g. a file created
+// and removed again.
+func merge(prev, next fsEvent) (fsEvent, bool) {
+	switch {
+	case prev.op == opCreate && next.op == opWrite:
+		return prev, true
+	case prev.op == opCreate && (next.op == opRemove || next.op == opRename):
+		return fsEvent{}, false
+	case (prev.op == opRemove || prev.op == opRename) && next.op == opCreate:
+		next.op = opWrite
+		return next, true
+	}
+	return next, true
+}
+
+// batch holds the changes of one debounce window, one per path, in the
+// order their paths first changed.
+type batch struct {
+	events map[string]fsEvent
+	order  []string
+}
+
+// add merges event into the change pending for its path.
+func (b *batch) add(event fsEvent) {
+	if b.events == nil {
+		b.events = make(map[string]fsEvent)
+	}
+
+	prev, ok := b.events[event.path]
+	if !ok {
+		b.events[event.path] = event
+		b.order = append(b.order, event.path)
+		return
+	}
+
+	if merged, ok := merge(prev, event); ok {
+		b.events[event.path] = merged
+		return
+	}
+	// The changes cancelled out; a later change of the path starts over.
+	delete(b.events, event.path)
+	b.order = slices.DeleteFunc(b.order, func(path string) bool { return path == event.path })
+}
+
+// drain returns the pending changes in order and empties the batch.
+func (b *batch) drain() []fsEvent {
+	events := make([]fsEvent, len(b.order))
+	for i, path := range b.order {
+		events[i] = b.events[path]
+	}
+	clear(b.events)
+	b.order = b.order[:0]
+	return events
+}
+
+// watcher debounces the changes of a backend: changes are held until no
+// new change arrived for the debounce duration, then delivered in the
+// order their paths first changed, one event per path.
+type watcher struct {
+	backend  watchBackend
+	debounce time.Duration
+	// root and display map the watched path to the path the script gave.
+	root, display string
+
+	out       chan fsEvent
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+func newWatcher(backend watchBackend, root, display string, debounce time.Duration) *watcher {
+	w := &watcher{
+		backend:  backend,
+		debounce: debounce,
+		root:     root,
+		display:  display,
+		out:      make(chan fsEvent),
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *watcher) run() {
+	defer close(w.out)
+
+	var (
+		pending batch
+		timer   = time.NewTimer(w.debounce)
+		fire    <-chan time.Time
+	)
+	timer.Stop()
+
+	flush := func() bool {
+		for _, event := range pending.drain() {
+			select {
+			case w.out <- event:
+			case <-w.done:
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		select {
+		case event, ok := <-w.backend.Events():
+			if !ok {
+				w.err = w.backend.Err()
+				flush()
+				return
+			}
+			event.path = w.displayPath(event.path)
+
+			pending.add(event)
+			if w.debounce <= 0 {
+				if !flush() {
+					return
+				}
+				continue
+			}
+			timer.Reset(w.debounce)
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			if !flush() {
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// displayPath returns path relative to the path the script watches.
+func (w *watcher) displayPath(path string) string {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil || rel == "." {
+		return w.display
+	}
+	return filepath.Join(w.display, rel)
+}
+
+// next waits for the next change. It returns false once the watcher is
+// closed, ctx is done or timeout, if positive, elapsed.
+func (w *watcher) next(ctx context.Context, timeout time.Duration) (fsEvent, bool, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case event, ok := <-w.out:
+		if !ok {
+			return fsEvent{}, false, w.err
+		}
+		return event, true, nil
+	case <-w.done:
+		return fsEvent{}, false, nil
+	case <-expired:
+		return fsEvent{}, false, nil
+	case <-ctx.Done():
+		return fsEvent{}, false, ctx.Err()
+	}
+}
+
+func (w *watcher) close() error {
+	err := error(nil)
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.backend.Close()
+	})
+	return err
+}
+
+// watchStructs defines the WatchEvent and Watcher structs on first use.
+func watchStructs(dg *debug.Debug) (*language.Struct, *language.Struct) {
+	watchEventOnce.Do(func() {
+		watchEvent = language.NewStruct("WatchEvent", []language.StructField{
+			{Name: "op", Type: n.TString},
+			{Name: "path", Type: n.TString},
+			{Name: "isDir", Type: n.TBool},
+		}, dg)
+		watcherStruct = newWatcherStruct(dg)
+	})
+	return watchEvent, watcherStruct
+}
+
+func newWatchEvent(event fsEvent, dg *debug.Debug) (language.Object, error) {
+	inst, err := watchEvent.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	proto := inst.GetPrototype()
+	proto.SetObject(ctx, "op", n.String(event.op, dg))
+	proto.SetObject(ctx, "path", n.String(event.path, dg))
+	proto.SetObject(ctx, "isDir", n.Bool(event.isDir, dg))
+	return inst, nil
+}
+
+func watcherOf(self language.Object) (*watcher, error) {
+	inst, ok := self.(*language.StructInstance)
+	if ok {
+		if raw, ok := inst.BucketGet("os"); ok {
+			if w, ok := raw.(*watcher); ok {
+				return w, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("expected Watcher, got %s", self.Type())
+}
+
+func newWatcherStruct(dg *debug.Debug) *language.Struct {
+	ctx := context.Background()
+	s := language.NewStruct("Watcher", nil, dg)
+	sp := s.GetPrototype().(*language.StructPrototype)
+
+	it := iter.NewIter(dg)
+	iterProto := it.GetPrototype()
+	iterator, _ := iterProto.GetObject(ctx, "Iterator")
+	end, _ := iterProto.GetObject(ctx, "End")
+	progress, _ := iterProto.GetObject(ctx, "Progress")
+
+	closeFn := n.Function(n.Describe(n.Arg("self", s.Type())), func(a *n.Args) (any, error) {
+		w, err := watcherOf(a.Name("self"))
+		if err != nil {
+			return nil, err
+		}
+		return nil, w.close()
+	})
+
+	sp.Unlock()
+
+	// next waits for the next event and returns nil once the watcher is
+	// closed or after timeout milliseconds if it is positive.
+	sp.SetObject(ctx, "next", n.Function(n.Describe(
+		n.Arg("self", s.Type()),
+		n.Arg("timeout", n.TInt, n.Int(0, dg)),
+	).Returns(n.Nullable(watchEvent.Type())),
+		func(a *n.Args) (any, error) {
+			self := a.Name("self")
+			w, err := watcherOf(self)
+			if err != nil {
+				return nil, err
+			}
+
+			timeout := time.Duration(a.Name("timeout").Value().(int64)) * time.Millisecond
+			event, ok, err := w.next(a.Context(), timeout)
+			if err != nil || !ok {
+				return language.Nil, err
+			}
+			return newWatchEvent(event, self.Debug())
+		}))
+	sp.SetObject(ctx, "close", closeFn)
+	sp.SetObject(ctx, "__close__", closeFn)
+
+	sp.SetObject(ctx, "__iterate__", n.Function(n.Describe(n.Arg("self", s.Type())).Returns(iterator.Type()), func(a *n.Args) (any, error) {
+		self := a.Name("self")
+		w, err := watcherOf(self)
+		if err != nil {
+			return nil, err
+		}
+		callCtx := a.Context()
+
+		iterInst, err := iterator.(*language.Struct).NewInstance()
+		if err != nil {
+			return nil, err
+		}
+		iterInit, _ := iterInst.GetPrototype().GetObject(ctx, "init")
+		ctx := language.StructAllowPrivateCtx(ctx)
+
+		index := 0
+		return iterInit.(*language.Function).Data(ctx, []language.Object{
+			n.Function(n.Describe().Returns(progress.Type()), func(_ *n.Args) (any, error) {
+				event, ok, err := w.next(callCtx, 0)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					return end, nil
+				}
+
+				value, err := newWatchEvent(event, self.Debug())
+				if err != nil {
+					return nil, err
+				}
+
+				inst, _ := progress.(*language.Struct).NewInstance()
+				progInit, _ := inst.GetPrototype().GetObject(ctx, "init")
+
+				key := n.Int(index)
+				index++
+				return progInit.(*language.Function).Data(ctx, []language.Object{key, value})
+			}),
+		})
+	}))
+	sp.Lock()
+	sp.Implement()
+
+	return s
+}
+
+// watchPath starts watching path for changes. The watcher is closed when the
+// interpreter that started it detaches or, outside of an interpreter, when
+// the context of the call is done.
+func watchPath(args *n.Args) (any, error) {
+	pathArg := args.Name("path")
+	path := resolve(args, "path")
+	if err := checkPaths(args, path); err != nil {
+		return nil, err
+	}
+
+	ctx := args.Context()
+	if ctx == nil || ctx.Done() == nil && !events.HasDetach(ctx) {
+		return nil, errors.New("os.watch needs to run in a script or with a cancellable context")
+	}
+
+	debounce := time.Duration(args.Name("debounce").Value().(int64)) * time.Millisecond
+	if debounce < 0 {
+		return nil, errors.New("debounce must not be negative")
+	}
+
+	backend, err := newWatchBackend(path, args.Name("recursive").Value().(bool))
+	if err != nil {
+		return nil, err
+	}
+	w := newWatcher(backend, path, pathArg.String(), debounce)
+
+	_, s := watchStructs(pathArg.Debug())
+	inst, err := s.NewInstance()
+	if err != nil {
+		_ = w.close()
+		return nil, err
+	}
+	inst.BucketSet("os", w)
+
+	if !events.OnDetach(ctx, w.close) {
+		context.AfterFunc(ctx, func() { _ = w.close() })
+	}
+	return inst, nil
+}
@@ -0,0 +1,216 @@
+//go:build linux
+
+package os
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE |
+	unix.IN_DELETE | unix.IN_DELETE_SELF | unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_MOVE_SELF
+
+// inotify watches a file or directory, and with recursive every directory
+// below it, including directories created later.
+type inotify struct {
+	fd        int
+	file      *os.File
+	root      string
+	recursive bool
+
+	mu   sync.Mutex
+	dirs map[int]string // watch descriptor -> path
+
+	events    chan fsEvent
+	err       error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newWatchBackend(path string, recursive bool) (watchBackend, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	w := &inotify{
+		// A non-blocking descriptor goes through the runtime poller, so
+		// closing the file stops a pending read.
+		fd:        fd,
+		file:      os.NewFile(uintptr(fd), "inotify"),
+		root:      path,
+		recursive: recursive && info.IsDir(),
+		dirs:      make(map[int]string),
+		events:    make(chan fsEvent),
+		done:      make(chan struct{}),
+	}
+
+	if w.recursive {
+		err = w.addTree(path, nil)
+	} else {
+		err = w.add(path)
+	}
+	if err != nil {
+		w.file.Close()
+		return nil, err
+	}
+
+	go w.read()
+	return w, nil
+}
+
+func (w *inotify) add(path string) error {
+	wd, err := unix.InotifyAddWatch(w.fd, path, inotifyMask)
+	if err != nil {
+		return &fs.PathError{Op: "watch", Path: path, Err: err}
+	}
+
+	w.mu.Lock()
+	w.dirs[wd] = path
+	w.mu.Unlock()
+	return nil
+}
+
+// addTree watches root and every directory below it. Files and directories
+// found below root are passed to found, as they may have been created
+// before their directory was watched.
+func (w *inotify) addTree(root string, found func(path string, isDir bool)) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Entries removed while walking are reported by their parent.
+			if errors.Is(err, fs.ErrNotExist) && path != root {
+				return nil
+			}
+			return err
+		}
+		if path != root && found != nil {
+			found(path, d.IsDir())
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.add(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	})
+}
+
+func (w *inotify) read() {
+	defer close(w.events)
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.err = err
+			}
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			wd := int(int32(binary.NativeEndian.Uint32(buf[offset:])))
+			mask := binary.NativeEndian.Uint32(buf[offset+4:])
+			nameLen := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+			name := strings.TrimRight(string(buf[offset+unix.SizeofInotifyEvent:offset+unix.SizeofInotifyEvent+nameLen]), "\x00")
+			offset += unix.SizeofInotifyEvent + nameLen
+
+			if !w.handle(wd, mask, name) {
+				return
+			}
+		}
+	}
+}
+
+// handle turns one inotify event into changes. It returns false once the
+// watcher is closed.
+func (w *inotify) handle(wd int, mask uint32, name string) bool {
+	w.mu.Lock()
+	dir, ok := w.dirs[wd]
+	if mask&unix.IN_IGNORED != 0 {
+		delete(w.dirs, wd)
+	}
+	w.mu.Unlock()
+	if !ok || mask&unix.IN_IGNORED != 0 {
+		return true
+	}
+
+	path := dir
+	if name != "" {
+		path = filepath.Join(dir, name)
+	}
+	isDir := mask&unix.IN_ISDIR != 0
+
+	// Removing or moving a directory below the root is also reported by
+	// its parent, so only the root reports changes to itself.
+	if mask&(unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0 && path != w.root {
+		return true
+	}
+
+	var op string
+	switch {
+	case mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+		op = opCreate
+	case mask&(unix.IN_MODIFY|unix.IN_CLOSE_WRITE) != 0:
+		op = opWrite
+	case mask&(unix.IN_DELETE|unix.IN_DELETE_SELF) != 0:
+		op = opRemove
+	case mask&(unix.IN_MOVED_FROM|unix.IN_MOVE_SELF) != 0:
+		op = opRename
+	default:
+		return true
+	}
+
+	if !w.send(fsEvent{op: op, path: path, isDir: isDir}) {
+		return false
+	}
+
+	if op == opCreate && isDir && w.recursive {
+		open := true
+		_ = w.addTree(path, func(path string, isDir bool) {
+			open = open && w.send(fsEvent{op: opCreate, path: path, isDir: isDir})
+		})
+		return open
+	}
+	return true
+}
+
+// send delivers event unless the watcher is closed first.
+func (w *inotify) send(event fsEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+func (w *inotify) Events() <-chan fsEvent {
+	return w.events
+}
+
+func (w *inotify) Err() error {
+	return w.err
+}
+
+func (w *inotify) Close() error {
+	err := error(nil)
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.file.Close()
+	})
+	return err
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package os
+
+import (
+	"errors"
+	"runtime"
+)
+
+func newWatchBackend(path string, recursive bool) (watchBackend, error) {
+	return nil, errors.New("watching files is not supported on " + runtime.GOOS)
+}
@@ -0,0 +1,104 @@
+package os
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBackend delivers the events sent on its channel.
+type fakeBackend struct {
+	events chan fsEvent
+}
+
+func (f *fakeBackend) Events() <-chan fsEvent { return f.events }
+func (f *fakeBackend) Err() error             { return nil }
+func (f *fakeBackend) Close() error           { return nil }
+
+func collect(t *testing.T, w *watcher) []fsEvent {
+	t.Helper()
+
+	var events []fsEvent
+	for {
+		event, ok, err := w.next(context.Background(), time.Second)
+		assert.NoError(t, err)
+		if !ok {
+			return events
+		}
+		events = append(events, event)
+	}
+}
+
+func TestWatcherDebounce(t *testing.T) {
+	backend := &fakeBackend{events: make(chan fsEvent, 16)}
+	for _, event := range []fsEvent{
+		{op: opCreate, path: "/r/a"},
+		{op: opRemove, path: "/r/a"},
+		{op: opCreate, path: "/r/b"},
+		{op: opCreate, path: "/r/a"},
+		{op: opWrite, path: "/r/a"},
+		{op: opWrite, path: "/r/c"},
+		{op: opRemove, path: "/r/c"},
+		{op: opRename, path: "/r/d"},
+		{op: opCreate, path: "/r/d"},
+	} {
+		backend.events <- event
+	}
+	close(backend.events)
+
+	w := newWatcher(backend, "/r", "r", 50*time.Millisecond)
+	defer w.close()
+
+	assert.Equal(t, []fsEvent{
+		{op: opCreate, path: "r/b"},
+		{op: opCreate, path: "r/a"},
+		{op: opRemove, path: "r/c"},
+		{op: opWrite, path: "r/d"},
+	}, collect(t, w))
+}
+
+func TestWatcherNoDebounce(t *testing.T) {
+	backend := &fakeBackend{events: make(chan fsEvent, 4)}
+	backend.events <- fsEvent{op: opCreate, path: "/r/a"}
+	backend.events <- fsEvent{op: opRemove, path: "/r/a"}
+	close(backend.events)
+
+	w := newWatcher(backend, "/r", "/r", 0)
+	defer w.close()
+
+	assert.Equal(t, []fsEvent{
+		{op: opCreate, path: "/r/a"},
+		{op: opRemove, path: "/r/a"},
+	}, collect(t, w))
+}
+
+func TestWatchContext(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("os.watch is built on inotify")
+	}
+
+	watch, _ := NewOS(nil).GetPrototype().GetObject(context.Background(), "watch")
+	fn := watch.(*language.Function)
+
+	// Outside of a script a watcher must be tied to a context that ends.
+	_, err := fn.Data(context.Background(), []language.Object{n.String(t.TempDir())})
+	assert.ErrorContains(t, err, "cancellable context")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	obj, err := fn.Data(ctx, []language.Object{n.String(t.TempDir())})
+	assert.NoError(t, err)
+	w, err := watcherOf(obj)
+	assert.NoError(t, err)
+
+	cancel()
+	select {
+	case <-w.done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher still open after its context was cancelled")
+	}
+}
@@ -0,0 +1,175 @@
+package path
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// NewPath returns @std/path. Paths use the separator of the host system.
+// Relative paths given to abs, glob and walk resolve like every other std
+// path, see os.ResolvePath: against the directory of the script calling
+// them.
+func NewPath(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("path", dg)
+	proto := instance.GetPrototype()
+
+	ctx := context.Background()
+	proto.SetObject(ctx, "sep", n.String(string(filepath.Separator), dg))
+
+	proto.SetObject(ctx, "join", n.Function(n.Describe(
+		n.Variadic("parts", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			list := a.Name("parts").(*language.List)
+			parts := make([]string, len(list.Data))
+			for i, part := range list.Data {
+				parts[i] = part.String()
+			}
+			return filepath.Join(parts...), nil
+		}))
+
+	for name, fn := range map[string]func(string) string{
+		"clean": filepath.Clean,
+		"dir":   filepath.Dir,
+		"base":  filepath.Base,
+		"ext":   filepath.Ext,
+	} {
+		proto.SetObject(ctx, name, n.Function(n.Describe(
+			n.Arg("path", n.TString),
+		).Returns(n.TString),
+			func(a *n.Args) (any, error) {
+				return fn(a.Name("path").String()), nil
+			}))
+	}
+
+	proto.SetObject(ctx, "isAbs", n.Function(n.Describe(
+		n.Arg("path", n.TString),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			return filepath.IsAbs(a.Name("path").String()), nil
+		}))
+
+	proto.SetObject(ctx, "abs", n.Function(n.Describe(
+		n.Arg("path", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			path, err := filepath.Abs(os.ResolvePath(a.Context(), a.Name("path").String()))
+			if err != nil {
+				return nil, fmt.Errorf("[path] %w", err)
+			}
+			return path, nil
+		}))
+
+	// rel returns target relative to base, failing if it cannot be made so.
+	proto.SetObject(ctx, "rel", n.Function(n.Describe(
+		n.Arg("base", n.TString),
+		n.Arg("target", n.TString),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			path, err := filepath.Rel(a.Name("base").String(), a.Name("target").String())
+			if err != nil {
+				return nil, fmt.Errorf("[path] %w", err)
+			}
+			return path, nil
+		}))
+
+	proto.SetObject(ctx, "match", n.Function(n.Describe(
+		n.Arg("pattern", n.TString),
+		n.Arg("name", n.TString),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			ok, err := filepath.Match(a.Name("pattern").String(), a.Name("name").String())
+			if err != nil {
+				return nil, fmt.Errorf("[path] invalid pattern %q", a.Name("pattern").String())
+			}
+			return ok, nil
+		}))
+
+	// glob returns the paths matching pattern. Matches of a relative
+	// pattern are relative too.
+	proto.SetObject(ctx, "glob", n.Function(n.Describe(
+		n.Arg("pattern", n.TString),
+	).Returns(n.TTList(n.TString)),
+		func(a *n.Args) (any, error) {
+			pattern := a.Name("pattern")
+			matches, err := filepath.Glob(os.ResolvePath(a.Context(), pattern.String()))
+			if err != nil {
+				return nil, fmt.Errorf("[path] invalid pattern %q", pattern.String())
+			}
+
+			policy := sandbox.FromContext(a.Context())
+			base := os.ResolvePath(a.Context(), ".")
+
+			paths := make([]string, len(matches))
+			for i, match := range matches {
+				if err := policy.CheckPath(match); err != nil {
+					return nil, err
+				}
+
+				paths[i] = match
+				if !filepath.IsAbs(pattern.String()) {
+					if rel, err := filepath.Rel(base, match); err == nil {
+						paths[i] = rel
+					}
+				}
+			}
+			return language.FromValue(paths, false, pattern.Debug())
+		}))
+
+	// walk returns a FileInfo for every file and directory below root, in
+	// lexical order. Their paths start with root as it was given.
+	proto.SetObject(ctx, "walk", n.Function(n.Describe(
+		n.Arg("root", n.TString),
+	).Returns(n.TTList(os.FileInfoStruct(dg).Type())),
+		func(a *n.Args) (any, error) {
+			root := a.Name("root")
+			resolved := os.ResolvePath(a.Context(), root.String())
+			if err := sandbox.FromContext(a.Context()).CheckPath(resolved); err != nil {
+				return nil, err
+			}
+
+			var infos []language.Object
+			err := filepath.WalkDir(resolved, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if err := a.Context().Err(); err != nil {
+					return err
+				}
+				if path == resolved {
+					return nil
+				}
+
+				info, err := entry.Info()
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(resolved, path)
+				if err != nil {
+					return err
+				}
+
+				obj, err := os.NewFileInfo(filepath.Join(root.String(), rel), info, root.Debug())
+				if err != nil {
+					return err
+				}
+				infos = append(infos, obj)
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("[path] %w", err)
+			}
+
+			return language.NewList(infos, os.FileInfoStruct(dg).Type(), root.Debug()), nil
+		}))
+
+	return instance
+}
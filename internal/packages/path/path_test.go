@@ -0,0 +1,42 @@
+package path_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub", "deep"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("abc"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "deep", "b.md"), nil, 0o644))
+
+	results := nubotest.Strings(t, `
+		import path from "@std/path"
+
+		const names = []
+		for info in path.walk(root) {
+			if info.isDir {
+				names.push(path.rel(root, info.path) + "/")
+			} else {
+				names.push(path.rel(root, info.path) + ":" + string(info.size))
+			}
+		}
+
+		const matches = path.glob(path.join(root, "*.txt"))
+
+		return [
+			path.join("a", "b", "../c.txt"),
+			path.base("dir/file.tar.gz"),
+			path.ext("file.tar.gz"),
+			path.match("*.md", "b.md"),
+			names,
+			path.base(matches[0])
+		]
+	`, map[string]any{"root": filepath.Join(dir, "sub")})
+	assert.Equal(t, []string{"a/c.txt", "file.tar.gz", ".gz", "true", "[a.txt:3, deep/, deep/b.md:0]", "a.txt"}, results)
+}
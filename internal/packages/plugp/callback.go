@@ -0,0 +1,188 @@
+package plugp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nubolang/nubo/events"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/plug"
+	"go.uber.org/zap"
+)
+
+// forwarders holds the active forwarding of events from a plugin to a
+// provider, so requiring the same plugin twice does not deliver events twice.
+var (
+	forwardersMu sync.Mutex
+	forwarders   = make(map[subscription]*forwarder)
+)
+
+type subscription struct {
+	plugin   *plug.Plugin
+	provider events.Provider
+}
+
+// forwarder is the plugin subscription of one (plugin, provider) pair. It
+// is removed once every interpreter that required the plugin detached.
+type forwarder struct {
+	refs        int
+	unsubscribe func()
+}
+
+// toPlugValue converts obj for sending to pl. Functions, also inside lists
+// and dicts, are registered as callbacks; release functions are appended to
+// releases and must be called once the plugin call returns.
+func toPlugValue(ctx context.Context, obj language.Object, pl *plug.Plugin, releases *[]func(), dg *debug.Debug) (any, error) {
+	switch v := obj.(type) {
+	case *language.Function:
+		cb, release := pl.RegisterCallback(callbackFunc(ctx, v, dg))
+		*releases = append(*releases, release)
+		return cb, nil
+
+	case *language.List:
+		out := make([]any, len(v.Data))
+		for i, item := range v.Data {
+			val, err := toPlugValue(ctx, item, pl, releases, dg)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = val
+		}
+		return out, nil
+
+	case *language.Dict:
+		out := make(map[string]any)
+		err := v.Data.IterateErr(func(key, value language.Object) error {
+			val, err := toPlugValue(ctx, value, pl, releases, dg)
+			if err != nil {
+				return err
+			}
+			out[key.String()] = val
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	return language.ToValue(obj)
+}
+
+// callbackFunc wraps a Nubo function so the plugin can call it. Arguments are
+// converted against the function's declared argument types.
+func callbackFunc(ctx context.Context, fn *language.Function, dg *debug.Debug) plug.HostFunc {
+	return func(_ context.Context, params []byte) (any, error) {
+		var raw []any
+		if err := plug.Unmarshal(params, &raw); err != nil {
+			return nil, err
+		}
+
+		args := make([]language.Object, len(raw))
+		for i, value := range raw {
+			var typ *language.Type
+			if i < len(fn.ArgTypes) {
+				typ = fn.ArgTypes[i].Type()
+			}
+
+			obj, err := fromTyped(value, typ, dg)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = obj
+		}
+
+		result, err := fn.Call(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, nil
+		}
+		return language.ToValue(result)
+	}
+}
+
+// forwardEvents delivers events published by pl to provider until the
+// interpreter ctx belongs to detaches. A topic matches the Nubo events
+// declared with that name.
+func forwardEvents(ctx context.Context, pl *plug.Plugin, provider events.Provider, dg *debug.Debug) {
+	key := subscription{pl, provider}
+
+	forwardersMu.Lock()
+	f, ok := forwarders[key]
+	if !ok {
+		f = &forwarder{unsubscribe: pl.Subscribe(publishTo(pl, provider, dg))}
+		forwarders[key] = f
+	}
+	f.refs++
+	forwardersMu.Unlock()
+
+	release := func() error {
+		forwardersMu.Lock()
+		defer forwardersMu.Unlock()
+
+		if f.refs--; f.refs == 0 {
+			f.unsubscribe()
+			delete(forwarders, key)
+		}
+		return nil
+	}
+	if !events.OnDetach(ctx, release) {
+		context.AfterFunc(ctx, func() { _ = release() })
+	}
+}
+
+// publishTo returns the function that publishes the events of pl to the
+// matching events of provider.
+func publishTo(pl *plug.Plugin, provider events.Provider, dg *debug.Debug) plug.PublishFunc {
+	return func(topic string, params []byte) error {
+		var raw []any
+		if err := plug.Unmarshal(params, &raw); err != nil {
+			return err
+		}
+
+		found := false
+		for _, event := range provider.Events() {
+			if event.Name != topic {
+				continue
+			}
+			found = true
+
+			if len(event.Args) != len(raw) {
+				return fmt.Errorf("event %s expects %d arguments, got %d", topic, len(event.Args), len(raw))
+			}
+
+			data := make(events.TransportData, len(raw))
+			for i, value := range raw {
+				obj, err := fromTyped(value, event.Args[i].Type(), dg)
+				if err != nil {
+					return err
+				}
+				if !language.TypeCheck(event.Args[i].Type(), obj.Type()) {
+					return fmt.Errorf("event %s argument %d (%s) expected type %s, got %s", topic, i+1, event.Args[i].Name(), event.Args[i].Type(), obj.Type())
+				}
+				data[i] = obj
+			}
+
+			if err := provider.Publish(event.ID, data); err != nil {
+				return err
+			}
+		}
+
+		if !found {
+			zap.L().Debug("@std/plug.publish: no such event", zap.String("plugin", pl.DisplayName), zap.String("topic", topic))
+			return fmt.Errorf("event %s is not declared", topic)
+		}
+		return nil
+	}
+}
+
+// releaseAll unregisters the callbacks created for a finished call.
+func releaseAll(releases *[]func()) {
+	for _, release := range *releases {
+		release()
+	}
+}
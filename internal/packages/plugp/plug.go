@@ -3,12 +3,15 @@ package plugp
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/nubolang/nubo/events"
 	"github.com/nubolang/nubo/internal/debug"
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 	"github.com/nubolang/nubo/plug"
+	"go.uber.org/zap"
 )
 
 var (
@@ -38,19 +41,18 @@ func NewPlug(dg *debug.Debug) language.Object {
 		).Returns(n.NewDictType(n.TString, n.TAny)),
 			func(a *n.Args) (any, error) {
 				self := a.Name("self").Value().(*language.StructInstance)
-				rawPlugin, ok := self.BucketGet("_plugin")
-				if !ok {
-					return nil, fmt.Errorf("plugin cannot be loaded")
-				}
-				pl, ok := rawPlugin.(*plug.Plugin)
-				if !ok {
-					return nil, fmt.Errorf("plugin cannot be loaded")
+				pl, err := pluginOf(self)
+				if err != nil {
+					return nil, err
 				}
 
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+				ctx, cancel := context.WithTimeout(a.Context(), pl.Timeout())
 				defer cancel()
 
-				val, err := language.ToValue(a.Name("props"))
+				var releases []func()
+				defer releaseAll(&releases)
+
+				val, err := toPlugValue(ctx, a.Name("props"), pl, &releases, self.Debug())
 				if err != nil {
 					return nil, err
 				}
@@ -63,11 +65,50 @@ func NewPlug(dg *debug.Debug) language.Object {
 				return language.FromValue(data, false, self.Debug())
 			}))
 
+		ps.SetObject(ctx, "stream", n.Function(n.Describe(
+			n.Arg("self", plugStruct.Type()),
+			n.Arg("action", n.TString),
+			n.Arg("props", n.Nullable(n.NewDictType(n.TString, n.TAny)), language.Nil),
+		).Returns(getStreamStruct(dg).Type()),
+			func(a *n.Args) (any, error) {
+				self := a.Name("self").Value().(*language.StructInstance)
+				pl, err := pluginOf(self)
+				if err != nil {
+					return nil, err
+				}
+
+				return openStream(a.Context(), pl, a.Name("action").String(), func(releases *[]func()) (any, error) {
+					return toPlugValue(a.Context(), a.Name("props"), pl, releases, self.Debug())
+				}, nil, self.Debug())
+			}))
+
+		ps.SetObject(ctx, "describe", n.Function(n.Describe(
+			n.Arg("self", plugStruct.Type()),
+		).Returns(n.TTList(n.NewDictType(n.TString, n.TAny))),
+			func(a *n.Args) (any, error) {
+				self := a.Name("self").Value().(*language.StructInstance)
+				pl, err := pluginOf(self)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx, cancel := context.WithTimeout(a.Context(), pl.Timeout())
+				defer cancel()
+
+				manifest, err := pl.Describe(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				return manifestToList(manifest, self.Debug())
+			}))
+
 		ps.Lock()
 		ps.Implement()
 	}
 
 	proto.SetObject(ctx, "Plug", plugStruct)
+	proto.SetObject(ctx, "Stream", getStreamStruct(dg))
 	proto.SetObject(ctx, "require", n.Function(n.Describe(
 		n.Arg("mode", n.TString, n.String("stdio", dg)),
 		n.Arg("path", n.TString, n.String("./backend", dg)),
@@ -79,7 +120,17 @@ func NewPlug(dg *debug.Debug) language.Object {
 			opts = append(opts, plug.WithToken(token.String()))
 		}
 
-		pl, err := plugManager.Load(a.Name("path").String(), opts...)
+		path := nuboos.ResolvePath(a.Context(), a.Name("path").String())
+
+		policy := sandbox.FromContext(a.Context())
+		if err := policy.CheckProcess("plugin " + path); err != nil {
+			return nil, err
+		}
+		if err := policy.CheckPath(path); err != nil {
+			return nil, err
+		}
+
+		pl, err := plugManager.Load(path, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -90,8 +141,166 @@ func NewPlug(dg *debug.Debug) language.Object {
 		}
 
 		inst.BucketSet("_plugin", pl)
+
+		if provider, ok := events.ProviderFrom(a.Context()); ok {
+			forwardEvents(a.Context(), pl, provider, dg)
+		}
+
+		describeCtx, cancel := context.WithTimeout(a.Context(), pl.Timeout())
+		defer cancel()
+
+		// Plugins without a manifest keep working through send.
+		manifest, err := pl.Describe(describeCtx)
+		if err != nil {
+			zap.L().Debug("@std/plug.require: no manifest", zap.String("plugin", pl.DisplayName), zap.Error(err))
+			return inst, nil
+		}
+
+		if err := bindMethods(inst, pl, manifest, dg); err != nil {
+			return nil, err
+		}
 		return inst, nil
 	}))
 
 	return pkg
 }
+
+// pluginOf returns the plugin handle stored in a Plug instance.
+func pluginOf(self *language.StructInstance) (*plug.Plugin, error) {
+	rawPlugin, ok := self.BucketGet("_plugin")
+	if !ok {
+		return nil, fmt.Errorf("plugin cannot be loaded")
+	}
+	pl, ok := rawPlugin.(*plug.Plugin)
+	if !ok {
+		return nil, fmt.Errorf("plugin cannot be loaded")
+	}
+	return pl, nil
+}
+
+// bindMethods adds one typed function per manifest method to inst.
+func bindMethods(inst *language.StructInstance, pl *plug.Plugin, manifest *plug.Manifest, dg *debug.Debug) error {
+	ctx := context.Background()
+	proto := inst.GetPrototype().(*language.StructPrototype)
+
+	proto.Unlock()
+	defer proto.Lock()
+
+	for _, method := range manifest.Methods {
+		if _, exists := proto.GetObject(ctx, method.Name); exists {
+			zap.L().Warn("@std/plug.require: method shadows a builtin, skipping", zap.String("plugin", pl.DisplayName), zap.String("method", method.Name))
+			continue
+		}
+
+		fn, err := typedMethod(pl, method, dg)
+		if err != nil {
+			return fmt.Errorf("plug[%s]: method %s: %w", pl.DisplayName, method.Name, err)
+		}
+
+		if err := proto.SetObject(ctx, method.Name, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// typedMethod builds a function whose arguments and return value are checked
+// against the manifest before and after the plugin is called.
+func typedMethod(pl *plug.Plugin, method plug.Method, dg *debug.Debug) (*language.Function, error) {
+	args := make([]language.FnArg, len(method.Args))
+	for i, arg := range method.Args {
+		typ, err := parseType(arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", arg.Name, err)
+		}
+
+		var def language.Object
+		if arg.Optional {
+			typ = n.Nullable(typ)
+			def = language.Nil
+		}
+
+		args[i] = &language.BasicFnArg{TypeVal: typ, NameVal: arg.Name, DefaultVal: def}
+	}
+
+	returns, err := parseType(method.Returns)
+	if err != nil {
+		return nil, fmt.Errorf("return type: %w", err)
+	}
+
+	if method.Stream {
+		return language.NewTypedFunction(args, getStreamStruct(dg).Type(), func(ctx context.Context, o []language.Object) (language.Object, error) {
+			return openStream(ctx, pl, method.Name, func(releases *[]func()) (any, error) {
+				return namedParams(ctx, args, o, pl, releases, dg)
+			}, returns, dg)
+		}, dg), nil
+	}
+
+	return language.NewTypedFunction(args, returns, func(ctx context.Context, o []language.Object) (language.Object, error) {
+		ctx, cancel := context.WithTimeout(ctx, pl.Timeout())
+		defer cancel()
+
+		var releases []func()
+		defer releaseAll(&releases)
+
+		params, err := namedParams(ctx, args, o, pl, &releases, dg)
+		if err != nil {
+			return nil, err
+		}
+
+		if returns.BaseType == language.ObjectTypeVoid {
+			_, err := pl.Call(ctx, method.Name, params)
+			return nil, err
+		}
+
+		var data any
+		if err := pl.CallInto(ctx, method.Name, params, &data); err != nil {
+			return nil, err
+		}
+
+		return fromTyped(data, returns, dg)
+	}, dg), nil
+}
+
+// namedParams builds the request of a typed call, keyed by argument name.
+func namedParams(ctx context.Context, args []language.FnArg, o []language.Object, pl *plug.Plugin, releases *[]func(), dg *debug.Debug) (map[string]any, error) {
+	params := make(map[string]any, len(o))
+	for i, arg := range o {
+		val, err := toPlugValue(ctx, arg, pl, releases, dg)
+		if err != nil {
+			return nil, err
+		}
+		params[args[i].Name()] = val
+	}
+	return params, nil
+}
+
+// manifestToList exposes a manifest to Nubo code as a list of dicts.
+func manifestToList(manifest *plug.Manifest, dg *debug.Debug) (language.Object, error) {
+	methods := make([]any, len(manifest.Methods))
+	for i, method := range manifest.Methods {
+		args := make([]any, len(method.Args))
+		for j, arg := range method.Args {
+			args[j] = map[string]any{
+				"name":     arg.Name,
+				"type":     arg.Type,
+				"optional": arg.Optional,
+			}
+		}
+
+		methods[i] = map[string]any{
+			"name":    method.Name,
+			"args":    args,
+			"returns": method.Returns,
+			"doc":     method.Doc,
+			"stream":  method.Stream,
+		}
+	}
+
+	list, err := language.FromValue(methods, false, dg)
+	if err != nil {
+		return nil, err
+	}
+	return language.NewList(list.(*language.List).Data, n.NewDictType(n.TString, n.TAny), dg), nil
+}
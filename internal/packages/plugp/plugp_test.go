@@ -0,0 +1,244 @@
+package plugp_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/nubolang/nubo/internal/plugtest"
+	"github.com/nubolang/nubo/plug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	plugtest.Main(m, func() { testPlugin().Start() })
+}
+
+type addArgs struct {
+	A int `codec:"a"`
+	B int `codec:"b"`
+}
+
+type applyArgs struct {
+	Fn plug.Callback `codec:"fn"`
+	X  int           `codec:"x"`
+}
+
+type countArgs struct {
+	N int `codec:"n"`
+}
+
+type notifyArgs struct {
+	Name string `codec:"name"`
+}
+
+func testPlugin() *plug.App {
+	app := plug.Create()
+
+	app.Method(plug.Method{
+		Name:    "add",
+		Args:    []plug.Arg{{Name: "a", Type: "int"}, {Name: "b", Type: "int"}},
+		Returns: "int",
+	}, func(ctx *plug.Ctx) error {
+		var args addArgs
+		if err := ctx.Bind(&args); err != nil {
+			return err
+		}
+		return ctx.Send(args.A + args.B)
+	})
+	app.Method(plug.Method{
+		Name:    "apply",
+		Args:    []plug.Arg{{Name: "fn", Type: "fn(int) -> int"}, {Name: "x", Type: "int"}},
+		Returns: "int",
+	}, func(ctx *plug.Ctx) error {
+		var args applyArgs
+		if err := ctx.Bind(&args); err != nil {
+			return err
+		}
+		var out int
+		if err := ctx.InvokeInto(&out, args.Fn, args.X); err != nil {
+			return err
+		}
+		return ctx.Send(out)
+	})
+	app.Method(plug.Method{
+		Name: "notify",
+		Args: []plug.Arg{{Name: "name", Type: "string"}},
+	}, func(ctx *plug.Ctx) error {
+		var args notifyArgs
+		if err := ctx.Bind(&args); err != nil {
+			return err
+		}
+		if err := ctx.Publish("ready", args.Name); err != nil {
+			return err
+		}
+		return ctx.Send(nil)
+	})
+	var cancelled atomic.Bool
+	app.Method(plug.Method{
+		Name:    "count",
+		Args:    []plug.Arg{{Name: "n", Type: "int"}},
+		Returns: "int",
+		Stream:  true,
+	}, func(ctx *plug.Ctx) error {
+		var args countArgs
+		if err := ctx.Bind(&args); err != nil {
+			return err
+		}
+		for i := range args.N {
+			if err := ctx.Emit(i); err != nil {
+				cancelled.Store(ctx.Err() != nil)
+				return err
+			}
+		}
+		return nil
+	})
+	app.Handler("cancelled", func(ctx *plug.Ctx) error {
+		return ctx.Send(cancelled.Load())
+	})
+	return app
+}
+
+// pluginDir writes a _plug.yaml that starts the test binary as a plugin and
+// returns its directory.
+func pluginDir(t *testing.T) string {
+	t.Helper()
+
+	dir := plugtest.Dir(t, "  health:\n    interval: -1s\n")
+	t.Cleanup(func() {
+		if p, ok := plug.GetManager().Get(dir); ok {
+			_ = p.Stop()
+		}
+	})
+	return dir
+}
+
+func TestTypedMethods(t *testing.T) {
+	dir := pluginDir(t)
+
+	results := nubotest.Strings(t, `
+		import plug from "@std/plug"
+
+		const p = plug.require("stdio", dir)
+		const methods = p.describe()
+		const add = methods[0]
+
+		return [p.add(2, 3), add["name"], add["args"][1]["type"], add["returns"]]
+	`, map[string]any{"dir": dir})
+	assert.Equal(t, []string{"5", "add", "int", "int"}, results)
+
+	_, err := nubotest.Exec(`
+		import plug from "@std/plug"
+
+		plug.require("stdio", dir).add(2, "3")
+	`, map[string]any{"dir": dir})
+	assert.ErrorContains(t, err, "expected type int, got string")
+}
+
+func TestCallback(t *testing.T) {
+	dir := pluginDir(t)
+
+	results := nubotest.Strings(t, `
+		import plug from "@std/plug"
+
+		const p = plug.require("stdio", dir)
+		let calls = 0
+		const result = p.apply(fn(x: int) int {
+			calls = calls + 1
+			return x * 10
+		}, 4)
+		return [result, calls]
+	`, map[string]any{"dir": dir})
+	assert.Equal(t, []string{"40", "1"}, results)
+}
+
+func TestForwardEvents(t *testing.T) {
+	dir := pluginDir(t)
+	script := `
+		import plug from "@std/plug"
+		import thread from "@std/thread"
+		import { Portal } from "@std/thread"
+
+		event ready(name: string)
+		event readyish(name: string)
+
+		const got = Portal(2)
+		sub ready(name) {
+			got.send(name)
+		}
+		sub readyish(name) {
+			got.send("readyish " + name)
+		}
+
+		const p = plug.require("stdio", dir)
+		const again = plug.require("stdio", dir)
+		p.notify("x")
+		const first = got.receive()
+		const more = thread.select([thread.recv(got), thread.after(100)])
+		return [first, more.index]
+	`
+
+	// Only events declared with the published name receive it, and once
+	// although the plugin was required twice.
+	for range 2 {
+		results := nubotest.Strings(t, script, map[string]any{"dir": dir})
+		assert.Equal(t, []string{"x", "1"}, results)
+	}
+
+	// Every run forwarded events to its own provider until it finished.
+	p, ok := plug.GetManager().Get(dir)
+	require.True(t, ok)
+	_, err := p.Call(context.Background(), "notify", map[string]any{"name": "late"})
+	assert.ErrorContains(t, err, "no host subscribed to events of this plugin")
+}
+
+func TestStream(t *testing.T) {
+	dir := pluginDir(t)
+
+	results := nubotest.Strings(t, `
+		import plug from "@std/plug"
+
+		const p = plug.require("stdio", dir)
+		let sum = 0
+		for i, item in p.count(4) {
+			sum = sum + i * 10 + item
+		}
+
+		const raw = p.stream("count", {"n": 2})
+		const first = raw.next()
+		const second = raw.next()
+		return [sum, first, second, isNil(raw.next())]
+	`, map[string]any{"dir": dir})
+	assert.Equal(t, []string{"66", "0", "1", "true"}, results)
+}
+
+func TestStreamBreak(t *testing.T) {
+	dir := pluginDir(t)
+
+	results := nubotest.Strings(t, `
+		import plug from "@std/plug"
+
+		const p = plug.require("stdio", dir)
+		let seen = 0
+		for item in p.count(1000) {
+			if item == 2 {
+				break
+			}
+			seen = seen + 1
+		}
+		return [seen]
+	`, map[string]any{"dir": dir})
+	assert.Equal(t, []string{"2"}, results)
+
+	// Leaving the loop early cancels the handler on the plugin.
+	p, ok := plug.GetManager().Get(dir)
+	require.True(t, ok)
+	assert.Eventually(t, func() bool {
+		var cancelled bool
+		require.NoError(t, p.CallInto(context.Background(), "cancelled", nil, &cancelled))
+		return cancelled
+	}, 5*time.Second, 10*time.Millisecond)
+}
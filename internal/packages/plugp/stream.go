@@ -0,0 +1,175 @@
+package plugp
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/packages/iter"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+	"github.com/nubolang/nubo/plug"
+)
+
+var streamStruct *language.Struct
+
+// streamState is the Go side of a Stream instance.
+type streamState struct {
+	ctx      context.Context
+	stream   *plug.Stream
+	item     *language.Type
+	releases []func()
+	index    int
+	closed   bool
+}
+
+// next returns the next item converted to the item type, or nil at the end.
+func (s *streamState) next(dg *debug.Debug) (language.Object, error) {
+	if s.closed {
+		return nil, nil
+	}
+
+	raw, err := s.stream.Next(s.ctx)
+	if err != nil {
+		_ = s.close()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var data any
+	if err := plug.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return fromTyped(data, s.item, dg)
+}
+
+// close cancels the stream on the plugin and releases its callbacks.
+func (s *streamState) close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	releaseAll(&s.releases)
+	return s.stream.Close()
+}
+
+func streamOf(self language.Object) (*streamState, error) {
+	inst, ok := self.(*language.StructInstance)
+	if !ok {
+		return nil, errors.New("stream cannot be loaded")
+	}
+	raw, ok := inst.BucketGet("_stream")
+	if !ok {
+		return nil, errors.New("stream cannot be loaded")
+	}
+	return raw.(*streamState), nil
+}
+
+func getStreamStruct(dg *debug.Debug) *language.Struct {
+	if streamStruct != nil {
+		return streamStruct
+	}
+
+	ctx := context.Background()
+	streamStruct = language.NewStruct("Stream", nil, dg)
+	sp := streamStruct.GetPrototype().(*language.StructPrototype)
+
+	it := iter.NewIter(dg)
+	iterProto := it.GetPrototype()
+	iterator, _ := iterProto.GetObject(ctx, "Iterator")
+	end, _ := iterProto.GetObject(ctx, "End")
+	progress, _ := iterProto.GetObject(ctx, "Progress")
+
+	closeFn := n.Function(n.Describe(n.Arg("self", streamStruct.Type())), func(a *n.Args) (any, error) {
+		st, err := streamOf(a.Name("self"))
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.close()
+	})
+
+	sp.Unlock()
+	sp.SetObject(ctx, "next", n.Function(n.Describe(n.Arg("self", streamStruct.Type())).Returns(n.TAny), func(a *n.Args) (any, error) {
+		self := a.Name("self")
+		st, err := streamOf(self)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := st.next(self.Debug())
+		if err != nil || item == nil {
+			return language.Nil, err
+		}
+		return item, nil
+	}))
+	sp.SetObject(ctx, "close", closeFn)
+	sp.SetObject(ctx, "__close__", closeFn)
+
+	sp.SetObject(ctx, "__iterate__", n.Function(n.Describe(n.Arg("self", streamStruct.Type())).Returns(iterator.Type()), func(a *n.Args) (any, error) {
+		self := a.Name("self")
+		st, err := streamOf(self)
+		if err != nil {
+			return nil, err
+		}
+
+		iterInst, err := iterator.(*language.Struct).NewInstance()
+		if err != nil {
+			return nil, err
+		}
+		iterInit, _ := iterInst.GetPrototype().GetObject(ctx, "init")
+		ctx := language.StructAllowPrivateCtx(ctx)
+
+		return iterInit.(*language.Function).Data(ctx, []language.Object{
+			n.Function(n.Describe().Returns(progress.Type()), func(_ *n.Args) (any, error) {
+				item, err := st.next(self.Debug())
+				if err != nil {
+					return nil, err
+				}
+				if item == nil {
+					return end, nil
+				}
+
+				inst, _ := progress.(*language.Struct).NewInstance()
+				progInit, _ := inst.GetPrototype().GetObject(ctx, "init")
+
+				key := n.Int(st.index)
+				st.index++
+				return progInit.(*language.Function).Data(ctx, []language.Object{key, item})
+			}),
+		})
+	}))
+	sp.Lock()
+	sp.Implement()
+
+	return streamStruct
+}
+
+// openStream calls action as a stream and wraps it in a Stream instance.
+// params builds the request; callbacks it registers stay valid until the
+// stream ends or is closed.
+func openStream(ctx context.Context, pl *plug.Plugin, action string, params func(releases *[]func()) (any, error), item *language.Type, dg *debug.Debug) (language.Object, error) {
+	state := &streamState{ctx: ctx, item: item}
+
+	val, err := params(&state.releases)
+	if err != nil {
+		releaseAll(&state.releases)
+		return nil, err
+	}
+
+	state.stream, err = pl.Stream(ctx, action, val, 0)
+	if err != nil {
+		releaseAll(&state.releases)
+		return nil, err
+	}
+
+	inst, err := getStreamStruct(dg).NewInstance()
+	if err != nil {
+		_ = state.close()
+		return nil, err
+	}
+	inst.BucketSet("_stream", state)
+	return inst, nil
+}
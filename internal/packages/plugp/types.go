@@ -0,0 +1,265 @@
+package plugp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// parseType converts a manifest type string such as "[]string", "int?",
+// "dict[string, int|float]" or "fn(int) -> bool" into a language type.
+func parseType(s string) (*language.Type, error) {
+	p := &typeParser{src: strings.TrimSpace(s)}
+	if p.src == "" {
+		return n.TVoid, nil
+	}
+
+	t, err := p.union()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, fmt.Errorf("invalid type %q: unexpected %q", s, p.src[p.pos:])
+	}
+	return t, nil
+}
+
+type typeParser struct {
+	src string
+	pos int
+}
+
+func (p *typeParser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *typeParser) consume(prefix string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) union() (*language.Type, error) {
+	types := make([]*language.Type, 0, 1)
+	for {
+		t, err := p.single()
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+		if !p.consume("|") {
+			break
+		}
+	}
+	return language.NewUnionType(types...), nil
+}
+
+func (p *typeParser) single() (*language.Type, error) {
+	var (
+		t   *language.Type
+		err error
+	)
+
+	switch {
+	case p.consume("("):
+		t, err = p.union()
+		if err == nil && !p.consume(")") {
+			err = fmt.Errorf("invalid type %q: missing ')'", p.src)
+		}
+	case p.consume("[]"):
+		var elem *language.Type
+		elem, err = p.single()
+		if err == nil {
+			t = n.TTList(elem)
+		}
+	case p.consume("fn("):
+		var fnArgs []*language.Type
+		for !p.consume(")") {
+			if len(fnArgs) > 0 && !p.consume(",") {
+				return nil, fmt.Errorf("invalid type %q: expected ',' or ')' in fn arguments", p.src)
+			}
+			var arg *language.Type
+			if arg, err = p.union(); err != nil {
+				return nil, err
+			}
+			fnArgs = append(fnArgs, arg)
+		}
+		returns := n.TVoid
+		if p.consume("->") {
+			if returns, err = p.single(); err != nil {
+				return nil, err
+			}
+		}
+		t = language.NewFunctionType(returns, fnArgs...)
+	case p.consume("dict["):
+		var key, value *language.Type
+		if key, err = p.union(); err != nil {
+			return nil, err
+		}
+		if !p.consume(",") {
+			return nil, fmt.Errorf("invalid type %q: dict needs a key and a value type", p.src)
+		}
+		if value, err = p.union(); err != nil {
+			return nil, err
+		}
+		if !p.consume("]") {
+			return nil, fmt.Errorf("invalid type %q: missing ']'", p.src)
+		}
+		t = n.NewDictType(key, value)
+	default:
+		t, err = p.primitive()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.consume("?") {
+		t = n.Nullable(t)
+	}
+	return t, nil
+}
+
+func (p *typeParser) primitive() (*language.Type, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			p.pos++
+			continue
+		}
+		break
+	}
+
+	switch name := p.src[start:p.pos]; name {
+	case "int":
+		return n.TInt, nil
+	case "float":
+		return n.TFloat, nil
+	case "number":
+		return language.TypeNumber, nil
+	case "bool":
+		return n.TBool, nil
+	case "string":
+		return n.TString, nil
+	case "char":
+		return n.TChar, nil
+	case "byte":
+		return n.TByte, nil
+	case "list":
+		return n.TList, nil
+	case "dict":
+		return n.TDict, nil
+	case "nil":
+		return n.TNil, nil
+	case "any":
+		return n.TAny, nil
+	case "void":
+		return n.TVoid, nil
+	case "":
+		return nil, fmt.Errorf("invalid type %q: expected a type at offset %d", p.src, start)
+	default:
+		return nil, fmt.Errorf("invalid type %q: unknown type %q", p.src, name)
+	}
+}
+
+// fromTyped converts a msgpack-decoded value into an object of type t.
+// Msgpack strings arrive as raw bytes and whole floats may arrive as
+// integers, so a plain language.FromValue would not satisfy t.
+func fromTyped(v any, t *language.Type, dg *debug.Debug) (language.Object, error) {
+	if t == nil || t.BaseType == language.ObjectTypeAny {
+		return language.FromValue(fromUntyped(v), false, dg)
+	}
+
+	switch value := v.(type) {
+	case []byte:
+		if t.Compare(n.TString) {
+			return n.String(string(value), dg), nil
+		}
+	case uint64:
+		if !t.Compare(n.TInt) && t.Compare(n.TFloat) {
+			return n.Float(float64(value), dg), nil
+		}
+		return n.Int64(int64(value), dg), nil
+	case int64:
+		if !t.Compare(n.TInt) && t.Compare(n.TFloat) {
+			return n.Float(float64(value), dg), nil
+		}
+	case []any:
+		lt := matchBase(t, language.ObjectTypeList)
+		if lt == nil {
+			break
+		}
+		items := make([]language.Object, len(value))
+		for i, item := range value {
+			obj, err := fromTyped(item, lt.Element, dg)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = obj
+		}
+		return language.NewList(items, lt.Element, dg), nil
+	case map[any]any:
+		dt := matchBase(t, language.ObjectTypeDict)
+		if dt == nil {
+			break
+		}
+		keys := make([]language.Object, 0, len(value))
+		values := make([]language.Object, 0, len(value))
+		for k, item := range value {
+			key, err := fromTyped(k, dt.Key, dg)
+			if err != nil {
+				return nil, err
+			}
+			val, err := fromTyped(item, dt.Value, dg)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+			values = append(values, val)
+		}
+		return language.NewDict(keys, values, dt.Key, dt.Value, dg)
+	}
+
+	return language.FromValue(fromUntyped(v), false, dg)
+}
+
+// fromUntyped applies the conversions of fromTyped where no type is known:
+// raw bytes become strings and dict keys become strings.
+func fromUntyped(v any) any {
+	switch value := v.(type) {
+	case []byte:
+		return string(value)
+	case []any:
+		out := make([]any, len(value))
+		for i, item := range value {
+			out[i] = fromUntyped(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(value))
+		for k, item := range value {
+			out[fmt.Sprint(fromUntyped(k))] = fromUntyped(item)
+		}
+		return out
+	}
+	return v
+}
+
+// matchBase returns the member of the (possibly union) type t with base b.
+func matchBase(t *language.Type, b language.ObjectType) *language.Type {
+	for current := t; current != nil; current = current.Next {
+		if current.BaseType == b {
+			return current
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 
 	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native"
 	"github.com/nubolang/nubo/native/n"
@@ -27,6 +28,10 @@ func NewProcess(dg *debug.Debug) language.Object {
 			argsObj, _ := ctx.Get("args")
 
 			cmdStr := cmdObj.Value().(string)
+			if err := sandbox.FromContext(ctx.Context()).CheckProcess(cmdStr); err != nil {
+				return nil, err
+			}
+
 			args := []string{}
 			for _, arg := range argsObj.Value().([]language.Object) {
 				args = append(args, arg.String())
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
@@ -29,6 +30,10 @@ func NewMySQL() language.Object {
 		charset := args.Name("charset").String()
 		parseTime := args.Name("parseTime").Value().(bool)
 
+		if err := sandbox.FromContext(args.Context()).CheckNetwork(host); err != nil {
+			return nil, err
+		}
+
 		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=%t",
 			user, password, host, port, dbname, charset, parseTime)
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	_ "github.com/lib/pq"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
@@ -29,6 +30,10 @@ func NewPostgres() language.Object {
 		sslmode := args.Name("sslmode").String()
 		timezone := args.Name("timezone").String()
 
+		if err := sandbox.FromContext(args.Context()).CheckNetwork(host); err != nil {
+			return nil, err
+		}
+
 		dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=%s TimeZone=%s",
 			user, password, host, port, dbname, sslmode, timezone)
 
@@ -1,10 +1,12 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
-	"path/filepath"
 	"strings"
 
+	nuboos "github.com/nubolang/nubo/internal/packages/os"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 	_ "modernc.org/sqlite"
@@ -15,7 +17,12 @@ func NewSQLite() language.Object {
 
 	return n.Function(n.Describe(n.Arg("dsn", n.TString)).Returns(n.TStruct), func(args *n.Args) (any, error) {
 		dsnObj := args.Name("dsn")
-		dsn := sqliteDSN(dsnObj.String(), filepath.Dir(dsnObj.Debug().File))
+		dsn := sqliteDSN(args.Context(), dsnObj.String())
+		if file, _, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?"); !strings.HasPrefix(file, ":memory:") {
+			if err := sandbox.FromContext(args.Context()).CheckPath(file); err != nil {
+				return nil, err
+			}
+		}
 
 		if !strings.Contains(dsn, "cache=") {
 			if strings.Contains(dsn, "?") {
@@ -42,7 +49,8 @@ func NewSQLite() language.Object {
 	})
 }
 
-func sqliteDSN(dsn string, currentDir string) string {
+// sqliteDSN resolves the file name of dsn like every other std path.
+func sqliteDSN(ctx context.Context, dsn string) string {
 	if strings.HasPrefix(dsn, ":memory:") || strings.HasPrefix(dsn, "file::memory:") {
 		return dsn
 	}
@@ -55,9 +63,5 @@ func sqliteDSN(dsn string, currentDir string) string {
 		opts = "?" + parts[1]
 	}
 
-	if !filepath.IsAbs(filename) {
-		filename = filepath.Join(currentDir, filename)
-	}
-
-	return "file:" + filename + opts
+	return "file:" + nuboos.ResolvePath(ctx, filename) + opts
 }
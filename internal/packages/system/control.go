@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native/n"
 )
@@ -43,6 +44,9 @@ var kill = n.Function(
     n.Describe(n.Arg("pid", n.TInt)),
     func(a *n.Args) (any, error) {
         pid := int(a.Name("pid").Value().(int64))
+        if err := sandbox.FromContext(a.Context()).CheckProcess("kill"); err != nil {
+            return nil, err
+        }
         p, err := os.FindProcess(pid)
         if err != nil {
             return nil, err
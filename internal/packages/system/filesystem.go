@@ -3,6 +3,7 @@ package system
 import (
 	"os"
 
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/native/n"
 )
 
@@ -11,6 +12,9 @@ var cwd = n.Function(n.Describe().Returns(n.TString), func(a *n.Args) (any, erro
 })
 
 var chdir = n.Function(n.Describe(n.Arg("dir", n.TString)), func(a *n.Args) (any, error) {
+	if err := sandbox.FromContext(a.Context()).CheckPath(a.Name("dir").String()); err != nil {
+		return nil, err
+	}
 	return nil, os.Chdir(a.Name("dir").String())
 })
 
@@ -1,7 +1,9 @@
 package thread
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/nubolang/nubo/language"
@@ -11,6 +13,7 @@ type Portal struct {
 	portal   chan language.Object
 	capacity int
 	closed   bool
+	mu       sync.Mutex
 }
 
 func NewPortal(capacity int) *Portal {
@@ -21,24 +24,61 @@ func NewPortal(capacity int) *Portal {
 	}
 }
 
-func (p *Portal) Send(obj language.Object) {
-	p.portal <- obj
+// Send blocks until obj is accepted by the portal or ctx is cancelled.
+func (p *Portal) Send(ctx context.Context, obj language.Object) (err error) {
+	// The portal may get closed while we are waiting.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("[thread/Portal] portal is closed")
+		}
+	}()
+
+	select {
+	case p.portal <- obj:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-func (p *Portal) Receive() language.Object {
-	return <-p.portal
+// Receive blocks until a message arrives or ctx is cancelled. ok is false
+// once the portal is closed and drained.
+func (p *Portal) Receive(ctx context.Context) (obj language.Object, ok bool, err error) {
+	select {
+	case obj, ok = <-p.portal:
+		return obj, ok, nil
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
 }
 
-func (p *Portal) ReceiveWithTimeout(ms int) (language.Object, error) {
+func (p *Portal) ReceiveWithTimeout(ctx context.Context, ms int) (language.Object, bool, error) {
+	timer := time.NewTimer(time.Duration(ms) * time.Millisecond)
+	defer timer.Stop()
+
 	select {
-	case obj := <-p.portal:
-		return obj, nil
-	case <-time.After(time.Duration(ms) * time.Millisecond):
-		return nil, fmt.Errorf("[thread/Portal] receive timeout")
+	case obj, ok := <-p.portal:
+		return obj, ok, nil
+	case <-timer.C:
+		return nil, false, fmt.Errorf("[thread/Portal] receive timeout")
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
 	}
 }
 
 func (p *Portal) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
 	close(p.portal)
 	p.closed = true
 }
+
+func (p *Portal) Closed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
@@ -96,9 +96,8 @@ func newPortalStruct(dg *debug.Debug) {
 
 			bucket := self.(language.Bucketable)
 			p, _ := bucket.BucketGet("portal")
-			p.(*Portal).Send(message)
 
-			return nil, nil
+			return nil, p.(*Portal).Send(a.Context(), message)
 		}))
 
 	proto.SetObject(ctx, "receive", n.Function(n.Describe(
@@ -107,20 +106,40 @@ func newPortalStruct(dg *debug.Debug) {
 	).Returns(n.TAny),
 		func(a *n.Args) (any, error) {
 			self := a.Name("self")
-			if err := isClosed(self); err != nil {
-				return nil, err
-			}
 
 			bucket := self.(language.Bucketable)
 			p, _ := bucket.BucketGet("portal")
 			po := p.(*Portal)
 
-			timeout := a.Name("timeout").Value()
-			if timeout == nil {
-				return po.Receive(), nil
+			var (
+				message language.Object
+				ok      bool
+				err     error
+			)
+			if timeout := a.Name("timeout").Value(); timeout == nil {
+				message, ok, err = po.Receive(a.Context())
+			} else {
+				message, ok, err = po.ReceiveWithTimeout(a.Context(), int(timeout.(int64)))
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			// Buffered messages are still delivered after close, then
+			// receiving fails instead of blocking forever.
+			if !ok {
+				return nil, fmt.Errorf("[thread/Portal] portal is closed")
 			}
+			return message, nil
+		}))
 
-			return po.ReceiveWithTimeout(int(timeout.(int64)))
+	proto.SetObject(ctx, "isClosed", n.Function(n.Describe(
+		n.Arg("self", portalStruct.Type()),
+	).Returns(n.TBool),
+		func(a *n.Args) (any, error) {
+			bucket := a.Name("self").(language.Bucketable)
+			p, _ := bucket.BucketGet("portal")
+			return p.(*Portal).Closed(), nil
 		}))
 
 	proto.Lock()
@@ -0,0 +1,236 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+type selectKind int
+
+const (
+	selectReceive selectKind = iota
+	selectSend
+	selectAfter
+	selectFallback
+)
+
+// selectCase is one operation thread.select waits on.
+type selectCase struct {
+	kind    selectKind
+	portal  *Portal
+	message language.Object
+	after   time.Duration
+}
+
+var (
+	caseStruct     *language.Struct
+	selectedStruct *language.Struct
+	selectOnce     sync.Once
+)
+
+func newSelectStructs(dg *debug.Debug) {
+	caseStruct = language.NewStruct("Case", []language.StructField{
+		{
+			Name: "kind",
+			Type: n.TString,
+		},
+	}, dg)
+
+	selectedStruct = language.NewStruct("Selected", []language.StructField{
+		{
+			Name: "index",
+			Type: n.TInt,
+		},
+		{
+			Name: "value",
+			Type: n.TAny,
+		},
+		{
+			Name: "ok",
+			Type: n.TBool,
+		},
+	}, dg)
+}
+
+// newCase wraps c into a Case struct instance.
+func newCase(kind string, c *selectCase, dg *debug.Debug) (language.Object, error) {
+	instance, err := caseStruct.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := instance.GetPrototype().SetObject(context.Background(), "kind", n.String(kind, dg)); err != nil {
+		return nil, err
+	}
+	instance.BucketSet("case", c)
+	return instance, nil
+}
+
+func portalOf(obj language.Object) (*Portal, error) {
+	p, ok := fromBucket[*Portal](obj, "portal")
+	if !ok {
+		return nil, fmt.Errorf("[thread/select] expected Portal, got %s", obj.Type())
+	}
+	return p, nil
+}
+
+// selectCases registers the case constructors and select itself on the
+// thread package prototype.
+func selectCases(proto language.Prototype, dg *debug.Debug) {
+	selectOnce.Do(func() { newSelectStructs(dg) })
+
+	ctx := context.Background()
+	proto.SetObject(ctx, "Case", caseStruct)
+	proto.SetObject(ctx, "Selected", selectedStruct)
+
+	proto.SetObject(ctx, "recv", n.Function(n.Describe(
+		n.Arg("portal", portalStruct.Type()),
+	).Returns(caseStruct.Type()),
+		func(a *n.Args) (any, error) {
+			p, err := portalOf(a.Name("portal"))
+			if err != nil {
+				return nil, err
+			}
+			return newCase("recv", &selectCase{kind: selectReceive, portal: p}, dg)
+		}))
+
+	proto.SetObject(ctx, "send", n.Function(n.Describe(
+		n.Arg("portal", portalStruct.Type()),
+		n.Arg("message", n.TAny),
+	).Returns(caseStruct.Type()),
+		func(a *n.Args) (any, error) {
+			p, err := portalOf(a.Name("portal"))
+			if err != nil {
+				return nil, err
+			}
+			return newCase("send", &selectCase{kind: selectSend, portal: p, message: a.Name("message")}, dg)
+		}))
+
+	proto.SetObject(ctx, "after", n.Function(n.Describe(
+		n.Arg("ms", n.TInt),
+	).Returns(caseStruct.Type()),
+		func(a *n.Args) (any, error) {
+			ms := a.Name("ms").Value().(int64)
+			if ms < 0 {
+				return nil, fmt.Errorf("[thread/select] duration must be non-negative")
+			}
+			return newCase("after", &selectCase{kind: selectAfter, after: time.Duration(ms) * time.Millisecond}, dg)
+		}))
+
+	proto.SetObject(ctx, "fallback", n.Function(n.Describe().Returns(caseStruct.Type()),
+		func(a *n.Args) (any, error) {
+			return newCase("fallback", &selectCase{kind: selectFallback}, dg)
+		}))
+
+	proto.SetObject(ctx, "select", n.Function(n.Describe(
+		n.Arg("cases", n.TTList(caseStruct.Type())),
+	).Returns(selectedStruct.Type()),
+		func(a *n.Args) (any, error) {
+			cases, err := casesOf(a.Name("cases"))
+			if err != nil {
+				return nil, err
+			}
+
+			index, value, ok, err := runSelect(a.Context(), cases)
+			if err != nil {
+				return nil, err
+			}
+
+			return newSelected(index, value, ok, dg)
+		}))
+}
+
+func casesOf(obj language.Object) ([]*selectCase, error) {
+	list := obj.(*language.List)
+	if len(list.Data) == 0 {
+		return nil, fmt.Errorf("[thread/select] expected at least one case")
+	}
+
+	cases := make([]*selectCase, len(list.Data))
+	fallback := false
+	for i, item := range list.Data {
+		c, ok := fromBucket[*selectCase](item, "case")
+		if !ok {
+			return nil, fmt.Errorf("[thread/select] case %d: expected Case, got %s", i, item.Type())
+		}
+
+		cases[i] = c
+		if cases[i].kind == selectFallback {
+			if fallback {
+				return nil, fmt.Errorf("[thread/select] only one fallback case is allowed")
+			}
+			fallback = true
+		}
+	}
+	return cases, nil
+}
+
+// runSelect waits until one of cases can proceed and returns its index.
+// For receive cases ok is false when the portal is closed and drained.
+func runSelect(ctx context.Context, cases []*selectCase) (index int, value language.Object, ok bool, err error) {
+	reflected := make([]reflect.SelectCase, 0, len(cases)+1)
+	for i, c := range cases {
+		switch c.kind {
+		case selectReceive:
+			reflected = append(reflected, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.portal.portal)})
+		case selectSend:
+			if c.portal.Closed() {
+				return 0, nil, false, fmt.Errorf("[thread/select] case %d: portal is closed", i)
+			}
+			reflected = append(reflected, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(c.portal.portal), Send: reflect.ValueOf(c.message)})
+		case selectAfter:
+			timer := time.NewTimer(c.after)
+			defer timer.Stop()
+			reflected = append(reflected, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+		case selectFallback:
+			reflected = append(reflected, reflect.SelectCase{Dir: reflect.SelectDefault})
+		}
+	}
+	reflected = append(reflected, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+
+	// A portal may get closed while we are waiting to send on it.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("[thread/select] portal is closed")
+		}
+	}()
+
+	chosen, received, recvOK := reflect.Select(reflected)
+	if chosen == len(cases) {
+		return 0, nil, false, ctx.Err()
+	}
+
+	if cases[chosen].kind == selectReceive {
+		if !recvOK {
+			return chosen, language.Nil, false, nil
+		}
+		return chosen, received.Interface().(language.Object), true, nil
+	}
+	return chosen, language.Nil, true, nil
+}
+
+func newSelected(index int, value language.Object, ok bool, dg *debug.Debug) (language.Object, error) {
+	instance, err := selectedStruct.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	proto := instance.GetPrototype()
+	if err := proto.SetObject(context.Background(), "index", n.Int(index, dg)); err != nil {
+		return nil, err
+	}
+	if err := proto.SetObject(context.Background(), "value", value); err != nil {
+		return nil, err
+	}
+	if err := proto.SetObject(context.Background(), "ok", n.Bool(ok, dg)); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
@@ -0,0 +1,490 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// fromBucket returns the Go value stored under key in a struct instance.
+func fromBucket[T any](obj language.Object, key string) (T, bool) {
+	var zero T
+
+	bucket, ok := obj.(language.Bucketable)
+	if !ok {
+		return zero, false
+	}
+	value, ok := bucket.BucketGet(key)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := value.(T)
+	return typed, ok
+}
+
+// syncState returns the Go state of a sync struct instance, failing with
+// the struct name if obj was not created through its constructor.
+func syncState[T any](obj language.Object, name string) (T, error) {
+	state, ok := fromBucket[T](obj, "sync")
+	if !ok {
+		return state, fmt.Errorf("[thread/%s] expected %s, got %s", name, name, obj.Type())
+	}
+	return state, nil
+}
+
+// syncStruct defines a struct without public fields whose state lives in
+// the instance bucket. init creates that state from the constructor
+// arguments.
+func syncStruct(name string, dg *debug.Debug, init func(a *n.Args) (any, error), args ...*n.FnArg) *language.Struct {
+	s := language.NewStruct(name, nil, dg)
+
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	proto.SetObject(context.Background(), "init", n.Function(n.Describe(
+		append([]*n.FnArg{n.Arg("self", s.Type())}, args...)...,
+	).Returns(s.Type()),
+		func(a *n.Args) (any, error) {
+			state, err := init(a)
+			if err != nil {
+				return nil, err
+			}
+
+			self := a.Name("self")
+			self.(language.Bucketable).BucketSet("sync", state)
+			return self, nil
+		}))
+
+	return s
+}
+
+// method adds a method to a struct created by syncStruct.
+func method[T any](s *language.Struct, name, method string, returns *language.Type, fn func(a *n.Args, state T) (any, error), args ...*n.FnArg) {
+	describe := n.Describe(append([]*n.FnArg{n.Arg("self", s.Type())}, args...)...)
+	if returns != nil {
+		describe = describe.Returns(returns)
+	}
+
+	s.GetPrototype().SetObject(context.Background(), method, n.Function(describe,
+		func(a *n.Args) (any, error) {
+			state, err := syncState[T](a.Name("self"), name)
+			if err != nil {
+				return nil, err
+			}
+			return fn(a, state)
+		}))
+}
+
+func seal(s *language.Struct) *language.Struct {
+	proto := s.GetPrototype().(*language.StructPrototype)
+	proto.Lock()
+	proto.Implement()
+	return s
+}
+
+// Mutex is a mutex whose Lock gives up when the context of the caller is
+// cancelled. Unlocking an unlocked mutex is an error instead of a fatal Go
+// error.
+type Mutex struct {
+	slot chan struct{}
+}
+
+func newMutex() *Mutex {
+	return &Mutex{slot: make(chan struct{}, 1)}
+}
+
+func (m *Mutex) Lock(ctx context.Context) error {
+	select {
+	case m.slot <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Mutex) TryLock() bool {
+	select {
+	case m.slot <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *Mutex) Unlock() error {
+	select {
+	case <-m.slot:
+		return nil
+	default:
+		return fmt.Errorf("[thread/Mutex] unlock of unlocked mutex")
+	}
+}
+
+// RWMutex is a readers-writer mutex whose Lock and RLock give up when the
+// context of the caller is cancelled. Waiting writers keep new readers out,
+// so readers cannot starve them.
+type RWMutex struct {
+	mu      sync.Mutex
+	writer  bool
+	readers int64
+	waiting int64
+	// changed is closed and cleared when the mutex is released.
+	changed chan struct{}
+}
+
+// wait returns a channel closed once the mutex changes. m.mu must be held.
+func (m *RWMutex) wait() <-chan struct{} {
+	if m.changed == nil {
+		m.changed = make(chan struct{})
+	}
+	return m.changed
+}
+
+// broadcast wakes everybody waiting for the mutex. m.mu must be held.
+func (m *RWMutex) broadcast() {
+	if m.changed != nil {
+		close(m.changed)
+		m.changed = nil
+	}
+}
+
+// await releases m.mu until the mutex changes or ctx is cancelled and
+// holds it again on return.
+func (m *RWMutex) await(ctx context.Context) error {
+	changed := m.wait()
+	m.mu.Unlock()
+	defer m.mu.Lock()
+
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *RWMutex) Lock(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.waiting++
+	defer func() { m.waiting-- }()
+
+	for m.writer || m.readers > 0 {
+		if err := m.await(ctx); err != nil {
+			// Readers held back by this writer may go on.
+			m.broadcast()
+			return err
+		}
+	}
+	m.writer = true
+	return nil
+}
+
+func (m *RWMutex) TryLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writer || m.readers > 0 {
+		return false
+	}
+	m.writer = true
+	return true
+}
+
+func (m *RWMutex) Unlock() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.writer {
+		return fmt.Errorf("[thread/RWMutex] unlock of unlocked mutex")
+	}
+	m.writer = false
+	m.broadcast()
+	return nil
+}
+
+func (m *RWMutex) RLock(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for m.writer || m.waiting > 0 {
+		if err := m.await(ctx); err != nil {
+			return err
+		}
+	}
+	m.readers++
+	return nil
+}
+
+func (m *RWMutex) TryRLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writer || m.waiting > 0 {
+		return false
+	}
+	m.readers++
+	return true
+}
+
+func (m *RWMutex) RUnlock() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.readers <= 0 {
+		return fmt.Errorf("[thread/RWMutex] runlock of unlocked mutex")
+	}
+	m.readers--
+	if m.readers == 0 {
+		m.broadcast()
+	}
+	return nil
+}
+
+// WaitGroup counts outstanding work like a sync.WaitGroup, but refuses to
+// go negative and lets Wait give up without leaving anything behind.
+type WaitGroup struct {
+	mu    sync.Mutex
+	count int64
+	// zero is closed and cleared when the counter drops to zero.
+	zero chan struct{}
+}
+
+func (w *WaitGroup) Add(delta int64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.count+delta < 0 {
+		return fmt.Errorf("[thread/WaitGroup] negative counter")
+	}
+	w.count += delta
+	if w.count == 0 && w.zero != nil {
+		close(w.zero)
+		w.zero = nil
+	}
+	return nil
+}
+
+// Wait blocks until the counter is zero, the timeout (if positive) elapsed
+// or ctx was cancelled.
+func (w *WaitGroup) Wait(ctx context.Context, timeout time.Duration) error {
+	w.mu.Lock()
+	if w.count == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	if w.zero == nil {
+		w.zero = make(chan struct{})
+	}
+	zero := w.zero
+	w.mu.Unlock()
+
+	var after <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		after = timer.C
+	}
+
+	select {
+	case <-zero:
+		return nil
+	case <-after:
+		return fmt.Errorf("[thread/WaitGroup] wait timeout")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Semaphore limits the number of holders to its size.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Semaphore) Release() error {
+	select {
+	case <-s.slots:
+		return nil
+	default:
+		return fmt.Errorf("[thread/Semaphore] release without acquire")
+	}
+}
+
+var (
+	syncStructs     map[string]*language.Struct
+	syncStructsOnce sync.Once
+)
+
+func newSyncStructs(dg *debug.Debug) {
+	syncStructs = map[string]*language.Struct{
+		"Mutex":     newMutexStruct(dg),
+		"RWMutex":   newRWMutexStruct(dg),
+		"WaitGroup": newWaitGroupStruct(dg),
+		"Once":      newOnceStruct(dg),
+		"Semaphore": newSemaphoreStruct(dg),
+		"Counter":   newCounterStruct(dg),
+	}
+}
+
+func newMutexStruct(dg *debug.Debug) *language.Struct {
+	s := syncStruct("Mutex", dg, func(a *n.Args) (any, error) {
+		return newMutex(), nil
+	})
+
+	method(s, "Mutex", "lock", nil, func(a *n.Args, m *Mutex) (any, error) {
+		return nil, m.Lock(a.Context())
+	})
+	method(s, "Mutex", "tryLock", n.TBool, func(a *n.Args, m *Mutex) (any, error) {
+		return m.TryLock(), nil
+	})
+	method(s, "Mutex", "unlock", nil, func(a *n.Args, m *Mutex) (any, error) {
+		return nil, m.Unlock()
+	})
+
+	return seal(s)
+}
+
+func newRWMutexStruct(dg *debug.Debug) *language.Struct {
+	s := syncStruct("RWMutex", dg, func(a *n.Args) (any, error) {
+		return &RWMutex{}, nil
+	})
+
+	method(s, "RWMutex", "lock", nil, func(a *n.Args, m *RWMutex) (any, error) {
+		return nil, m.Lock(a.Context())
+	})
+	method(s, "RWMutex", "tryLock", n.TBool, func(a *n.Args, m *RWMutex) (any, error) {
+		return m.TryLock(), nil
+	})
+	method(s, "RWMutex", "unlock", nil, func(a *n.Args, m *RWMutex) (any, error) {
+		return nil, m.Unlock()
+	})
+	method(s, "RWMutex", "rlock", nil, func(a *n.Args, m *RWMutex) (any, error) {
+		return nil, m.RLock(a.Context())
+	})
+	method(s, "RWMutex", "tryRLock", n.TBool, func(a *n.Args, m *RWMutex) (any, error) {
+		return m.TryRLock(), nil
+	})
+	method(s, "RWMutex", "runlock", nil, func(a *n.Args, m *RWMutex) (any, error) {
+		return nil, m.RUnlock()
+	})
+
+	return seal(s)
+}
+
+func newWaitGroupStruct(dg *debug.Debug) *language.Struct {
+	s := syncStruct("WaitGroup", dg, func(a *n.Args) (any, error) {
+		return &WaitGroup{}, nil
+	})
+
+	method(s, "WaitGroup", "add", nil, func(a *n.Args, w *WaitGroup) (any, error) {
+		return nil, w.Add(a.Name("delta").Value().(int64))
+	}, n.Arg("delta", n.TInt, n.Int(1, dg)))
+	method(s, "WaitGroup", "done", nil, func(a *n.Args, w *WaitGroup) (any, error) {
+		return nil, w.Add(-1)
+	})
+	method(s, "WaitGroup", "wait", nil, func(a *n.Args, w *WaitGroup) (any, error) {
+		var timeout time.Duration
+		if ms := a.Name("timeout").Value(); ms != nil {
+			timeout = time.Duration(ms.(int64)) * time.Millisecond
+		}
+		return nil, w.Wait(a.Context(), timeout)
+	}, n.Arg("timeout", n.Nullable(n.TInt), language.Nil))
+
+	return seal(s)
+}
+
+func newOnceStruct(dg *debug.Debug) *language.Struct {
+	s := syncStruct("Once", dg, func(a *n.Args) (any, error) {
+		return &sync.Once{}, nil
+	})
+
+	method(s, "Once", "do", n.TBool, func(a *n.Args, once *sync.Once) (any, error) {
+		fn, ok := a.Name("fn").(*language.Function)
+		if !ok {
+			return nil, fmt.Errorf("[thread/Once] expected function, got %s", a.Name("fn").Type())
+		}
+
+		var (
+			ran bool
+			err error
+		)
+		once.Do(func() {
+			ran = true
+			_, err = fn.Call(a.Context(), nil)
+		})
+		return ran, err
+	}, n.Arg("fn", n.TAny))
+
+	return seal(s)
+}
+
+func newSemaphoreStruct(dg *debug.Debug) *language.Struct {
+	s := syncStruct("Semaphore", dg, func(a *n.Args) (any, error) {
+		size := a.Name("size").Value().(int64)
+		if size < 1 {
+			return nil, fmt.Errorf("[thread/Semaphore] size must be at least 1, got %d", size)
+		}
+		return &Semaphore{slots: make(chan struct{}, size)}, nil
+	}, n.Arg("size", n.TInt, n.Int(1, dg)))
+
+	method(s, "Semaphore", "acquire", nil, func(a *n.Args, sem *Semaphore) (any, error) {
+		return nil, sem.Acquire(a.Context())
+	})
+	method(s, "Semaphore", "tryAcquire", n.TBool, func(a *n.Args, sem *Semaphore) (any, error) {
+		return sem.TryAcquire(), nil
+	})
+	method(s, "Semaphore", "release", nil, func(a *n.Args, sem *Semaphore) (any, error) {
+		return nil, sem.Release()
+	})
+
+	return seal(s)
+}
+
+func newCounterStruct(dg *debug.Debug) *language.Struct {
+	s := syncStruct("Counter", dg, func(a *n.Args) (any, error) {
+		counter := &atomic.Int64{}
+		counter.Store(a.Name("value").Value().(int64))
+		return counter, nil
+	}, n.Arg("value", n.TInt, n.Int(0, dg)))
+
+	method(s, "Counter", "add", n.TInt, func(a *n.Args, c *atomic.Int64) (any, error) {
+		return c.Add(a.Name("delta").Value().(int64)), nil
+	}, n.Arg("delta", n.TInt, n.Int(1, dg)))
+	method(s, "Counter", "get", n.TInt, func(a *n.Args, c *atomic.Int64) (any, error) {
+		return c.Load(), nil
+	})
+	method(s, "Counter", "set", nil, func(a *n.Args, c *atomic.Int64) (any, error) {
+		c.Store(a.Name("value").Value().(int64))
+		return nil, nil
+	}, n.Arg("value", n.TInt))
+	method(s, "Counter", "compareAndSwap", n.TBool, func(a *n.Args, c *atomic.Int64) (any, error) {
+		return c.CompareAndSwap(a.Name("old").Value().(int64), a.Name("new").Value().(int64)), nil
+	}, n.Arg("old", n.TInt), n.Arg("new", n.TInt))
+
+	return seal(s)
+}
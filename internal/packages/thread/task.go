@@ -0,0 +1,221 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+	"go.uber.org/zap"
+)
+
+// Task is the handle of a spawned block or function. It keeps the outcome
+// of the run until somebody waits for it.
+type Task struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	result language.Object
+	err    error
+
+	// observed is set once somebody waits for the task. A failure nobody
+	// waits for when it happens is printed to stderr.
+	observed atomic.Bool
+}
+
+// observe marks the outcome of the task as handled by its caller.
+func (t *Task) observe() {
+	t.observed.Store(true)
+}
+
+// finish stores the outcome of the task.
+func (t *Task) finish(result language.Object, err error) {
+	t.mu.Lock()
+	t.result, t.err = result, err
+	t.mu.Unlock()
+}
+
+// outcome returns what finish stored.
+func (t *Task) outcome() (language.Object, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.result, t.err
+}
+
+// Wait blocks until the task finished, the timeout (if positive) elapsed or
+// ctx was cancelled, and returns the outcome of the task.
+func (t *Task) Wait(ctx context.Context, timeout time.Duration) (language.Object, error) {
+	t.observe()
+
+	var after <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		after = timer.C
+	}
+
+	select {
+	case <-t.done:
+	case <-after:
+		return nil, fmt.Errorf("[thread/Task] wait timeout")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	result, err := t.outcome()
+	if err != nil {
+		return nil, err
+	}
+	// Blocks without a value and bare returns give nil.
+	if result == nil || result.Type().Base() == language.ObjectTypeSignal {
+		return language.Nil, nil
+	}
+	return result, nil
+}
+
+// Done reports whether the task finished.
+func (t *Task) Done() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cancel stops the task at its next statement.
+func (t *Task) Cancel() {
+	t.cancel()
+}
+
+var (
+	taskStruct     *language.Struct
+	taskStructOnce sync.Once
+)
+
+// Spawn runs fn in a new goroutine with a cancellable child of ctx and
+// returns a Task struct instance to control it. Errors and panics of fn are
+// kept by the task and returned by wait(). A failure nobody is waiting for
+// yet is also printed to stderr, as it would go unnoticed otherwise.
+func Spawn(ctx context.Context, dg *debug.Debug, fn func(ctx context.Context) (language.Object, error)) (language.Object, error) {
+	taskStructOnce.Do(func() { newTaskStruct(dg) })
+
+	instance, err := taskStruct.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	task := &Task{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	instance.BucketSet("task", task)
+
+	go func() {
+		defer cancel()
+		defer close(task.done)
+
+		result, err := runTask(ctx, fn)
+		task.finish(result, err)
+		instance.GetPrototype().SetObject(language.StructAllowPrivateCtx(context.Background()), "done", n.Bool(true, dg))
+
+		if err != nil {
+			zap.L().Error("@std/thread.Task: task failed", zap.Error(err))
+			// Cancelled tasks were stopped on purpose.
+			if !task.observed.Load() && ctx.Err() == nil {
+				fmt.Fprintln(os.Stderr, exception.From(err, dg))
+			}
+		}
+	}()
+
+	return instance, nil
+}
+
+// runTask calls fn and turns a panic into the error of the task.
+func runTask(ctx context.Context, fn func(ctx context.Context) (language.Object, error)) (result language.Object, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("[thread/Task] task panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// TaskOf returns the Task behind a Task struct instance.
+func TaskOf(obj language.Object) (*Task, error) {
+	task, ok := fromBucket[*Task](obj, "task")
+	if !ok {
+		return nil, fmt.Errorf("[thread/Task] expected Task, got %s", obj.Type())
+	}
+	return task, nil
+}
+
+func newTaskStruct(dg *debug.Debug) {
+	ctx := context.Background()
+
+	taskStruct = language.NewStruct("Task", []language.StructField{
+		{
+			Name: "done",
+			Type: n.TBool,
+		},
+	}, dg)
+
+	proto := taskStruct.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	proto.SetObject(ctx, "wait", n.Function(n.Describe(
+		n.Arg("self", taskStruct.Type()),
+		n.Arg("timeout", n.Nullable(n.TInt), language.Nil),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			task, err := TaskOf(a.Name("self"))
+			if err != nil {
+				return nil, err
+			}
+
+			var timeout time.Duration
+			if ms := a.Name("timeout").Value(); ms != nil {
+				timeout = time.Duration(ms.(int64)) * time.Millisecond
+			}
+
+			return task.Wait(a.Context(), timeout)
+		}))
+
+	proto.SetObject(ctx, "result", n.Function(n.Describe(
+		n.Arg("self", taskStruct.Type()),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			task, err := TaskOf(a.Name("self"))
+			if err != nil {
+				return nil, err
+			}
+			if !task.Done() {
+				return nil, fmt.Errorf("[thread/Task] task is still running")
+			}
+
+			return task.Wait(a.Context(), 0)
+		}))
+
+	proto.SetObject(ctx, "cancel", n.Function(n.Describe(
+		n.Arg("self", taskStruct.Type()),
+	),
+		func(a *n.Args) (any, error) {
+			task, err := TaskOf(a.Name("self"))
+			if err != nil {
+				return nil, err
+			}
+			task.Cancel()
+			return nil, nil
+		}))
+
+	proto.Lock()
+	proto.Implement()
+}
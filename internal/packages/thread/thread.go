@@ -9,7 +9,6 @@ import (
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native"
 	"github.com/nubolang/nubo/native/n"
-	"go.uber.org/zap"
 )
 
 func NewThread(dg *debug.Debug) language.Object {
@@ -21,43 +20,126 @@ func NewThread(dg *debug.Debug) language.Object {
 	ctx := context.Background()
 	proto.SetObject(ctx, "Portal", portalStruct)
 
-	proto.SetObject(ctx, "spawn", native.NewFunction(func(args []language.Object) (language.Object, error) {
-		if len(args) < 1 {
-			return nil, fmt.Errorf("Expected at least one argument")
-		}
+	taskStructOnce.Do(func() { newTaskStruct(dg) })
+	proto.SetObject(ctx, "Task", taskStruct)
 
+	proto.SetObject(ctx, "spawn", language.NewTypedFunction([]language.FnArg{
+		&language.BasicFnArg{NameVal: "fn", TypeVal: n.TAny},
+		&language.BasicFnArg{NameVal: "args", TypeVal: n.TTList(n.TAny), VariadicVal: true},
+	}, taskStruct.Type(), func(ctx context.Context, args []language.Object) (language.Object, error) {
 		fnObj, ok := args[0].(*language.Function)
 		if !ok {
 			return nil, fmt.Errorf("First argument must be a function")
 		}
 
-		if len(args)-1 != len(fnObj.ArgTypes) {
-			return nil, fmt.Errorf("Provided function expected %d arguments, got %d", len(fnObj.ArgTypes), len(args)-1)
+		fnArgs := args[1].(*language.List).Data
+		if len(fnArgs) != len(fnObj.ArgTypes) && !language.IsVariadic(lastArg(fnObj.ArgTypes)) {
+			return nil, fmt.Errorf("Provided function expected %d arguments, got %d", len(fnObj.ArgTypes), len(fnArgs))
 		}
 
-		fn := fnObj.Data
-		fnArgs := args[1:]
+		return Spawn(ctx, dg, func(ctx context.Context) (language.Object, error) {
+			return fnObj.Call(ctx, fnArgs)
+		})
+	}, dg))
 
-		for i, argType := range fnObj.ArgTypes {
-			if !argType.Type().Compare(fnArgs[i].Type()) {
-				return nil, fmt.Errorf("Argument %d has type %s, expected %s", i+1, fnArgs[i].Type(), argType.Type())
+	proto.SetObject(ctx, "all", n.Function(n.Describe(
+		n.Arg("tasks", n.TTList(taskStruct.Type())),
+	).Returns(n.TTList(n.TAny)),
+		func(a *n.Args) (any, error) {
+			tasks, err := tasksOf(a.Name("tasks"))
+			if err != nil {
+				return nil, err
 			}
-		}
 
-		go func(fn func(ctx context.Context, args []language.Object) (language.Object, error), fnArgs []language.Object) {
-			_, err := fn(ctx, fnArgs)
+			// The group reports the failure of any of its tasks.
+			for _, task := range tasks {
+				task.observe()
+			}
+
+			results := make([]language.Object, len(tasks))
+			for i, task := range tasks {
+				result, err := task.Wait(a.Context(), 0)
+				if err != nil {
+					// One failure fails the whole group.
+					for _, other := range tasks {
+						other.Cancel()
+					}
+					return nil, err
+				}
+				results[i] = result
+			}
+
+			return language.NewList(results, n.TAny, dg), nil
+		}))
+
+	proto.SetObject(ctx, "race", n.Function(n.Describe(
+		n.Arg("tasks", n.TTList(taskStruct.Type())),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			tasks, err := tasksOf(a.Name("tasks"))
 			if err != nil {
-				zap.L().Error("@std/thread.spawn: executing a function failed", zap.Error(err))
+				return nil, err
+			}
+			if len(tasks) == 0 {
+				return nil, fmt.Errorf("[thread/race] expected at least one task")
 			}
-		}(fn, fnArgs)
 
-		return nil, nil
-	}))
+			first := make(chan *Task, len(tasks))
+			for _, task := range tasks {
+				task.observe()
+				go func(task *Task) {
+					<-task.done
+					first <- task
+				}(task)
+			}
+
+			var winner *Task
+			select {
+			case winner = <-first:
+			case <-a.Context().Done():
+				return nil, a.Context().Err()
+			}
+
+			// The losers are no longer needed.
+			for _, task := range tasks {
+				if task != winner {
+					task.Cancel()
+				}
+			}
+			return winner.Wait(a.Context(), 0)
+		}))
 
 	proto.SetObject(ctx, "yield", native.NewFunction(func(args []language.Object) (language.Object, error) {
 		runtime.Gosched()
 		return nil, nil
 	}))
 
+	selectCases(proto, dg)
+
+	syncStructsOnce.Do(func() { newSyncStructs(dg) })
+	for name, s := range syncStructs {
+		proto.SetObject(ctx, name, s)
+	}
+
 	return instance
 }
+
+func lastArg(args []language.FnArg) language.FnArg {
+	if len(args) == 0 {
+		return &language.BasicFnArg{}
+	}
+	return args[len(args)-1]
+}
+
+func tasksOf(obj language.Object) ([]*Task, error) {
+	list := obj.(*language.List)
+	tasks := make([]*Task, len(list.Data))
+	for i, item := range list.Data {
+		task, err := TaskOf(item)
+		if err != nil {
+			return nil, err
+		}
+		tasks[i] = task
+	}
+	return tasks, nil
+}
@@ -0,0 +1,246 @@
+package thread_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/nubolang/nubo/internal/packages/thread"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStderr returns what fn writes to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fn()
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestTasks(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import thread from "@std/thread"
+
+		fn double(n: int) int {
+			sleep(n)
+			return n * 2
+		}
+
+		const task = spawn double(3)
+		const all = thread.all([spawn double(1), thread.spawn(double, 2)])
+		const first = thread.race([spawn double(500), spawn double(1)])
+
+		const failing = spawn fn() int { panic("boom") }()
+		catch e {
+			failing.wait()
+		}
+
+		const slow = spawn double(5000)
+		slow.cancel()
+		catch c {
+			slow.wait()
+		}
+
+		return [string(task.wait()), string(task.done), all, string(first), e.message, c.message]
+	`, nil)
+	assert.Equal(t, []string{"6", "true", "[2, 4]", "2", "boom", "execution stopped: context canceled"}, results)
+}
+
+func TestUnobservedFailures(t *testing.T) {
+	out := captureStderr(t, func() {
+		nubotest.Run(t, `
+			import thread from "@std/thread"
+
+			const lost = spawn fn() { panic("nobody waits") }()
+
+			const waited = spawn fn() int {
+				sleep(20)
+				panic("somebody waits")
+			}()
+			catch e {
+				waited.wait()
+			}
+
+			const cancelled = spawn fn() { sleep(5000) }()
+			cancelled.cancel()
+
+			fn loser() int {
+				sleep(1000)
+				panic("lost the race")
+			}
+			fn winner() int {
+				return 1
+			}
+			thread.race([spawn loser(), spawn winner()])
+
+			sleep(50)
+		`, nil)
+	})
+
+	assert.Contains(t, out, "nobody waits")
+	assert.NotContains(t, out, "somebody waits")
+	assert.NotContains(t, out, "context canceled")
+	assert.NotContains(t, out, "lost the race")
+}
+
+func TestSelect(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import thread from "@std/thread"
+		import { Portal } from "@std/thread"
+
+		const a = Portal(1)
+		const b = Portal(1)
+		b.send("hello")
+
+		const received = thread.select([thread.recv(a), thread.recv(b)])
+		const fallback = thread.select([thread.recv(a), thread.fallback()])
+		const timeout = thread.select([thread.recv(a), thread.after(5)])
+
+		a.close()
+		const closed = thread.select([thread.recv(a), thread.after(1000)])
+
+		return [received.index, received.value, fallback.index, timeout.index, closed.index, closed.ok]
+	`, nil)
+	assert.Equal(t, []string{"1", "hello", "1", "1", "0", "false"}, results)
+}
+
+func TestSync(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import { Mutex, WaitGroup, Once, Semaphore, Counter } from "@std/thread"
+
+		const mu = Mutex()
+		const wg = WaitGroup()
+		const counter = Counter()
+		let total = 0
+
+		for i in range(0, 20) {
+			wg.add()
+			spawn fn() void {
+				defer wg.done()
+				mu.lock()
+				total = total + 1
+				mu.unlock()
+				counter.add()
+			}()
+		}
+		wg.wait()
+
+		const once = Once()
+		let calls = 0
+		once.do(fn() void { calls = calls + 1 })
+		once.do(fn() void { calls = calls + 1 })
+
+		const sem = Semaphore(1)
+		sem.acquire()
+		const acquired = sem.tryAcquire()
+		sem.release()
+
+		return [total, counter.get(), calls, acquired]
+	`, nil)
+	assert.Equal(t, []string{"20", "20", "1", "false"}, results)
+
+	_, err := nubotest.Exec(`
+		import { Mutex } from "@std/thread"
+
+		const mu = Mutex()
+		mu.unlock()
+	`, nil)
+	assert.ErrorContains(t, err, "[thread/Mutex] unlock of unlocked mutex")
+	assert.ErrorContains(t, err, "<nativeExecute>:5")
+}
+
+func TestSyncCancel(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import { Mutex, RWMutex } from "@std/thread"
+
+		const mu = Mutex()
+		mu.lock()
+		const blocked = spawn fn() { mu.lock() }()
+		sleep(10)
+		blocked.cancel()
+		catch e {
+			blocked.wait()
+		}
+		mu.unlock()
+
+		const rw = RWMutex()
+		rw.rlock()
+		const writer = spawn fn() { rw.lock() }()
+		sleep(10)
+		const readable = rw.tryRLock()
+		writer.cancel()
+		catch w {
+			writer.wait()
+		}
+		rw.runlock()
+
+		return [e.message, mu.tryLock(), readable, w.message, rw.tryLock()]
+	`, nil)
+	assert.Equal(t, []string{"context canceled", "true", "false", "context canceled", "true"}, results)
+}
+
+func TestRWMutex(t *testing.T) {
+	var m thread.RWMutex
+	ctx := context.Background()
+
+	require.NoError(t, m.RLock(ctx))
+	require.NoError(t, m.RLock(ctx))
+	assert.False(t, m.TryLock())
+
+	locked := make(chan error, 1)
+	go func() { locked <- m.Lock(ctx) }()
+
+	// A waiting writer keeps new readers out.
+	assert.Eventually(t, func() bool { return !m.TryRLock() }, time.Second, time.Millisecond)
+
+	require.NoError(t, m.RUnlock())
+	require.NoError(t, m.RUnlock())
+	require.NoError(t, <-locked)
+	assert.ErrorContains(t, m.RUnlock(), "runlock of unlocked mutex")
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.RLock(timeout), context.DeadlineExceeded)
+
+	require.NoError(t, m.Unlock())
+	assert.True(t, m.TryRLock())
+}
+
+func TestWaitGroup(t *testing.T) {
+	var wg thread.WaitGroup
+	ctx := context.Background()
+
+	require.NoError(t, wg.Add(2))
+	assert.ErrorContains(t, wg.Add(-3), "negative counter")
+
+	// Waits that give up leave no goroutine behind.
+	before := runtime.NumGoroutine()
+	for range 50 {
+		assert.ErrorContains(t, wg.Wait(ctx, time.Millisecond), "wait timeout")
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, wg.Wait(cancelled, 0), context.Canceled)
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+
+	done := make(chan error, 1)
+	go func() { done <- wg.Wait(ctx, 0) }()
+	require.NoError(t, wg.Add(-1))
+	require.NoError(t, wg.Add(-1))
+	assert.NoError(t, <-done)
+	assert.NoError(t, wg.Wait(ctx, 0))
+}
@@ -0,0 +1,97 @@
+package toml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+func NewTOML(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("toml", dg)
+	proto := instance.GetPrototype()
+
+	ctx := context.Background()
+	proto.SetObject(ctx, "parse", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(n.NewDictType(n.TString, n.TAny)),
+		func(a *n.Args) (any, error) {
+			text := a.Name("text").String()
+
+			data := make(map[string]any)
+			if _, err := toml.Decode(text, &data); err != nil {
+				return nil, parseError(text, err)
+			}
+			return language.FromValue(data, false, a.Name("text").Debug())
+		}))
+
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("type", n.TAny),
+		n.Arg("text", n.TString),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			text := a.Name("text").String()
+
+			data := make(map[string]any)
+			if _, err := toml.Decode(text, &data); err != nil {
+				return nil, parseError(text, err)
+			}
+
+			typ, ok := a.Name("type").(*language.Struct)
+			if !ok {
+				return nil, fmt.Errorf("[toml] cannot decode into %s", a.Name("type").Type())
+			}
+			instance, err := typ.FromValue(data, "toml", a.Name("text").Debug())
+			if err != nil {
+				return nil, fmt.Errorf("[toml] %w", err)
+			}
+			return instance, nil
+		}))
+
+	// value is a dict or a struct instance, as a TOML document is a table.
+	proto.SetObject(ctx, "stringify", n.Function(n.Describe(
+		n.Arg("value", n.TAny),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			value, err := language.EncodeValue(a.Name("value"), "toml")
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := value.(map[string]any); !ok {
+				return nil, fmt.Errorf("[toml] expected a dict or struct, got %s", a.Name("value").Type())
+			}
+
+			var buf bytes.Buffer
+			if err := toml.NewEncoder(&buf).Encode(value); err != nil {
+				return nil, fmt.Errorf("[toml] %w", err)
+			}
+			return buf.String(), nil
+		}))
+
+	return instance
+}
+
+var errorPrefix = regexp.MustCompile(`^toml: line \d+(?: \(last key "[^"]*"\))?: `)
+
+// parseError reports a toml.ParseError as "[toml] line L, column C: msg".
+// Both are computed from the byte offset of the error, as the reported line
+// is off by one for errors at the end of a line.
+func parseError(text string, err error) error {
+	var parseErr toml.ParseError
+	if !errors.As(err, &parseErr) {
+		return fmt.Errorf("[toml] %w", err)
+	}
+
+	start := min(parseErr.Position.Start, len(text))
+	line := strings.Count(text[:start], "\n") + 1
+	column := start - strings.LastIndexByte(text[:start], '\n')
+	msg := errorPrefix.ReplaceAllString(parseErr.Error(), "")
+	return fmt.Errorf("[toml] line %d, column %d: %s", line, column, msg)
+}
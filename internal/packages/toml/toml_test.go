@@ -0,0 +1,24 @@
+package toml_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToml(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import toml from "@std/toml"
+
+		const manifest = toml.parse("[package]\nversion = 3\n")
+		const pkg = manifest["package"]
+
+		catch e {
+			toml.parse("a = 1\nb = \n")
+		}
+
+		return [pkg["version"], e.message]
+	`, nil)
+	assert.Equal(t, []string{"3", "[toml] line 2, column 5: expected value but found '\\n' instead"}, results)
+}
@@ -0,0 +1,186 @@
+package xml
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+)
+
+// An element is decoded into a dict of the form
+//
+//	{"name": "item", "attrs": {"id": "1"}, "text": "...", "children": [...]}
+//
+// where text is the trimmed character data directly inside the element and
+// children are the nested elements in document order.
+//
+// Unlike json, yaml, toml and csv there is no decode into structs: a field
+// could come from an attribute, a child's text or repeated children, and
+// guessing differently from encoding/xml would surprise more than help.
+// Scripts map the element dict themselves.
+var TElement = n.NewDictType(n.TString, n.TAny)
+
+func NewXML(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("xml", dg)
+	proto := instance.GetPrototype()
+
+	ctx := context.Background()
+	proto.SetObject(ctx, "parse", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(TElement),
+		func(a *n.Args) (any, error) {
+			root, err := decode(a.Name("text").String())
+			if err != nil {
+				return nil, err
+			}
+			return language.FromValue(root, false, a.Name("text").Debug())
+		}))
+
+	proto.SetObject(ctx, "stringify", n.Function(n.Describe(
+		n.Arg("element", TElement),
+		n.Arg("indent", n.TString, n.String("")),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			value, err := language.ToValue(a.Name("element"), true)
+			if err != nil {
+				return nil, err
+			}
+
+			var buf bytes.Buffer
+			encoder := xml.NewEncoder(&buf)
+			encoder.Indent("", a.Name("indent").String())
+			if err := encode(encoder, value, "element"); err != nil {
+				return nil, err
+			}
+			if err := encoder.Flush(); err != nil {
+				return nil, fmt.Errorf("[xml] %w", err)
+			}
+			return buf.String(), nil
+		}))
+
+	return instance
+}
+
+func element(start xml.StartElement) map[string]any {
+	attrs := make(map[string]any, len(start.Attr))
+	for _, attr := range start.Attr {
+		attrs[attr.Name.Local] = attr.Value
+	}
+
+	return map[string]any{
+		"name":     start.Name.Local,
+		"attrs":    attrs,
+		"text":     "",
+		"children": []any{},
+	}
+}
+
+func decode(text string) (map[string]any, error) {
+	decoder := xml.NewDecoder(strings.NewReader(text))
+
+	var (
+		root  map[string]any
+		stack []map[string]any
+		texts []strings.Builder
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			line, column := decoder.InputPos()
+			var syntaxErr *xml.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				err = errors.New(syntaxErr.Msg)
+			}
+			return nil, fmt.Errorf("[xml] line %d, column %d: %s", line, column, err)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			el := element(token)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent["children"] = append(parent["children"].([]any), el)
+			} else if root != nil {
+				line, column := decoder.InputPos()
+				return nil, fmt.Errorf("[xml] line %d, column %d: multiple root elements", line, column)
+			} else {
+				root = el
+			}
+			stack = append(stack, el)
+			texts = append(texts, strings.Builder{})
+
+		case xml.EndElement:
+			stack[len(stack)-1]["text"] = strings.TrimSpace(texts[len(texts)-1].String())
+			stack = stack[:len(stack)-1]
+			texts = texts[:len(texts)-1]
+
+		case xml.CharData:
+			if len(texts) > 0 {
+				texts[len(texts)-1].Write(token)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("[xml] no root element")
+	}
+	return root, nil
+}
+
+// encode writes an element dict. path names the element in errors.
+func encode(encoder *xml.Encoder, value any, path string) error {
+	el, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("[xml] %s: expected an element dict", path)
+	}
+
+	name, ok := el["name"].(string)
+	if !ok || name == "" {
+		return fmt.Errorf("[xml] %s: missing name", path)
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if attrs, ok := el["attrs"].(map[string]any); ok {
+		keys := make([]string, 0, len(attrs))
+		for key := range attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: key}, Value: fmt.Sprint(attrs[key])})
+		}
+	}
+
+	if err := encoder.EncodeToken(start); err != nil {
+		return fmt.Errorf("[xml] %s: %w", path, err)
+	}
+	if text, ok := el["text"]; ok && text != "" {
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(text))); err != nil {
+			return fmt.Errorf("[xml] %s: %w", path, err)
+		}
+	}
+	if children, ok := el["children"].([]any); ok {
+		for i, child := range children {
+			if err := encode(encoder, child, fmt.Sprintf("%s.children[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := encoder.EncodeToken(start.End()); err != nil {
+		return fmt.Errorf("[xml] %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package xml_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXml(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import xml from "@std/xml"
+
+		const doc = xml.parse("<root id=\"1\"><item>hi</item></root>")
+		const item = doc["children"][0]
+
+		return [doc["attrs"]["id"], item["text"], xml.stringify(doc)]
+	`, nil)
+	assert.Equal(t, []string{"1", "hi", `<root id="1"><item>hi</item></root>`}, results)
+}
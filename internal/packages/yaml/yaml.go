@@ -0,0 +1,108 @@
+package yaml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
+	"gopkg.in/yaml.v3"
+)
+
+func NewYAML(dg *debug.Debug) language.Object {
+	instance := n.NewPackage("yaml", dg)
+	proto := instance.GetPrototype()
+
+	ctx := context.Background()
+
+	// parse and decode report syntax errors with their line only: yaml.v3
+	// does not expose the column of an error, unlike json and toml.
+	proto.SetObject(ctx, "parse", n.Function(n.Describe(
+		n.Arg("text", n.TString),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			var data any
+			if err := yaml.Unmarshal([]byte(a.Name("text").String()), &data); err != nil {
+				return nil, parseError(err)
+			}
+			return language.FromValue(data, false, a.Name("text").Debug())
+		}))
+
+	proto.SetObject(ctx, "decode", n.Function(n.Describe(
+		n.Arg("type", n.TAny),
+		n.Arg("text", n.TString),
+	).Returns(n.TAny),
+		func(a *n.Args) (any, error) {
+			var data any
+			if err := yaml.Unmarshal([]byte(a.Name("text").String()), &data); err != nil {
+				return nil, parseError(err)
+			}
+			return decode(a.Name("type"), data, a.Name("text").Debug())
+		}))
+
+	proto.SetObject(ctx, "stringify", n.Function(n.Describe(
+		n.Arg("value", n.TAny),
+		n.Arg("indent", n.TInt, n.Int(2, dg)),
+	).Returns(n.TString),
+		func(a *n.Args) (any, error) {
+			value, err := language.EncodeValue(a.Name("value"), "yaml")
+			if err != nil {
+				return nil, err
+			}
+
+			var buf bytes.Buffer
+			encoder := yaml.NewEncoder(&buf)
+			encoder.SetIndent(int(a.Name("indent").Value().(int64)))
+			if err := encoder.Encode(value); err != nil {
+				return nil, fmt.Errorf("[yaml] %w", err)
+			}
+			if err := encoder.Close(); err != nil {
+				return nil, fmt.Errorf("[yaml] %w", err)
+			}
+			return buf.String(), nil
+		}))
+
+	return instance
+}
+
+// decode converts parsed data into an instance of a struct or enum type.
+func decode(typ language.Object, data any, dg *debug.Debug) (language.Object, error) {
+	switch typ := typ.(type) {
+	case *language.Struct:
+		instance, err := typ.FromValue(data, "yaml", dg)
+		if err != nil {
+			return nil, fmt.Errorf("[yaml] %w", err)
+		}
+		return instance, nil
+	case *language.Enum:
+		value, err := typ.FromValue(data, dg)
+		if err != nil {
+			return nil, fmt.Errorf("[yaml] %w", err)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("[yaml] cannot decode into %s", typ.Type())
+}
+
+var lineError = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// parseError rewrites yaml.v3 errors as "[yaml] line L: msg". There is no
+// column to add: yaml.v3 only formats the line into its messages and keeps
+// neither in its errors. Some syntax errors come without a line too.
+func parseError(err error) error {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		return parseError(errors.New(typeErr.Errors[0]))
+	}
+
+	msg := err.Error()
+	if m := lineError.FindStringSubmatch(msg); m != nil {
+		return fmt.Errorf("[yaml] line %s: %s", m[1], m[2])
+	}
+	return fmt.Errorf("[yaml] %s", strings.TrimPrefix(msg, "yaml: "))
+}
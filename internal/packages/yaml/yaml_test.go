@@ -0,0 +1,37 @@
+package yaml_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYaml(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import yaml from "@std/yaml"
+
+		struct User {
+			name: string
+			email: string
+		}
+
+		const config = yaml.parse("name: nubo\ntags: [a, b]\n")
+		const bob = yaml.decode(User, "name: bob\nemail: bob@example.com\n")
+
+		return [config["name"], config["tags"], bob.email]
+	`, nil)
+	assert.Equal(t, []string{"nubo", "[a, b]", "bob@example.com"}, results)
+}
+
+func TestYamlErrors(t *testing.T) {
+	results := nubotest.Strings(t, `
+		import yaml from "@std/yaml"
+
+		catch e {
+			yaml.parse("a: 1\nb: [\n")
+		}
+		return [e.message]
+	`, nil)
+	assert.Equal(t, "[yaml] line 2: did not find expected node content", results[0])
+}
@@ -0,0 +1,42 @@
+// Package plugtest runs the test binary as a plugin for the tests of the
+// plug packages.
+package plugtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// env makes the test binary serve its test plugin instead of running the
+// tests.
+const env = "NUBO_PLUGTEST_PLUGIN"
+
+// Main serves the test plugin when the binary was started by a plugin
+// written with Dir and runs the tests otherwise. Call it from TestMain.
+func Main(m *testing.M, serve func()) {
+	if os.Getenv(env) != "" {
+		serve()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// Dir writes a _plug.yaml that starts the test binary as a plugin and
+// returns its directory. extra is appended to the plugin section.
+func Dir(t *testing.T, extra string) string {
+	t.Helper()
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	t.Setenv(env, "1")
+
+	dir := filepath.Join(t.TempDir(), "testplug")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	config := fmt.Sprintf("plugin:\n  source: .\n  cmd: \"true\"\n  binary: %q\n  transport:\n    mode: stdio\n%s", exe, extra)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "_plug.yaml"), []byte(config), 0o644))
+	return dir
+}
@@ -0,0 +1,81 @@
+package runtime
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nubolang/nubo/config"
+	"github.com/nubolang/nubo/internal/sandbox"
+)
+
+// Options configures what scripts of a Runtime may do.
+type Options struct {
+	// AllowStd lists the @std modules scripts may import, e.g. "json" or
+	// "net/ssh". Nil allows all of them.
+	AllowStd []string
+	// DisallowStd lists @std modules scripts may not import.
+	DisallowStd []string
+	// Roots limits file access of std packages to these directories. Empty
+	// allows any path.
+	Roots []string
+	// Network allows std packages to open network connections.
+	Network bool
+	// Process allows std packages to start, signal and talk to processes,
+	// including plugins, and scripts to read or change the environment or
+	// exit the host process with env and exit.
+	Process bool
+	// Limits bounds the resources a script may use.
+	Limits Limits
+}
+
+// Limits bounds the resources a script may use. Zero values mean no limit.
+type Limits struct {
+	// Timeout bounds a single run of a file and each call through Ctx.Call.
+	Timeout time.Duration
+	// MaxCallDepth bounds nested function calls.
+	MaxCallDepth int
+}
+
+// DefaultOptions returns trusted options that only apply the std allow and
+// disallow lists of the current config.
+func DefaultOptions() Options {
+	opts := Options{Network: true, Process: true}
+	if config.Current == nil {
+		return opts
+	}
+
+	std := config.Current.Runtime.Std
+	switch {
+	case std.Disallow == ":all" || std.Allow == "-":
+		opts.AllowStd = []string{}
+	case std.Allow != "" && std.Allow != ":all":
+		opts.AllowStd = splitList(std.Allow)
+	}
+	if std.Disallow != "" && std.Disallow != "-" && std.Disallow != ":all" {
+		opts.DisallowStd = splitList(std.Disallow)
+	}
+
+	return opts
+}
+
+func (o Options) policy() *sandbox.Policy {
+	return &sandbox.Policy{
+		Allow:        o.AllowStd,
+		Deny:         o.DisallowStd,
+		Roots:        o.Roots,
+		Network:      o.Network,
+		Process:      o.Process,
+		Timeout:      o.Limits.Timeout,
+		MaxCallDepth: o.Limits.MaxCallDepth,
+	}
+}
+
+func splitList(list string) []string {
+	var out []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -2,7 +2,11 @@ package runtime
 
 import (
 	"context"
+	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/nubolang/nubo/events"
@@ -11,6 +15,7 @@ import (
 	"github.com/nubolang/nubo/internal/debug"
 	"github.com/nubolang/nubo/internal/interpreter"
 	"github.com/nubolang/nubo/internal/packages"
+	"github.com/nubolang/nubo/internal/sandbox"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/packer"
 	"go.uber.org/zap"
@@ -30,24 +35,35 @@ type Runtime struct {
 	filemap map[string]uint
 	// returnMap is a map of interpreter identifiers to their computed return values if any
 	returnMap map[uint]language.Object
+	// scopes holds the top-level interpreter of every interpreted file. Unlike
+	// interpreters, entries stay after the file finished running.
+	scopes map[string]*interpreter.Interpreter
 
 	builtins map[string]language.Object
 	packages map[string]language.Object
 	packer   *packer.Packer
+	// fsys, when set, is read instead of the OS filesystem.
+	fsys fs.FS
 
-	ctx context.Context
+	policy *sandbox.Policy
+	ctx    context.Context
 }
 
-func New(pubsubProvider events.Provider) *Runtime {
+// New creates a runtime. opts decides what its scripts may do; pass
+// DefaultOptions() for trusted code.
+func New(pubsubProvider events.Provider, opts Options) *Runtime {
+	policy := opts.policy()
 	rt := &Runtime{
 		pubsubProvider: pubsubProvider,
 		iid:            0,
 		interpreters:   make(map[uint]*interpreter.Interpreter),
 		filemap:        make(map[string]uint),
 		returnMap:      make(map[uint]language.Object),
+		scopes:         make(map[string]*interpreter.Interpreter),
 		builtins:       builtin.GetBuiltins(),
 		packages:       make(map[string]language.Object),
-		ctx:            context.Background(),
+		policy:         policy,
+		ctx:            sandbox.WithPolicy(events.WithProvider(context.Background(), pubsubProvider), policy),
 	}
 	zap.L().Info("runtime.new", zap.Bool("eventsEnabled", pubsubProvider != nil))
 	return rt
@@ -58,11 +74,22 @@ func (r *Runtime) Context() context.Context {
 }
 
 func (r *Runtime) WithContext(ctx context.Context) *Runtime {
-	r.ctx = ctx
+	r.ctx = sandbox.WithPolicy(events.WithProvider(ctx, r.pubsubProvider), r.policy)
 	zap.L().Debug("runtime.context.set")
 	return r
 }
 
+// CallContext returns the context to call script functions from Go with:
+// ctx carrying the runtime's policy and events provider, bounded by the
+// policy timeout.
+func (r *Runtime) CallContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = sandbox.WithPolicy(events.WithProvider(ctx, r.pubsubProvider), r.policy)
+	if r.policy.Timeout > 0 {
+		return context.WithTimeout(ctx, r.policy.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
 func (r *Runtime) GetBuiltin(name string) (language.Object, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -70,13 +97,34 @@ func (r *Runtime) GetBuiltin(name string) (language.Object, bool) {
 	return obj, ok
 }
 
+// SetGlobal makes obj visible under name in every file this runtime
+// interprets, like a builtin.
+func (r *Runtime) SetGlobal(name string, obj language.Object) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.builtins[name] = obj
+	zap.L().Debug("runtime.global.set", zap.String("name", name))
+}
+
+// Lookup returns the top-level object called name declared by the last run
+// of file.
+func (r *Runtime) Lookup(file, name string) (language.Object, bool) {
+	r.mu.RLock()
+	scope, ok := r.scopes[file]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return scope.GetObject(name)
+}
+
 func (r *Runtime) GetPacker() (*packer.Packer, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if r.packer == nil {
 		zap.L().Info("runtime.packer.init")
-		p, err := packer.New(".")
+		p, err := packer.New(packer.DefaultRoot())
 		if err != nil {
 			return nil, err
 		}
@@ -103,6 +151,14 @@ func (r *Runtime) ProvidePackage(name string, pkg language.Object) {
 	zap.L().Debug("runtime.package.provide", zap.String("name", name))
 }
 
+// HasPackage reports whether a package was provided under name.
+func (r *Runtime) HasPackage(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.packages[name]
+	return ok
+}
+
 func (r *Runtime) ImportPackage(name string, dg *debug.Debug) (language.Object, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -113,7 +169,7 @@ func (r *Runtime) ImportPackage(name string, dg *debug.Debug) (language.Object,
 	}
 
 	zap.L().Debug("runtime.package.import", zap.String("name", name))
-	return packages.ImportPackage(name, dg)
+	return packages.ImportPackage(name, r.policy, dg)
 }
 
 func (r *Runtime) Interpret(file string, nodes []*astnode.Node) (language.Object, error) {
@@ -124,8 +180,10 @@ func (r *Runtime) Interpret(file string, nodes []*astnode.Node) (language.Object
 		return nil, err
 	}
 
-	info, err := os.Stat(file)
-	if err != nil {
+	// Code that does not come from a file, e.g. "<nativeExecute>", is
+	// always run again.
+	info, err := r.Stat(file)
+	if err != nil && !isVirtual(file) {
 		zap.L().Error("runtime.interpret.stat", zap.String("file", file), zap.Error(err))
 		return nil, err
 	}
@@ -133,8 +191,10 @@ func (r *Runtime) Interpret(file string, nodes []*astnode.Node) (language.Object
 	// check if same file already registered
 	r.mu.RLock()
 	for path, id := range r.filemap {
-		existingInfo, err := os.Stat(path)
-		if err == nil && os.SameFile(existingInfo, info) {
+		if info == nil {
+			break
+		}
+		if r.sameFile(file, info, path) {
 			if ret, ok := r.returnMap[id]; ok {
 				r.mu.RUnlock()
 				zap.L().Info("runtime.interpret.cachedReturn", zap.Uint("id", id), zap.String("file", file))
@@ -147,12 +207,20 @@ func (r *Runtime) Interpret(file string, nodes []*astnode.Node) (language.Object
 	}
 	r.mu.RUnlock()
 
-	interpreter := interpreter.New(r.ctx, file, r, false, wd)
+	ctx := r.ctx
+	if r.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.policy.Timeout)
+		defer cancel()
+	}
+
+	interpreter := interpreter.New(ctx, file, r, false, wd)
 	zap.L().Info("runtime.interpret.spawn", zap.Uint("id", interpreter.ID), zap.String("file", file))
 
 	r.mu.Lock()
 	r.interpreters[interpreter.ID] = interpreter
 	r.filemap[file] = interpreter.ID
+	r.scopes[file] = interpreter
 	r.mu.Unlock()
 
 	result, runErr := interpreter.Run(nodes)
@@ -189,7 +257,7 @@ func (r *Runtime) RemoveInterpreter(id uint) {
 }
 
 func (r *Runtime) FindInterpreter(file string) (*interpreter.Interpreter, bool) {
-	info, err := os.Stat(file)
+	info, err := r.Stat(file)
 	if err != nil {
 		return nil, false
 	}
@@ -198,8 +266,7 @@ func (r *Runtime) FindInterpreter(file string) (*interpreter.Interpreter, bool)
 	defer r.mu.RUnlock()
 
 	for path, id := range r.filemap {
-		existingInfo, err := os.Stat(path)
-		if err == nil && os.SameFile(existingInfo, info) {
+		if r.sameFile(file, info, path) {
 			zap.L().Debug("runtime.interpreter.find", zap.Uint("id", id), zap.String("file", file))
 			return r.interpreters[id], true
 		}
@@ -207,3 +274,46 @@ func (r *Runtime) FindInterpreter(file string) (*interpreter.Interpreter, bool)
 	zap.L().Debug("runtime.interpreter.miss", zap.String("file", file))
 	return nil, false
 }
+
+// UseFS makes the runtime read files, including imports, from fsys instead
+// of the OS filesystem. File names are then slash separated paths in fsys.
+func (r *Runtime) UseFS(fsys fs.FS) {
+	r.fsys = fsys
+	zap.L().Debug("runtime.fs.set")
+}
+
+// Stat returns information about file.
+func (r *Runtime) Stat(file string) (fs.FileInfo, error) {
+	if r.fsys == nil {
+		return os.Stat(file)
+	}
+	return fs.Stat(r.fsys, fsPath(file))
+}
+
+// Open opens file for reading.
+func (r *Runtime) Open(file string) (fs.File, error) {
+	if r.fsys == nil {
+		return os.Open(file)
+	}
+	return r.fsys.Open(fsPath(file))
+}
+
+// sameFile reports whether file, described by info, and other are the same
+// file.
+func (r *Runtime) sameFile(file string, info fs.FileInfo, other string) bool {
+	if r.fsys != nil {
+		return fsPath(file) == fsPath(other)
+	}
+	otherInfo, err := os.Stat(other)
+	return err == nil && os.SameFile(otherInfo, info)
+}
+
+// fsPath turns a file name into a path valid in an fs.FS.
+func fsPath(file string) string {
+	return path.Clean(filepath.ToSlash(file))
+}
+
+// isVirtual reports whether file names in-memory code, like "<nativeExecute>".
+func isVirtual(file string) bool {
+	return strings.HasPrefix(file, "<") && strings.HasSuffix(file, ">")
+}
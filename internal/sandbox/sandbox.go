@@ -0,0 +1,132 @@
+// Package sandbox carries the restrictions of a runtime down to the std
+// packages that touch files, the network or other processes.
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrDenied is returned when a script does something its policy forbids.
+var ErrDenied = errors.New("sandbox: permission denied")
+
+// Policy describes what scripts of a runtime may do. A nil *Policy allows
+// everything.
+type Policy struct {
+	// Allow lists the @std modules scripts may import; nil allows all of them.
+	Allow []string
+	// Deny lists @std modules scripts may not import.
+	Deny []string
+	// Roots limits file access to these directories; empty allows any path.
+	Roots []string
+	// Network allows network connections.
+	Network bool
+	// Process allows starting and signalling processes, reading and
+	// changing the environment and exiting.
+	Process bool
+	// Timeout bounds a single run of a file; zero means no limit.
+	Timeout time.Duration
+	// MaxCallDepth bounds nested function calls; zero means no limit.
+	MaxCallDepth int
+}
+
+// AllowStd reports whether the @std module name may be imported.
+func (p *Policy) AllowStd(name string) bool {
+	if p == nil {
+		return true
+	}
+	if p.Allow != nil && !slices.Contains(p.Allow, name) {
+		return false
+	}
+	return !slices.Contains(p.Deny, name)
+}
+
+// CheckPath returns an error unless path lies below one of the roots.
+func (p *Policy) CheckPath(path string) error {
+	if p == nil || len(p.Roots) == 0 {
+		return nil
+	}
+
+	resolved, err := resolve(path)
+	if err != nil {
+		return err
+	}
+	for _, root := range p.Roots {
+		root, err := resolve(root)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(root, resolved); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is outside of the allowed directories", ErrDenied, path)
+}
+
+// CheckNetwork returns an error unless network access is allowed. what
+// names the operation for the error message.
+func (p *Policy) CheckNetwork(what string) error {
+	if p == nil || p.Network {
+		return nil
+	}
+	return fmt.Errorf("%w: network access (%s)", ErrDenied, what)
+}
+
+// CheckProcess returns an error unless processes may be started or
+// signalled.
+func (p *Policy) CheckProcess(what string) error {
+	if p == nil || p.Process {
+		return nil
+	}
+	return fmt.Errorf("%w: process access (%s)", ErrDenied, what)
+}
+
+// resolve returns the absolute path with symlinks of its existing part
+// evaluated, so links cannot point out of a root.
+func resolve(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	existing, rest := abs, ""
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return abs, nil
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+
+	real, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(real, rest), nil
+}
+
+type policyKey struct{}
+
+// WithPolicy returns a copy of ctx that carries p.
+func WithPolicy(ctx context.Context, p *Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, p)
+}
+
+// FromContext returns the policy stored in ctx by WithPolicy, or nil.
+func FromContext(ctx context.Context) *Policy {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(policyKey{}).(*Policy)
+	return p
+}
@@ -23,7 +23,7 @@ func (i *Any) ID() string {
 }
 
 func (i *Any) Type() *Type {
-	return withObject(i, i.Data.Type())
+	return i.Data.Type()
 }
 
 func (i *Any) Inspect() string {
@@ -24,7 +24,7 @@ func (i *Bool) ID() string {
 }
 
 func (i *Bool) Type() *Type {
-	return withObject(i, TypeBool)
+	return TypeBool
 }
 
 func (i *Bool) Inspect() string {
@@ -24,7 +24,7 @@ func (i *Byte) ID() string {
 }
 
 func (i *Byte) Type() *Type {
-	return withObject(i, TypeByte)
+	return TypeByte
 }
 
 func (i *Byte) Inspect() string {
@@ -0,0 +1,371 @@
+package language
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nubolang/nubo/internal/debug"
+)
+
+type EnumVariant struct {
+	Name   string
+	Fields []StructField
+}
+
+// Enum is the definition of a tagged union. Its prototype holds the unit
+// variants as values and the variants with a payload as constructors.
+type Enum struct {
+	Name      string
+	Variants  []*EnumVariant
+	enumType  *Type
+	prototype *EnumPrototype
+	debug     *debug.Debug
+}
+
+func NewEnum(name string, debug *debug.Debug) *Enum {
+	enumType := &Type{
+		BaseType: ObjectTypeEnumDefinition,
+		Content:  name,
+	}
+
+	e := &Enum{
+		Name:      name,
+		enumType:  enumType,
+		prototype: NewEnumPrototype(),
+		debug:     debug,
+	}
+	enumType.ID = fmt.Sprintf("%p", e)
+	enumType.Object = e
+
+	return e
+}
+
+// DefineVariants sets the variants of e. It is separate from NewEnum so the
+// fields of a variant can refer to the enum itself.
+func (e *Enum) DefineVariants(variants []*EnumVariant) {
+	e.prototype.mu.Lock()
+	defer e.prototype.mu.Unlock()
+
+	e.Variants = variants
+	e.prototype.data = make(map[string]Object, len(variants))
+	for _, variant := range variants {
+		if len(variant.Fields) == 0 {
+			e.prototype.data[variant.Name] = &EnumValue{base: e, Variant: variant, debug: e.debug}
+			continue
+		}
+		e.prototype.data[variant.Name] = e.constructor(variant)
+	}
+}
+
+func (e *Enum) constructor(variant *EnumVariant) *Function {
+	args := make([]FnArg, len(variant.Fields))
+	for i, field := range variant.Fields {
+		args[i] = &BasicFnArg{NameVal: field.Name, TypeVal: field.Type}
+	}
+
+	return NewTypedFunction(args, e.ValueType(), func(ctx context.Context, o []Object) (Object, error) {
+		return e.NewValue(variant.Name, o)
+	}, e.debug)
+}
+
+// Variant returns the variant called name.
+func (e *Enum) Variant(name string) (*EnumVariant, bool) {
+	for _, variant := range e.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+// NewValue creates the variant called name holding fields in declaration
+// order.
+func (e *Enum) NewValue(name string, fields []Object) (*EnumValue, error) {
+	variant, ok := e.Variant(name)
+	if !ok {
+		return nil, fmt.Errorf("enum %s has no variant %s", e.Name, name)
+	}
+
+	if len(variant.Fields) == 0 {
+		if len(fields) != 0 {
+			return nil, fmt.Errorf("variant %s.%s does not take any fields", e.Name, name)
+		}
+		value, _ := e.prototype.GetObject(context.Background(), name)
+		return value.(*EnumValue), nil
+	}
+
+	if len(fields) != len(variant.Fields) {
+		return nil, fmt.Errorf("variant %s.%s expects %d fields, got %d", e.Name, name, len(variant.Fields), len(fields))
+	}
+
+	for i, field := range variant.Fields {
+		if fields[i] == nil {
+			return nil, fmt.Errorf("missing field %s of variant %s.%s", field.Name, e.Name, name)
+		}
+		if !field.Type.Compare(fields[i].Type()) {
+			return nil, fmt.Errorf("field %s of variant %s.%s expected type %s, got %s", field.Name, e.Name, name, field.Type, fields[i].Type())
+		}
+	}
+
+	return &EnumValue{base: e, Variant: variant, Fields: fields, debug: e.debug}, nil
+}
+
+// FromValue builds a variant from its JSON-like Go representation: the name
+// of a unit variant, or a map with the variant name as its only key and the
+// fields as its value.
+func (e *Enum) FromValue(data any, dg *debug.Debug) (*EnumValue, error) {
+	switch data := data.(type) {
+	case string:
+		variant, ok := e.Variant(data)
+		if !ok {
+			return nil, fmt.Errorf("enum %s has no variant %s", e.Name, data)
+		}
+		if len(variant.Fields) > 0 {
+			return nil, fmt.Errorf("variant %s.%s expects fields", e.Name, data)
+		}
+		return e.NewValue(data, nil)
+
+	case map[string]any:
+		if len(data) != 1 {
+			return nil, fmt.Errorf("expected a single variant of enum %s, got %d keys", e.Name, len(data))
+		}
+
+		for name, value := range data {
+			variant, ok := e.Variant(name)
+			if !ok {
+				return nil, fmt.Errorf("enum %s has no variant %s", e.Name, name)
+			}
+
+			raw, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: expected an object of fields", name)
+			}
+
+			fields := make([]Object, len(variant.Fields))
+			for i, field := range variant.Fields {
+				v, ok := raw[field.Name]
+				if !ok {
+					return nil, fmt.Errorf("%s.%s: missing field", name, field.Name)
+				}
+				obj, err := fromValueAs(field.Type, v, dg)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", name, field.Name, err)
+				}
+				fields[i] = obj
+			}
+			return e.NewValue(name, fields)
+		}
+	}
+
+	return nil, fmt.Errorf("cannot decode %T into enum %s", data, e.Name)
+}
+
+// fromValueAs converts data to an object of type t, turning whole floats into
+// ints and decoding nested enums.
+func fromValueAs(t *Type, data any, dg *debug.Debug) (Object, error) {
+	if e, ok := t.Object.(*Enum); ok {
+		return e.FromValue(data, dg)
+	}
+
+	obj, err := FromValue(data, false, dg)
+	if err != nil {
+		return nil, err
+	}
+
+	if f, ok := obj.(*Float); ok && !t.Compare(obj.Type()) && t.Compare(TypeInt) && f.Data == math.Trunc(f.Data) {
+		obj = NewInt(int64(f.Data), dg)
+	}
+
+	if !t.Compare(obj.Type()) {
+		return nil, fmt.Errorf("expected %s, got %s", t, obj.Type())
+	}
+	return obj, nil
+}
+
+// ValueType returns the type of the variants of e.
+func (e *Enum) ValueType() *Type {
+	typ := e.enumType.DeepClone()
+	typ.BaseType = ObjectTypeEnum
+	return withObject(e, typ)
+}
+
+func (e *Enum) ID() string {
+	return fmt.Sprintf("%p", e)
+}
+
+func (e *Enum) Type() *Type {
+	return e.enumType
+}
+
+func (e *Enum) Inspect() string {
+	return fmt.Sprintf("(enum %s) %s", e.enumType.ID, e.TypeString())
+}
+
+func (e *Enum) TypeString() string {
+	var sb strings.Builder
+	sb.WriteString(e.Name)
+	sb.WriteString("{")
+
+	for inx, variant := range e.Variants {
+		sb.WriteString(variant.Name)
+		if len(variant.Fields) > 0 {
+			sb.WriteRune('(')
+			for j, field := range variant.Fields {
+				sb.WriteString(field.Name)
+				sb.WriteString(": ")
+				sb.WriteString(field.Type.String())
+				if j < len(variant.Fields)-1 {
+					sb.WriteString(", ")
+				}
+			}
+			sb.WriteRune(')')
+		}
+		if inx < len(e.Variants)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	sb.WriteString("}")
+
+	defer sb.Reset()
+	return sb.String()
+}
+
+func (e *Enum) String() string {
+	return e.Name
+}
+
+func (e *Enum) GetPrototype() Prototype {
+	return e.prototype
+}
+
+func (e *Enum) Value() any {
+	return e
+}
+
+func (e *Enum) Debug() *debug.Debug {
+	return e.debug
+}
+
+func (e *Enum) Clone() Object {
+	return e
+}
+
+// EnumValue is a variant of an enum together with its fields. Values are
+// immutable, unit variants are shared so they can be compared with ==.
+type EnumValue struct {
+	base    *Enum
+	Variant *EnumVariant
+	Fields  []Object
+	debug   *debug.Debug
+
+	prototype *EnumPrototype
+	once      sync.Once
+}
+
+// Enum returns the definition of the value.
+func (v *EnumValue) Enum() *Enum {
+	return v.base
+}
+
+// Field returns the field called name.
+func (v *EnumValue) Field(name string) (Object, bool) {
+	for i, field := range v.Variant.Fields {
+		if field.Name == name {
+			return v.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+func (v *EnumValue) ID() string {
+	return fmt.Sprintf("%p", v)
+}
+
+func (v *EnumValue) Type() *Type {
+	return v.base.ValueType()
+}
+
+func (v *EnumValue) Inspect() string {
+	return fmt.Sprintf("(enum %s) %s", v.base.Name, v.variantString())
+}
+
+func (v *EnumValue) TypeString() string {
+	return "<Object(enum)>"
+}
+
+func (v *EnumValue) String() string {
+	return v.base.Name + "." + v.variantString()
+}
+
+func (v *EnumValue) variantString() string {
+	if len(v.Fields) == 0 {
+		return v.Variant.Name
+	}
+
+	fields := make([]string, len(v.Fields))
+	for i, field := range v.Variant.Fields {
+		value := v.Fields[i]
+		if str, ok := value.(*String); ok {
+			fields[i] = fmt.Sprintf("%s: %s", field.Name, strconv.Quote(str.Data))
+		} else {
+			fields[i] = fmt.Sprintf("%s: %s", field.Name, value.String())
+		}
+	}
+	return fmt.Sprintf("%s(%s)", v.Variant.Name, strings.Join(fields, ", "))
+}
+
+func (v *EnumValue) GetPrototype() Prototype {
+	v.once.Do(func() {
+		v.prototype = NewEnumPrototype()
+		for i, field := range v.Variant.Fields {
+			v.prototype.data[field.Name] = v.Fields[i]
+		}
+	})
+	return v.prototype
+}
+
+func (v *EnumValue) Value() any {
+	return v
+}
+
+func (v *EnumValue) Debug() *debug.Debug {
+	return v.debug
+}
+
+func (v *EnumValue) Clone() Object {
+	return v
+}
+
+// EnumPrototype exposes the variants of an enum or the fields of a variant.
+// It cannot be changed from Nubo code.
+type EnumPrototype struct {
+	data map[string]Object
+	mu   sync.RWMutex
+}
+
+func NewEnumPrototype() *EnumPrototype {
+	return &EnumPrototype{
+		data: make(map[string]Object),
+	}
+}
+
+func (ep *EnumPrototype) GetObject(ctx context.Context, name string) (Object, bool) {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	obj, ok := ep.data[name]
+	return obj, ok
+}
+
+func (ep *EnumPrototype) SetObject(ctx context.Context, name string, value Object) error {
+	return fmt.Errorf("cannot set %s: enums are immutable", name)
+}
+
+func (ep *EnumPrototype) Objects() map[string]Object {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.data
+}
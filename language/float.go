@@ -24,7 +24,7 @@ func (i *Float) ID() string {
 }
 
 func (i *Float) Type() *Type {
-	return withObject(i, TypeFloat)
+	return TypeFloat
 }
 
 func (i *Float) Inspect() string {
@@ -18,6 +18,9 @@ type BasicFnArg struct {
 	TypeVal    *Type
 	NameVal    string
 	DefaultVal Object
+	// VariadicVal marks the last argument of a function as collecting the
+	// remaining arguments into a list of type TypeVal.
+	VariadicVal bool
 }
 
 func (b *BasicFnArg) Type() *Type {
@@ -32,6 +35,31 @@ func (b *BasicFnArg) Default() Object {
 	return b.DefaultVal
 }
 
+func (b *BasicFnArg) Variadic() bool {
+	return b.VariadicVal
+}
+
+// IsVariadic reports whether arg collects the remaining arguments of a call.
+// Arguments that do not implement Variadic() bool are never variadic.
+func IsVariadic(arg FnArg) bool {
+	v, ok := arg.(interface{ Variadic() bool })
+	return ok && v.Variadic()
+}
+
+// variadicArgs reports whether the last of args is variadic.
+func variadicArgs(args []FnArg) bool {
+	return len(args) > 0 && IsVariadic(args[len(args)-1])
+}
+
+// VariadicElement returns the type of the values collected by a variadic
+// argument of type t.
+func VariadicElement(t *Type) *Type {
+	if t != nil && t.BaseType == ObjectTypeList && t.Element != nil {
+		return t.Element
+	}
+	return TypeAny
+}
+
 type fnVersion int
 
 const (
@@ -50,12 +78,14 @@ type Function struct {
 }
 
 func NewFunction(data func(context.Context, []Object) (Object, error), debug *debug.Debug) *Function {
-	return &Function{
+	fn := &Function{
 		Data:  data,
 		debug: debug,
 		typ:   &Type{BaseType: ObjectTypeFunction},
 		ver:   fnVersionBase,
 	}
+	fn.typ.Object = fn
+	return fn
 }
 
 func NewTypedFunction(argTypes []FnArg, returnType *Type, data func(context.Context, []Object) (Object, error), debug *debug.Debug) *Function {
@@ -70,9 +100,16 @@ func NewTypedFunction(argTypes []FnArg, returnType *Type, data func(context.Cont
 		Args:     args,
 	}
 
+	variadic := variadicArgs(argTypes)
+
 	fn := func(ctx context.Context, args []Object) (Object, error) {
+		fixed := argTypes
+		if variadic {
+			fixed = argTypes[:len(argTypes)-1]
+		}
+
 		minRequiredArgs := 0
-		for _, arg := range argTypes {
+		for _, arg := range fixed {
 			if arg.Default() == nil {
 				minRequiredArgs++
 			}
@@ -83,8 +120,17 @@ func NewTypedFunction(argTypes []FnArg, returnType *Type, data func(context.Cont
 		}
 
 		provideArgs := make([]Object, len(argTypes))
-		for i, argType := range argTypes {
-			if i < len(args) {
+		if variadic {
+			rest, err := variadicRest(argTypes[len(fixed)], len(fixed), args, debug)
+			if err != nil {
+				return nil, err
+			}
+			provideArgs[len(fixed)] = rest
+		}
+
+		for i, argType := range fixed {
+			// Call pads arguments that were not passed with nil.
+			if i < len(args) && args[i] != nil {
 				arg := args[i]
 				if !argType.Type().Compare(arg.Type()) {
 					return nil, fmt.Errorf("argument %d (%s) expected type %s, got %s", i+1, argType.Name(), argType.Type(), arg.Type())
@@ -119,7 +165,7 @@ func NewTypedFunction(argTypes []FnArg, returnType *Type, data func(context.Cont
 		return value, nil
 	}
 
-	return &Function{
+	function := &Function{
 		Data:       fn,
 		ArgTypes:   argTypes,
 		ReturnType: returnType,
@@ -127,6 +173,30 @@ func NewTypedFunction(argTypes []FnArg, returnType *Type, data func(context.Cont
 		debug:      debug,
 		ver:        fnVersionTyped,
 	}
+	typ.Object = function
+	return function
+}
+
+// variadicRest collects the arguments from index start on into the list
+// passed as the variadic argument arg.
+func variadicRest(arg FnArg, start int, args []Object, debug *debug.Debug) (Object, error) {
+	elem := VariadicElement(arg.Type())
+
+	var items []Object
+	for i := start; i < len(args); i++ {
+		if args[i] == nil {
+			continue
+		}
+		if !elem.Compare(args[i].Type()) {
+			return nil, fmt.Errorf("argument %d (%s) expected type %s, got %s", i+1, arg.Name(), elem, args[i].Type())
+		}
+		items = append(items, args[i])
+	}
+
+	if items == nil {
+		items = []Object{}
+	}
+	return NewList(items, elem, debug), nil
 }
 
 func (i *Function) Call(ctx context.Context, args []Object, namedArgs ...map[string]Object) (Object, error) {
@@ -135,6 +205,9 @@ func (i *Function) Call(ctx context.Context, args []Object, namedArgs ...map[str
 	}
 
 	var realArgs = make([]Object, len(i.ArgTypes))
+	if variadicArgs(i.ArgTypes) && len(args) > len(realArgs) {
+		realArgs = make([]Object, len(args))
+	}
 
 	copy(realArgs, args)
 
@@ -158,12 +231,21 @@ func (i *Function) Call(ctx context.Context, args []Object, namedArgs ...map[str
 	return i.Data(ctx, realArgs)
 }
 
+// AcceptsArgs reports whether the function can be called with n positional
+// arguments without dropping any. Untyped functions accept any number.
+func (i *Function) AcceptsArgs(n int) bool {
+	if i.ver == fnVersionBase || variadicArgs(i.ArgTypes) {
+		return true
+	}
+	return n <= len(i.ArgTypes)
+}
+
 func (i *Function) ID() string {
 	return fmt.Sprintf("%p", i)
 }
 
 func (i *Function) Type() *Type {
-	return withObject(i, i.typ)
+	return i.typ
 }
 
 func (i *Function) Inspect() string {
@@ -220,11 +302,14 @@ func (i *Function) Debug() *debug.Debug {
 }
 
 func (i *Function) Clone() Object {
-	return &Function{
+	typ := *i.typ
+	clone := &Function{
 		Data:       i.Data,
 		ArgTypes:   i.ArgTypes,
 		ReturnType: i.ReturnType,
-		typ:        i.typ,
+		typ:        &typ,
 		debug:      i.debug,
 	}
+	typ.Object = clone
+	return clone
 }
@@ -0,0 +1,160 @@
+package language
+
+import "strings"
+
+// NewTypeParam returns the type parameter name of a generic declaration. It
+// matches any type until it is replaced by Substitute.
+func NewTypeParam(name string) *Type {
+	return &Type{BaseType: ObjectTypeAny, Content: name, Param: true}
+}
+
+// HasParams reports whether t refers to a type parameter.
+func (t *Type) HasParams() bool {
+	if t == nil {
+		return false
+	}
+	if t.Param || t.Key.HasParams() || t.Value.HasParams() || t.Element.HasParams() || t.Next.HasParams() {
+		return true
+	}
+	for _, arg := range t.Args {
+		if arg.HasParams() {
+			return true
+		}
+	}
+	return false
+}
+
+// Substitute returns a copy of t with the type parameters bound in args
+// replaced. Unbound parameters are kept.
+func (t *Type) Substitute(args map[string]*Type) *Type {
+	if t == nil {
+		return nil
+	}
+
+	var head *Type
+	if bound, ok := args[t.Content]; ok && t.Param {
+		head = bound.DeepClone()
+	} else {
+		head = &Type{
+			BaseType: t.BaseType,
+			Content:  t.Content,
+			ID:       t.ID,
+			Param:    t.Param,
+			Iface:    t.Iface,
+			Object:   t.Object,
+			Key:      t.Key.Substitute(args),
+			Value:    t.Value.Substitute(args),
+			Element:  t.Element.Substitute(args),
+		}
+		if len(t.Args) > 0 {
+			head.Args = make([]*Type, len(t.Args))
+			for i, arg := range t.Args {
+				head.Args[i] = arg.Substitute(args)
+			}
+		}
+	}
+
+	if t.Next != nil {
+		tail := head
+		for tail.Next != nil {
+			tail = tail.Next
+		}
+		tail.Next = t.Next.Substitute(args)
+	}
+	return head
+}
+
+// Infer binds the type parameters of t to the matching parts of actual, the
+// type of a value, and stores them in args. Parameters that are already
+// bound are left alone, so conflicts show up when the substituted type is
+// compared with actual.
+func (t *Type) Infer(actual *Type, args map[string]*Type) {
+	if t == nil || actual == nil {
+		return
+	}
+
+	if t.Next != nil {
+		// A union binds through the first alternative with parameters,
+		// unless actual already matches a concrete one, e.g. nil for T?.
+		var generic *Type
+		for alt := t; alt != nil; alt = alt.Next {
+			single := alt.single()
+			if !single.HasParams() {
+				if single.Compare(actual) {
+					return
+				}
+			} else if generic == nil {
+				generic = single
+			}
+		}
+		generic.Infer(actual, args)
+		return
+	}
+
+	if t.Param {
+		if _, ok := args[t.Content]; !ok && actual.Base() != ObjectTypeNil && !actual.Param {
+			args[t.Content] = actual.DeepClone()
+		}
+		return
+	}
+
+	if isStructType(t) && isStructType(actual) {
+		t.inferStruct(actual, args)
+		return
+	}
+
+	if actual.BaseType != t.BaseType {
+		return
+	}
+
+	switch t.BaseType {
+	case ObjectTypeList:
+		t.Element.Infer(actual.Element, args)
+	case ObjectTypeDict:
+		t.Key.Infer(actual.Key, args)
+		t.Value.Infer(actual.Value, args)
+	case ObjectTypeFunction:
+		if len(t.Args) == len(actual.Args) {
+			for i := range t.Args {
+				t.Args[i].Infer(actual.Args[i], args)
+			}
+		}
+		t.Value.Infer(actual.Value, args)
+	}
+}
+
+// inferStruct binds the type arguments of a generic struct type, e.g.
+// Box<T> against Box<int>.
+func (t *Type) inferStruct(actual *Type, args map[string]*Type) {
+	if t.ID != actual.ID || len(t.Args) != len(actual.Args) {
+		return
+	}
+	for i := range t.Args {
+		t.Args[i].Infer(actual.Args[i], args)
+	}
+}
+
+func isStructType(t *Type) bool {
+	return t.BaseType == ObjectTypeStructDefinition || t.BaseType == ObjectTypeStructInstance
+}
+
+// single returns t without the rest of its union.
+func (t *Type) single() *Type {
+	if t.Next == nil {
+		return t
+	}
+	clone := *t
+	clone.Next = nil
+	return &clone
+}
+
+func typeArgsString(args []*Type) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = arg.String()
+	}
+	return "<" + strings.Join(parts, ", ") + ">"
+}
@@ -87,6 +87,21 @@ func ToValue(obj Object, json ...bool) (any, error) {
 
 		return ToValue(converted, jsonMode)
 
+	case *EnumValue:
+		if len(v.Fields) == 0 {
+			return v.Variant.Name, nil
+		}
+
+		fields := make(map[string]any, len(v.Fields))
+		for i, field := range v.Variant.Fields {
+			val, err := ToValue(v.Fields[i], jsonMode)
+			if err != nil {
+				return nil, err
+			}
+			fields[field.Name] = val
+		}
+		return map[string]any{v.Variant.Name: fields}, nil
+
 	case *NilObj:
 		return nil, nil
 
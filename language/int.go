@@ -28,7 +28,7 @@ func (i *Int) ID() string {
 }
 
 func (i *Int) Type() *Type {
-	return withObject(i, TypeInt)
+	return TypeInt
 }
 
 func (i *Int) Inspect() string {
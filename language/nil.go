@@ -26,7 +26,7 @@ func (n *NilObj) ID() string {
 }
 
 func (n *NilObj) Type() *Type {
-	return withObject(n, TypeNil)
+	return TypeNil
 }
 
 func (n *NilObj) Inspect() string {
@@ -27,6 +27,8 @@ const (
 	ObjectTypeHtml
 	ObjectTypeIface
 	ObjectTypeType
+	ObjectTypeEnum
+	ObjectTypeEnumDefinition
 )
 
 func (ot ObjectType) String() string {
@@ -67,6 +69,10 @@ func (ot ObjectType) String() string {
 		return "iface"
 	case ObjectTypeType:
 		return "type"
+	case ObjectTypeEnum:
+		return "enum"
+	case ObjectTypeEnumDefinition:
+		return "enumdef"
 	default:
 		return "unknown"
 	}
@@ -115,6 +121,8 @@ type Object interface {
 	// Size() uint64
 }
 
+// withObject attaches ob to t, a type built for ob alone. Types shared
+// between objects are never written to, as objects are used concurrently.
 func withObject(ob Object, t *Type) *Type {
 	t.Object = ob
 	return t
@@ -27,7 +27,7 @@ func (i *String) ID() string {
 }
 
 func (i *String) Type() *Type {
-	return withObject(i, TypeString)
+	return TypeString
 }
 
 func (i *String) Inspect() string {
@@ -11,6 +11,9 @@ type StructField struct {
 	Name    string
 	Type    *Type
 	Private bool
+	// Tag holds the field tag in Go's `key:"value"` form, such as
+	// `json:"user_name"`.
+	Tag string
 }
 
 type StructDefinition struct {
@@ -26,6 +29,9 @@ type Struct struct {
 	prototype  *StructPrototype
 	debug      *debug.Debug
 	privateMap map[string]struct{}
+
+	// TypeParams names the type parameters of a generic struct.
+	TypeParams []string
 }
 
 func NewStruct(name string, fields []StructField, debug *debug.Debug) *Struct {
@@ -49,6 +55,7 @@ func NewStruct(name string, fields []StructField, debug *debug.Debug) *Struct {
 	}
 
 	structType.ID = fmt.Sprintf("%p", s)
+	structType.Object = s
 
 	return s
 }
@@ -65,6 +72,7 @@ func NewStructBetter(name string, debug *debug.Debug) *Struct {
 		debug:      debug,
 	}
 	structType.ID = fmt.Sprintf("%p", s)
+	structType.Object = s
 
 	return s
 }
@@ -100,7 +108,7 @@ func (i *Struct) ID() string {
 }
 
 func (i *Struct) Type() *Type {
-	return withObject(i, i.structType)
+	return i.structType
 }
 
 func (i *Struct) Inspect() string {
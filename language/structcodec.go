@@ -0,0 +1,331 @@
+package language
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/nubolang/nubo/internal/debug"
+)
+
+// Key returns the name f is stored under in format, read from a tag such as
+// `json:"user_name"`. ok is false for private fields and fields tagged "-".
+func (f StructField) Key(format string) (key string, ok bool) {
+	if f.Private {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(reflect.StructTag(f.Tag).Get(format), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	}
+	return name, true
+}
+
+// FromValue decodes data, as returned by a format parser, into a new
+// instance of s. Fields are looked up by their format keys and checked
+// against their types; every mismatch is reported with its path, as in
+// "users[3].email: expected string, got int".
+func (s *Struct) FromValue(data any, format string, dg *debug.Debug) (*StructInstance, error) {
+	d := &decoder{format: format, debug: dg}
+
+	instance := d.decodeStruct(s, data, "")
+	if len(d.errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(d.errs, "; "))
+	}
+	return instance, nil
+}
+
+type decoder struct {
+	format string
+	debug  *debug.Debug
+	errs   []string
+}
+
+func (d *decoder) fail(path string, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if path != "" {
+		msg = path + ": " + msg
+	}
+	d.errs = append(d.errs, msg)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// kindOf names the type of a decoded value in errors.
+func kindOf(data any) string {
+	switch data := data.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case float32:
+		return "float"
+	case float64:
+		if data == math.Trunc(data) {
+			return "int"
+		}
+		return "float"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "int"
+	case []any:
+		return "list"
+	case map[string]any, map[any]any:
+		return "dict"
+	}
+	return fmt.Sprintf("%T", data)
+}
+
+// stringMap returns data as a map with string keys. YAML decodes mappings
+// with non-string keys into map[any]any.
+func stringMap(data any) (map[string]any, bool) {
+	switch data := data.(type) {
+	case map[string]any:
+		return data, true
+	case map[any]any:
+		out := make(map[string]any, len(data))
+		for key, value := range data {
+			out[fmt.Sprint(key)] = value
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func (d *decoder) decodeStruct(s *Struct, data any, path string) *StructInstance {
+	fields, ok := stringMap(data)
+	if !ok {
+		d.fail(path, "expected %s, got %s", s.Name, kindOf(data))
+		return nil
+	}
+
+	instance, err := s.NewInstance()
+	if err != nil {
+		d.fail(path, "%s", err)
+		return nil
+	}
+
+	ctx := StructAllowPrivateCtx(context.Background())
+	for _, field := range s.Data {
+		key, ok := field.Key(d.format)
+		if !ok {
+			continue
+		}
+
+		fieldPath := joinPath(path, key)
+		value, ok := fields[key]
+		if !ok {
+			if !field.Type.Compare(TypeNil) {
+				d.fail(fieldPath, "missing field")
+			}
+			continue
+		}
+
+		obj := d.decode(field.Type, value, fieldPath)
+		if obj == nil {
+			continue
+		}
+		if err := instance.GetPrototype().SetObject(ctx, field.Name, obj); err != nil {
+			d.fail(fieldPath, "%s", err)
+		}
+	}
+
+	return instance
+}
+
+// decode converts data to an object of type t. It returns nil after
+// recording an error if data does not fit t.
+func (d *decoder) decode(t *Type, data any, path string) Object {
+	if t == nil || t.BaseType == ObjectTypeAny {
+		obj, err := FromValue(data, false, d.debug)
+		if err != nil {
+			d.fail(path, "%s", err)
+			return nil
+		}
+		return obj
+	}
+
+	if data == nil {
+		if t.Compare(TypeNil) {
+			return Nil
+		}
+		d.fail(path, "expected %s, got nil", t)
+		return nil
+	}
+
+	// Union members are tried in order; the first one that fits wins.
+	if t.Next != nil {
+		for member := t; member != nil; member = member.Next {
+			single := member.DeepClone()
+			single.Next = nil
+
+			try := &decoder{format: d.format, debug: d.debug}
+			if obj := try.decode(single, data, path); len(try.errs) == 0 {
+				return obj
+			}
+		}
+		d.fail(path, "expected %s, got %s", t, kindOf(data))
+		return nil
+	}
+
+	switch ob := t.Object.(type) {
+	case *Enum:
+		value, err := ob.FromValue(data, d.debug)
+		if err != nil {
+			d.fail(path, "%s", err)
+			return nil
+		}
+		return value
+	case *Struct:
+		if t.BaseType == ObjectTypeStructDefinition || t.BaseType == ObjectTypeStructInstance {
+			if instance := d.decodeStruct(ob, data, path); instance != nil {
+				return instance
+			}
+			return nil
+		}
+	}
+
+	switch t.BaseType {
+	case ObjectTypeList:
+		items, ok := data.([]any)
+		if !ok {
+			d.fail(path, "expected %s, got %s", t, kindOf(data))
+			return nil
+		}
+
+		list := make([]Object, len(items))
+		for i, item := range items {
+			list[i] = d.decode(t.Element, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+		for _, item := range list {
+			if item == nil {
+				return nil
+			}
+		}
+		return NewList(list, t.Element, d.debug)
+
+	case ObjectTypeDict:
+		entries, ok := stringMap(data)
+		if !ok {
+			d.fail(path, "expected %s, got %s", t, kindOf(data))
+			return nil
+		}
+
+		keys := make([]Object, 0, len(entries))
+		values := make([]Object, 0, len(entries))
+		failed := false
+		for key, value := range entries {
+			k := d.decode(t.Key, key, joinPath(path, key))
+			v := d.decode(t.Value, value, joinPath(path, key))
+			if k == nil || v == nil {
+				failed = true
+				continue
+			}
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		if failed {
+			return nil
+		}
+
+		dict, err := NewDict(keys, values, t.Key, t.Value, d.debug)
+		if err != nil {
+			d.fail(path, "%s", err)
+			return nil
+		}
+		return dict
+	}
+
+	obj, err := FromValue(data, false, d.debug)
+	if err != nil {
+		d.fail(path, "%s", err)
+		return nil
+	}
+
+	// Formats without an integer type decode every number as a float.
+	switch v := obj.(type) {
+	case *Float:
+		if t.BaseType != ObjectTypeFloat && v.Data == math.Trunc(v.Data) {
+			obj = NewInt(int64(v.Data), d.debug)
+		}
+	case *Int:
+		if t.BaseType == ObjectTypeFloat {
+			obj = NewFloat(float64(v.Data), d.debug)
+		}
+	}
+
+	if !t.Compare(obj.Type()) {
+		d.fail(path, "expected %s, got %s", t, obj.Type())
+		return nil
+	}
+	return obj
+}
+
+// EncodeValue converts obj like ToValue in json mode, but writes struct
+// fields under their format keys and leaves out private fields.
+func EncodeValue(obj Object, format string) (any, error) {
+	switch v := obj.(type) {
+	case *List:
+		out := make([]any, len(v.Data))
+		for i, item := range v.Data {
+			value, err := EncodeValue(item, format)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = value
+		}
+		return out, nil
+
+	case *Dict:
+		out := make(map[string]any)
+		err := v.Data.IterateErr(func(key Object, value Object) error {
+			val, err := EncodeValue(value, format)
+			if err != nil {
+				return err
+			}
+			out[key.String()] = val
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return out, nil
+
+	case *StructInstance:
+		ctx := StructAllowPrivateCtx(context.Background())
+		proto := v.GetPrototype()
+
+		out := make(map[string]any, len(v.base.Data))
+		for _, field := range v.base.Data {
+			key, ok := field.Key(format)
+			if !ok {
+				continue
+			}
+
+			value, ok := proto.GetObject(ctx, field.Name)
+			if !ok {
+				return nil, fmt.Errorf("value not found for key: %s", field.Name)
+			}
+			val, err := EncodeValue(value, format)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = val
+		}
+		return out, nil
+	}
+
+	return ToValue(obj, true)
+}
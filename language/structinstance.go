@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/nubolang/nubo/internal/debug"
 	"go.uber.org/zap"
@@ -15,6 +16,10 @@ type StructInstance struct {
 	prototype *StructPrototype
 	bucket    map[string]any
 	debug     *debug.Debug
+
+	// typeArgs holds the type arguments of a generic struct bound so far.
+	typeArgs map[string]*Type
+	typeMu   sync.RWMutex
 }
 
 func NewStructInstance(base *Struct, name string, debug *debug.Debug) (*StructInstance, error) {
@@ -41,9 +46,69 @@ func (i *StructInstance) ID() string {
 func (i *StructInstance) Type() *Type {
 	cloned := i.base.structType.DeepClone()
 	cloned.BaseType = ObjectTypeStructInstance
+
+	if len(i.base.TypeParams) > 0 {
+		i.typeMu.RLock()
+		cloned.Args = make([]*Type, len(i.base.TypeParams))
+		for inx, name := range i.base.TypeParams {
+			if arg, ok := i.typeArgs[name]; ok {
+				cloned.Args[inx] = arg.DeepClone()
+			} else {
+				cloned.Args[inx] = NewTypeParam(name)
+			}
+		}
+		i.typeMu.RUnlock()
+	}
+
 	return withObject(i, cloned)
 }
 
+// BindTypeArgs binds the type parameters of a generic struct instance that
+// are still unbound to the type arguments of declared, the type of the
+// variable or argument holding it, e.g. Box<int>.
+func BindTypeArgs(obj Object, declared *Type) {
+	i, ok := obj.(*StructInstance)
+	if !ok || len(i.base.TypeParams) == 0 {
+		return
+	}
+
+	for alt := declared; alt != nil; alt = alt.Next {
+		if !isStructType(alt) || alt.ID != i.base.structType.ID || len(alt.Args) != len(i.base.TypeParams) {
+			continue
+		}
+
+		i.typeMu.Lock()
+		if i.typeArgs == nil {
+			i.typeArgs = make(map[string]*Type, len(i.base.TypeParams))
+		}
+		for inx, name := range i.base.TypeParams {
+			arg := alt.Args[inx]
+			if _, bound := i.typeArgs[name]; !bound && !arg.HasParams() {
+				i.typeArgs[name] = arg.DeepClone()
+			}
+		}
+		i.typeMu.Unlock()
+		return
+	}
+}
+
+// fieldType returns the type of a field holding value. For generic structs
+// the type parameters of the field that are still unbound are inferred from
+// value first.
+func (i *StructInstance) fieldType(field *Type, value *Type) *Type {
+	if len(i.base.TypeParams) == 0 || !field.HasParams() {
+		return field
+	}
+
+	i.typeMu.Lock()
+	defer i.typeMu.Unlock()
+	if i.typeArgs == nil {
+		i.typeArgs = make(map[string]*Type, len(i.base.TypeParams))
+	}
+	field.Infer(value, i.typeArgs)
+	return field.Substitute(i.typeArgs)
+}
+
 func (i *StructInstance) Inspect() string {
 	objs := i.GetPrototype().Objects()
 	if len(objs) == 0 {
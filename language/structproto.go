@@ -87,6 +87,8 @@ func (s *StructPrototype) GetObject(ctx context.Context, name string) (Object, b
 		}
 	}
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	obj, ok := s.data[name]
 	return obj, ok
 }
@@ -110,8 +112,8 @@ func (s *StructPrototype) SetObject(ctx context.Context, name string, value Obje
 
 	for _, field := range s.base.Data {
 		if field.Name == name {
-			if !field.Type.Compare(value.Type()) {
-				return fmt.Errorf("type mismatch, expected %s, got %s", field.Type, value.Type())
+			if typ := s.instance.fieldType(field.Type, value.Type()); !typ.Compare(value.Type()) {
+				return fmt.Errorf("type mismatch, expected %s, got %s", typ, value.Type())
 			}
 			s.data[name] = value
 			return nil
@@ -27,6 +27,7 @@ type Type struct {
 	ID       string     // if BaseType == ObjectTypeStructInstance, represents the struct ID
 	Next     *Type      // if it's an union type, represents the next type in the union
 	Iface    *IfaceType // if Kind == "IFACE", represents the compare methods
+	Param    bool       // if true, the type is the type parameter named Content
 
 	Object Object
 }
@@ -110,6 +111,10 @@ func (t *Type) String() string {
 		next = "|" + t.Next.String()
 	}
 
+	if t.Param {
+		return t.Content + next
+	}
+
 	switch t.BaseType {
 	default:
 		return t.BaseType.String() + next
@@ -133,9 +138,13 @@ func (t *Type) String() string {
 	case ObjectTypeDict:
 		return fmt.Sprintf("dict[%s, %s]%s", t.Key.String(), t.Value.String(), next)
 	case ObjectTypeStructDefinition:
-		return fmt.Sprintf("(struct) %s%s", t.Content, next)
+		return fmt.Sprintf("(struct) %s%s%s", t.Content, typeArgsString(t.Args), next)
 	case ObjectTypeStructInstance:
-		return fmt.Sprintf("%s{}%s", t.Content, next)
+		return fmt.Sprintf("%s%s{}%s", t.Content, typeArgsString(t.Args), next)
+	case ObjectTypeEnumDefinition:
+		return fmt.Sprintf("(enum) %s%s", t.Content, next)
+	case ObjectTypeEnum:
+		return t.Content + next
 	case ObjectTypeIface:
 		var sb strings.Builder
 
@@ -238,15 +247,42 @@ func (t *Type) Compare(other *Type) bool {
 			}
 		}
 
-		// Return type is checked in normal direction.
-		ok := t.Value.Compare(other.Value)
+		// Return type is checked in normal direction. A generic function
+		// returning its type parameter fits any return type.
+		ok := (other.Value != nil && other.Value.Param) || t.Value.Compare(other.Value)
 		if !ok {
 			return t.NextMatch(other)
 		}
 		return ok
 
 	case ObjectTypeStructDefinition, ObjectTypeStructInstance:
-		return t.ID == other.ID
+		if t.ID != other.ID {
+			return t.NextMatch(other)
+		}
+		// A struct named without type arguments matches any of its instances.
+		if len(t.Args) == 0 || len(other.Args) == 0 {
+			return true
+		}
+		if len(t.Args) != len(other.Args) {
+			return t.NextMatch(other)
+		}
+		for i := range t.Args {
+			// An argument the instance has not bound yet is bound by the
+			// declaration, see BindTypeArgs.
+			if other.Args[i].Param {
+				continue
+			}
+			if !t.Args[i].Compare(other.Args[i]) {
+				return t.NextMatch(other)
+			}
+		}
+		return true
+
+	case ObjectTypeEnum, ObjectTypeEnumDefinition:
+		if t.ID != other.ID {
+			return t.NextMatch(other)
+		}
+		return true
 	}
 
 	ok := t.BaseType.String() == other.BaseType.String()
@@ -284,6 +320,8 @@ func (t *Type) DeepClone() *Type {
 		BaseType: t.BaseType,
 		Content:  t.Content,
 		ID:       t.ID,
+		Param:    t.Param,
+		Object:   t.Object,
 	}
 
 	if t.Key != nil {
@@ -326,11 +364,12 @@ func (t *Type) SatisfiesIface(iface *Type) bool {
 }
 
 func (t *Type) hasIfaceMethod(fn IfaceTypeFn) bool {
-	if t.Object == nil {
+	obj := t.methodsOf()
+	if obj == nil {
 		return false
 	}
 
-	proto := t.Object.GetPrototype()
+	proto := obj.GetPrototype()
 	if proto == nil {
 		return false
 	}
@@ -362,3 +401,30 @@ func (t *Type) hasIfaceMethod(fn IfaceTypeFn) bool {
 
 	return true
 }
+
+// methodsOf returns the object whose prototype holds the methods of t. The
+// types shared by every value of a kind, like TypeString, carry no object.
+// All values of such a kind have the same methods, so a zero value stands in.
+func (t *Type) methodsOf() Object {
+	if t.Object != nil {
+		return t.Object
+	}
+
+	switch t.BaseType {
+	case ObjectTypeString:
+		return NewString("", nil)
+	case ObjectTypeInt:
+		return NewInt(0, nil)
+	case ObjectTypeFloat:
+		return NewFloat(0, nil)
+	case ObjectTypeBool:
+		return NewBool(false, nil)
+	case ObjectTypeChar:
+		return NewChar(0, nil)
+	case ObjectTypeByte:
+		return NewByte(0, nil)
+	case ObjectTypeHtml:
+		return NewElement(&ElementData{}, nil)
+	}
+	return nil
+}
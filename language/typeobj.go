@@ -7,8 +7,10 @@ import (
 )
 
 type TypeObject struct {
-	Data  *Type
-	debug *debug.Debug
+	Data *Type
+	// Params names the type parameters of a generic type alias.
+	Params []string
+	debug  *debug.Debug
 }
 
 func NewTypeObject(t *Type, debug *debug.Debug) *TypeObject {
@@ -23,7 +25,7 @@ func (t *TypeObject) ID() string {
 }
 
 func (t *TypeObject) Type() *Type {
-	return withObject(t, TypeTypeObj)
+	return TypeTypeObj
 }
 
 func (t *TypeObject) Inspect() string {
@@ -51,5 +53,7 @@ func (t *TypeObject) Debug() *debug.Debug {
 }
 
 func (t *TypeObject) Clone() Object {
-	return NewTypeObject(t.Data.DeepClone(), t.debug)
+	clone := NewTypeObject(t.Data.DeepClone(), t.debug)
+	clone.Params = t.Params
+	return clone
 }
@@ -0,0 +1,808 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/internal/exception"
+	"github.com/nubolang/nubo/language"
+)
+
+// bucketKey holds the bound Go pointer inside a struct instance.
+const bucketKey = "_go"
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// binding is the Nubo definition generated for a Go struct type.
+type binding struct {
+	typ    reflect.Type
+	def    *language.Struct
+	fields []boundField
+}
+
+type boundField struct {
+	name  string
+	index []int
+}
+
+var (
+	bindingsMu sync.Mutex
+	bindings   = make(map[reflect.Type]*binding)
+
+	// buildMu serializes building bindings.
+	buildMu sync.Mutex
+)
+
+// Bind exposes a Go value to Nubo.
+//
+// Functions become typed functions: a leading context.Context parameter
+// receives the call context and a trailing error result is raised as an
+// exception. Structs, and pointers to structs, become instances of a struct
+// definition generated by BindStruct; calling a method on the instance calls
+// the Go method on the bound value. Other values are converted with
+// language.FromValue.
+//
+// Nubo values passed to Go are converted to the parameter types; numbers
+// that do not fit are rejected with an error wrapping strconv.ErrRange. A
+// Nubo function can only be passed as a Go function that returns error or
+// (T, error), which carries the exceptions it raises.
+func Bind(value any) (language.Object, error) {
+	if obj, ok := value.(language.Object); ok {
+		return obj, nil
+	}
+	return fromGo(reflect.ValueOf(value))
+}
+
+// BindStruct returns the struct definition for the Go struct type of value,
+// which may be a struct, a pointer to one or a reflect.Type.
+//
+// Exported fields become fields and exported methods of the pointer type
+// become methods, both named in lowerCamelCase. A `nubo` struct tag renames
+// a field, `nubo:"-"` hides it and `nubo:",private"` makes it private.
+func BindStruct(value any) (*language.Struct, error) {
+	t, ok := value.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(value)
+	}
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bind: expected a struct, got %v", t)
+	}
+
+	b, err := bindType(t)
+	if err != nil {
+		return nil, err
+	}
+	return b.def, nil
+}
+
+// bindType returns the binding for the struct type t, building it and the
+// struct types it refers to on first use. Bindings are cached only once
+// they are complete, so other goroutines never see a half built definition,
+// and a failed build leaves nothing behind.
+func bindType(t reflect.Type) (*binding, error) {
+	bindingsMu.Lock()
+	b, ok := bindings[t]
+	bindingsMu.Unlock()
+	if ok {
+		return b, nil
+	}
+
+	buildMu.Lock()
+	defer buildMu.Unlock()
+
+	bd := &builder{pending: make(map[reflect.Type]*binding)}
+	b, err := bd.bind(t)
+	if err != nil {
+		return nil, err
+	}
+
+	bindingsMu.Lock()
+	for pt, pb := range bd.pending {
+		bindings[pt] = pb
+	}
+	bindingsMu.Unlock()
+	return b, nil
+}
+
+// builder builds the bindings of one bindType call. A nil builder resolves
+// struct types through bindType.
+type builder struct {
+	pending map[reflect.Type]*binding
+}
+
+func (bd *builder) bind(t reflect.Type) (*binding, error) {
+	if bd == nil {
+		return bindType(t)
+	}
+
+	bindingsMu.Lock()
+	b, ok := bindings[t]
+	bindingsMu.Unlock()
+	if ok {
+		return b, nil
+	}
+	if b, ok := bd.pending[t]; ok {
+		return b, nil
+	}
+
+	// Register the definition before its fields and methods so that types
+	// referring to themselves resolve to it.
+	b = &binding{typ: t, def: language.NewStructBetter(t.Name(), nil)}
+	bd.pending[t] = b
+
+	var fields []language.StructField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+
+		name, private, skip := parseTag(f)
+		if skip {
+			continue
+		}
+
+		b.fields = append(b.fields, boundField{name: name, index: f.Index})
+		fields = append(fields, language.StructField{Name: name, Type: bd.typeOf(f.Type), Private: private})
+	}
+	b.def.DefineFieldset(fields)
+
+	ctx := context.Background()
+	proto := b.def.GetPrototype().(*language.StructPrototype)
+	proto.Unlock()
+
+	ptr := reflect.PointerTo(t)
+	for i := range ptr.NumMethod() {
+		m := ptr.Method(i)
+		if err := proto.SetObject(ctx, lowerCamel(m.Name), b.method(bd, m)); err != nil {
+			proto.Lock()
+			delete(bd.pending, t)
+			return nil, fmt.Errorf("bind: %s.%s: %w", t.Name(), m.Name, err)
+		}
+	}
+
+	proto.Lock()
+	proto.Implement()
+	return b, nil
+}
+
+// parseTag reads the `nubo` tag of a struct field.
+func parseTag(f reflect.StructField) (name string, private, skip bool) {
+	tag := f.Tag.Get("nubo")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = lowerCamel(f.Name)
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "private" {
+			private = true
+		}
+	}
+	return name, private, false
+}
+
+// lowerCamel turns a Go identifier into a Nubo one: "Name" becomes "name",
+// "ID" becomes "id" and "HTTPServer" becomes "httpServer".
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := range upper {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// instance wraps the Go pointer ptr in a new instance of the definition.
+func (b *binding) instance(ptr reflect.Value) (language.Object, error) {
+	inst, err := b.def.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+	inst.BucketSet(bucketKey, ptr)
+	if err := b.pull(inst, ptr); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+// value returns the Go pointer bound to inst. Instances created in Nubo get
+// a zero value filled from their fields on first use.
+func (b *binding) value(inst *language.StructInstance) (reflect.Value, error) {
+	if raw, ok := inst.BucketGet(bucketKey); ok && raw != nil {
+		return raw.(reflect.Value), nil
+	}
+	ptr := reflect.New(b.typ)
+	inst.BucketSet(bucketKey, ptr)
+	return ptr, nil
+}
+
+// push copies the Nubo fields of inst into the Go struct behind ptr.
+func (b *binding) push(inst *language.StructInstance, ptr reflect.Value) error {
+	ctx := language.StructAllowPrivateCtx(context.Background())
+	proto := inst.GetPrototype()
+
+	for _, f := range b.fields {
+		obj, ok := proto.GetObject(ctx, f.name)
+		if !ok {
+			continue
+		}
+		field, err := ptr.Elem().FieldByIndexErr(f.index)
+		if err != nil || !field.CanSet() {
+			continue
+		}
+		v, err := toGo(obj, field.Type())
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+		field.Set(v)
+	}
+	return nil
+}
+
+// pull copies the Go struct behind ptr into the Nubo fields of inst.
+func (b *binding) pull(inst *language.StructInstance, ptr reflect.Value) error {
+	ctx := language.StructAllowPrivateCtx(context.Background())
+	proto := inst.GetPrototype()
+
+	for _, f := range b.fields {
+		field, err := ptr.Elem().FieldByIndexErr(f.index)
+		if err != nil {
+			continue
+		}
+		obj, err := fromGo(field)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+		if err := proto.SetObject(ctx, f.name, obj); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// method binds a method of the pointer type. Fields are copied to Go before
+// the call and back afterwards, so both sides see the changes of the other.
+func (b *binding) method(bd *builder, m reflect.Method) *language.Function {
+	args, returns := bd.signature(m.Type, 1)
+	args = append([]language.FnArg{&Arg{NameValue: "self", TypeValue: b.def.Type()}}, args...)
+
+	return language.NewTypedFunction(args, returns, func(ctx context.Context, o []language.Object) (language.Object, error) {
+		inst, ok := o[0].(*language.StructInstance)
+		if !ok {
+			return nil, fmt.Errorf("bind: %s.%s called without an instance", b.typ.Name(), m.Name)
+		}
+
+		ptr, err := b.value(inst)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.push(inst, ptr); err != nil {
+			return nil, exception.From(err, inst.Debug())
+		}
+
+		dg := inst.Debug()
+		if len(o) > 1 {
+			dg = o[1].Debug()
+		}
+
+		result, err := call(ctx, m.Func, []reflect.Value{ptr}, o[1:], dg)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := b.pull(inst, ptr); err != nil {
+			return nil, exception.From(err, inst.Debug())
+		}
+		return result, nil
+	}, nil)
+}
+
+// function binds a Go function value.
+func function(fn reflect.Value) *language.Function {
+	args, returns := signature(fn.Type(), 0)
+
+	return language.NewTypedFunction(args, returns, func(ctx context.Context, o []language.Object) (language.Object, error) {
+		var dg *debug.Debug
+		if len(o) > 0 {
+			dg = o[0].Debug()
+		}
+		return call(ctx, fn, nil, o, dg)
+	}, nil)
+}
+
+// signature describes the parameters of ft from skip on, leaving out a
+// context.Context, and the Nubo return type of its results.
+func signature(ft reflect.Type, skip int) ([]language.FnArg, *language.Type) {
+	return (*builder)(nil).signature(ft, skip)
+}
+
+func (bd *builder) signature(ft reflect.Type, skip int) ([]language.FnArg, *language.Type) {
+	var args []language.FnArg
+	for i := skip; i < ft.NumIn(); i++ {
+		in := ft.In(i)
+		if i == skip && in == contextType {
+			continue
+		}
+
+		typ := bd.typeOf(in)
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			typ = language.NewListType(bd.typeOf(in.Elem()))
+		}
+		args = append(args, &Arg{NameValue: fmt.Sprintf("arg%d", len(args)), TypeValue: typ})
+	}
+
+	outs := results(ft)
+	switch len(outs) {
+	case 0:
+		return args, language.TypeVoid
+	case 1:
+		return args, bd.typeOf(outs[0])
+	default:
+		return args, language.NewListType(language.TypeAny)
+	}
+}
+
+// results returns the result types of ft without a trailing error.
+func results(ft reflect.Type) []reflect.Type {
+	outs := make([]reflect.Type, 0, ft.NumOut())
+	for i := range ft.NumOut() {
+		outs = append(outs, ft.Out(i))
+	}
+	if len(outs) > 0 && outs[len(outs)-1] == errorType {
+		outs = outs[:len(outs)-1]
+	}
+	return outs
+}
+
+// call converts args, calls fn after the already converted in values and
+// converts its results back. Several results are returned as a list.
+func call(ctx context.Context, fn reflect.Value, in []reflect.Value, args []language.Object, dg *debug.Debug) (language.Object, error) {
+	ft := fn.Type()
+	next := len(in)
+	if next < ft.NumIn() && ft.In(next) == contextType {
+		in = append(in, reflect.ValueOf(ctx))
+	}
+
+	for _, arg := range args {
+		inx := len(in)
+		if inx >= ft.NumIn() {
+			return nil, exception.From(fmt.Errorf("too many arguments"), dg)
+		}
+		v, err := toGo(arg, ft.In(inx))
+		if err != nil {
+			return nil, exception.From(fmt.Errorf("argument %d: %w", inx, err), dg)
+		}
+		in = append(in, v)
+	}
+
+	var out []reflect.Value
+	if ft.IsVariadic() {
+		out = fn.CallSlice(in)
+	} else {
+		out = fn.Call(in)
+	}
+
+	if len(out) > 0 && ft.Out(len(out)-1) == errorType {
+		if err, _ := out[len(out)-1].Interface().(error); err != nil {
+			return nil, exception.From(err, dg)
+		}
+		out = out[:len(out)-1]
+	}
+
+	switch len(out) {
+	case 0:
+		return nil, nil
+	case 1:
+		return fromGo(out[0])
+	}
+
+	items := make([]language.Object, len(out))
+	for i, v := range out {
+		obj, err := fromGo(v)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = obj
+	}
+	return language.NewList(items, language.TypeAny, dg), nil
+}
+
+// typeOf returns the Nubo type of values of the Go type t.
+func typeOf(t reflect.Type) *language.Type {
+	return (*builder)(nil).typeOf(t)
+}
+
+func (bd *builder) typeOf(t reflect.Type) *language.Type {
+	if t == timeType {
+		return language.TypeString
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return language.TypeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return language.TypeInt
+	case reflect.Float32, reflect.Float64:
+		return language.TypeFloat
+	case reflect.String:
+		return language.TypeString
+	case reflect.Slice, reflect.Array:
+		return language.NewListType(bd.typeOf(t.Elem()))
+	case reflect.Map:
+		return language.NewDictType(bd.typeOf(t.Key()), bd.typeOf(t.Elem()))
+	case reflect.Pointer:
+		if t.Elem().Kind() == reflect.Struct && t.Elem() != timeType {
+			return bd.typeOf(t.Elem())
+		}
+		return language.TypeAny
+	case reflect.Struct:
+		b, err := bd.bind(t)
+		if err != nil {
+			return language.TypeAny
+		}
+		return b.def.Type()
+	case reflect.Func:
+		args, returns := bd.signature(t, 0)
+		types := make([]*language.Type, len(args))
+		for i, arg := range args {
+			types[i] = arg.Type()
+		}
+		return language.NewFunctionType(returns, types...)
+	default:
+		return language.TypeAny
+	}
+}
+
+// fromGo converts a Go value to a Nubo object, binding structs and
+// functions on the way.
+func fromGo(v reflect.Value) (language.Object, error) {
+	if !v.IsValid() {
+		return language.Nil, nil
+	}
+	if v.CanInterface() {
+		if obj, ok := v.Interface().(language.Object); ok {
+			return obj, nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return language.Nil, nil
+		}
+		return fromGo(v.Elem())
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return language.Nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Struct && v.Type().Elem() != timeType {
+			b, err := bindType(v.Type().Elem())
+			if err != nil {
+				return nil, err
+			}
+			return b.instance(v)
+		}
+		return fromGo(v.Elem())
+
+	case reflect.Struct:
+		if v.Type() == timeType {
+			break
+		}
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return fromGo(ptr)
+
+	case reflect.Func:
+		if v.IsNil() {
+			return language.Nil, nil
+		}
+		return function(v), nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return language.NewList(nil, typeOf(v.Type().Elem()), nil), nil
+		}
+		items := make([]language.Object, v.Len())
+		for i := range v.Len() {
+			obj, err := fromGo(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = obj
+		}
+		return language.NewList(items, typeOf(v.Type().Elem()), nil), nil
+
+	case reflect.Map:
+		keys := make([]language.Object, 0, v.Len())
+		values := make([]language.Object, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := fromGo(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			value, err := fromGo(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		return language.NewDict(keys, values, typeOf(v.Type().Key()), typeOf(v.Type().Elem()), nil)
+	}
+
+	if !v.CanInterface() {
+		return nil, fmt.Errorf("bind: cannot access %s", v.Type())
+	}
+	return language.FromValue(v.Interface(), false)
+}
+
+// toGo converts obj to a Go value of type t.
+func toGo(obj language.Object, t reflect.Type) (reflect.Value, error) {
+	if obj == nil || obj.Type().Base() == language.ObjectTypeNil {
+		return reflect.Zero(t), nil
+	}
+
+	if inst, ok := obj.(*language.StructInstance); ok {
+		if raw, ok := inst.BucketGet(bucketKey); ok && raw != nil {
+			ptr := raw.(reflect.Value)
+			switch {
+			case ptr.Type().AssignableTo(t):
+				return ptr, nil
+			case ptr.Elem().Type().AssignableTo(t):
+				return ptr.Elem(), nil
+			}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if t.Elem().Kind() == reflect.Struct && t.Elem() != timeType {
+			return structToGo(obj, t.Elem())
+		}
+		v, err := toGo(obj, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		return ptr, nil
+
+	case reflect.Struct:
+		if t == timeType {
+			break
+		}
+		ptr, err := structToGo(obj, t)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return ptr.Elem(), nil
+
+	case reflect.Func:
+		fn, ok := obj.(*language.Function)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected a function, got %s", obj.Type())
+		}
+		if !returnsError(t) {
+			return reflect.Value{}, fmt.Errorf("cannot use a function as %s: it has to return error or (T, error)", t)
+		}
+		return funcToGo(fn, t), nil
+
+	case reflect.Slice:
+		if s, ok := obj.(*language.String); ok && t.Elem().Kind() == reflect.Uint8 {
+			return reflect.ValueOf([]byte(s.Data)).Convert(t), nil
+		}
+		list, ok := obj.(*language.List)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected a list, got %s", obj.Type())
+		}
+		out := reflect.MakeSlice(t, len(list.Data), len(list.Data))
+		for i, item := range list.Data {
+			v, err := toGo(item, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(v)
+		}
+		return out, nil
+
+	case reflect.Map:
+		dict, ok := obj.(*language.Dict)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected a dict, got %s", obj.Type())
+		}
+		out := reflect.MakeMapWithSize(t, dict.Data.Len())
+		err := dict.Data.IterateErr(func(key, value language.Object) error {
+			k, err := toGo(key, t.Key())
+			if err != nil {
+				return err
+			}
+			v, err := toGo(value, t.Elem())
+			if err != nil {
+				return err
+			}
+			out.SetMapIndex(k, v)
+			return nil
+		})
+		return out, err
+	}
+
+	raw, err := language.ToValue(obj)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if t == timeType {
+		if s, ok := raw.(string); ok {
+			parsed, err := time.Parse(time.RFC3339, s)
+			return reflect.ValueOf(parsed), err
+		}
+	}
+
+	v := reflect.ValueOf(raw)
+	if !v.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if v.Type().ConvertibleTo(t) && v.Kind() != reflect.String {
+		if err := checkRange(v, t); err != nil {
+			return reflect.Value{}, err
+		}
+		return v.Convert(t), nil
+	}
+	if v.Kind() == reflect.String && t.Kind() == reflect.String {
+		return v.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %s as %s", obj.Type(), t)
+}
+
+// checkRange fails if converting the number v to t would not keep its
+// value.
+func checkRange(v reflect.Value, t reflect.Type) error {
+	zero := reflect.Zero(t)
+	overflow := false
+
+	switch {
+	case v.CanInt() && zero.CanInt():
+		overflow = zero.OverflowInt(v.Int())
+	case v.CanInt() && zero.CanUint():
+		overflow = v.Int() < 0 || zero.OverflowUint(uint64(v.Int()))
+	case v.CanFloat() && zero.CanFloat():
+		overflow = zero.OverflowFloat(v.Float())
+	}
+
+	if overflow {
+		return fmt.Errorf("%v does not fit in %s: %w", v, t, strconv.ErrRange)
+	}
+	return nil
+}
+
+// structToGo returns a pointer to a new t filled from a bound instance or
+// a dict.
+func structToGo(obj language.Object, t reflect.Type) (reflect.Value, error) {
+	b, err := bindType(t)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	ptr := reflect.New(t)
+	switch v := obj.(type) {
+	case *language.StructInstance:
+		if !b.def.Type().Compare(v.Type()) {
+			return reflect.Value{}, fmt.Errorf("cannot use %s as %s", obj.Type(), t)
+		}
+		if err := b.push(v, ptr); err != nil {
+			return reflect.Value{}, err
+		}
+		v.BucketSet(bucketKey, ptr)
+		return ptr, nil
+
+	case *language.Dict:
+		values := make(map[string]language.Object, v.Data.Len())
+		v.Data.Iterate(func(key, value language.Object) bool {
+			values[key.String()] = value
+			return true
+		})
+		for _, f := range b.fields {
+			value, ok := values[f.name]
+			if !ok {
+				continue
+			}
+			field, err := ptr.Elem().FieldByIndexErr(f.index)
+			if err != nil || !field.CanSet() {
+				continue
+			}
+			gv, err := toGo(value, field.Type())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %w", f.name, err)
+			}
+			field.Set(gv)
+		}
+		return ptr, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot use %s as %s", obj.Type(), t)
+}
+
+// returnsError reports whether t returns error or (T, error), the Go
+// function types a Nubo function can be converted to.
+func returnsError(t reflect.Type) bool {
+	switch t.NumOut() {
+	case 1:
+		return t.Out(0) == errorType
+	case 2:
+		return t.Out(1) == errorType
+	}
+	return false
+}
+
+// funcToGo wraps a Nubo function into a Go function of type t, which has to
+// satisfy returnsError.
+func funcToGo(fn *language.Function, t reflect.Type) reflect.Value {
+	return reflect.MakeFunc(t, func(in []reflect.Value) []reflect.Value {
+		ctx := context.Background()
+		args := make([]language.Object, 0, len(in))
+		for i, v := range in {
+			if i == 0 && t.In(0) == contextType {
+				ctx = v.Interface().(context.Context)
+				continue
+			}
+			obj, err := fromGo(v)
+			if err != nil {
+				return funcResults(t, nil, err)
+			}
+			args = append(args, obj)
+		}
+
+		result, err := fn.Data(ctx, args)
+		return funcResults(t, result, err)
+	})
+}
+
+// funcResults builds the results of a Go function of type t from the result
+// of a Nubo call. Exceptions and results that do not convert are returned as
+// the error result.
+func funcResults(t reflect.Type, result language.Object, err error) []reflect.Value {
+	out := make([]reflect.Value, t.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(t.Out(i))
+	}
+
+	if err == nil && len(out) > 1 {
+		var v reflect.Value
+		if v, err = toGo(result, t.Out(0)); err == nil {
+			out[0] = v
+		}
+	}
+
+	if err != nil {
+		out[len(out)-1] = reflect.ValueOf(&err).Elem()
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+package native_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counter struct {
+	Count  int
+	Label  string `nubo:"name"`
+	Secret string `nubo:"-"`
+}
+
+func (c *counter) Add(n int) int {
+	c.Count += n
+	return c.Count
+}
+
+func (c *counter) Fail(ctx context.Context, msg string) error {
+	if ctx == nil {
+		return errors.New("no context")
+	}
+	return errors.New(msg)
+}
+
+func bind(t *testing.T, value any) language.Object {
+	t.Helper()
+
+	obj, err := native.Bind(value)
+	require.NoError(t, err)
+	return obj
+}
+
+func TestBindStruct(t *testing.T) {
+	c := &counter{Count: 1, Label: "clicks", Secret: "s"}
+
+	results := nubotest.Strings(t, `
+		const added = c.add(2)
+		c.count = c.count + 10
+
+		catch e {
+			c.fail("broken")
+		}
+		return [added, c.add(0), c.name, e.message]
+	`, map[string]any{"c": bind(t, c)})
+	assert.Equal(t, []string{"3", "13", "clicks", "broken"}, results)
+	assert.Equal(t, 13, c.Count, "the Go value sees changes made in Nubo")
+
+	_, err := nubotest.Exec(`return c.secret`, map[string]any{"c": bind(t, c)})
+	assert.ErrorContains(t, err, "undefined variable 'c.secret'")
+}
+
+func TestBindFunction(t *testing.T) {
+	globals := map[string]any{
+		"split": bind(t, func(s string) (string, string, error) {
+			if len(s) < 2 {
+				return "", "", errors.New("too short")
+			}
+			return s[:1], s[1:], nil
+		}),
+		"byte":  bind(t, func(b uint8) uint8 { return b }),
+		"small": bind(t, func(n int8) int8 { return n }),
+		"apply": bind(t, func(fn func(int) (int, error), x int) (int, error) {
+			return fn(x)
+		}),
+		"each": bind(t, func(fn func(int) int) int { return fn(1) }),
+		"down": bind(t, func() error { return errors.New("service down") }),
+	}
+
+	results := nubotest.Strings(t, `
+		const parts = split("abc")
+		catch e {
+			split("a")
+		}
+		// Functions without arguments raise exceptions without a location.
+		catch d {
+			down()
+		}
+		return [parts[0], parts[1], e.message, d.message, byte(255), small(-128), apply(fn(x: int) int { return x * 2 }, 4)]
+	`, globals)
+	assert.Equal(t, []string{"a", "bc", "too short", "service down", "255", "-128", "8"}, results)
+
+	_, err := nubotest.Exec(`byte(256)`, globals)
+	assert.ErrorContains(t, err, "256 does not fit in uint8")
+	assert.ErrorContains(t, err, strconv.ErrRange.Error())
+
+	_, err = nubotest.Exec(`byte(-1)`, globals)
+	assert.ErrorContains(t, err, "does not fit in uint8")
+
+	_, err = nubotest.Exec(`small(128)`, globals)
+	assert.ErrorContains(t, err, "does not fit in int8")
+
+	// The exception of a Nubo function reaches Go as its error result.
+	results = nubotest.Strings(t, `
+		catch e {
+			apply(fn(x: int) int {
+				panic("bad " + string(x))
+			}, 4)
+		}
+		return [e.message]
+	`, globals)
+	assert.Equal(t, []string{"bad 4"}, results)
+
+	// Without an error result the exception would have nowhere to go.
+	_, err = nubotest.Exec(`each(fn(x: int) int { return x })`, globals)
+	assert.ErrorContains(t, err, "has to return error or (T, error)")
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestBindStructConcurrent(t *testing.T) {
+	const n = 16
+
+	defs := make([]*language.Struct, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			def, err := native.BindStruct(&node{})
+			assert.NoError(t, err)
+			defs[i] = def
+		}()
+	}
+	wg.Wait()
+
+	for _, def := range defs {
+		assert.Same(t, defs[0], def)
+	}
+
+	results := nubotest.Strings(t, `
+		return [list.value, list.next.value, isNil(list.next.next)]
+	`, map[string]any{"list": bind(t, &node{Value: 1, Next: &node{Value: 2}})})
+	assert.Equal(t, []string{"1", "2", "true"}, results)
+}
@@ -11,6 +11,7 @@ import (
 type FnCtx struct {
 	typedArgs    []language.FnArg
 	providedArgs []language.Object
+	ctx          context.Context
 }
 
 type FunctionWrapper func(ctx FnCtx) (language.Object, error)
@@ -28,6 +29,11 @@ func (ctx FnCtx) All() []language.Object {
 	return ctx.providedArgs
 }
 
+// Context returns the context the function was called with.
+func (ctx FnCtx) Context() context.Context {
+	return ctx.ctx
+}
+
 func NewFunction(fn func(args []language.Object) (language.Object, error)) *language.Function {
 	return language.NewFunction(func(ctx context.Context, o []language.Object) (language.Object, error) {
 		return fn(o)
@@ -39,6 +45,7 @@ func NewTypedFunction(ctx context.Context, typedArgs []language.FnArg, returnTyp
 		fnCtx := FnCtx{
 			typedArgs:    typedArgs,
 			providedArgs: args,
+			ctx:          ctx,
 		}
 
 		return fn(fnCtx)
@@ -0,0 +1,34 @@
+package n
+
+import (
+	"github.com/nubolang/nubo/internal/debug"
+	"github.com/nubolang/nubo/language"
+)
+
+// ToBytes returns the bytes of a string or a []byte list
+func ToBytes(value language.Object) []byte {
+	if value.Type() == language.TypeString {
+		return []byte(value.String())
+	}
+
+	listValues := value.Value().([]language.Object)
+	bytes := make([]byte, len(listValues))
+	for i, b := range listValues {
+		bytes[i] = b.(*language.Byte).Data
+	}
+	return bytes
+}
+
+// Bytes creates a []byte language.List
+func Bytes(data []byte, dg ...*debug.Debug) *language.List {
+	var d *debug.Debug
+	if len(dg) > 0 {
+		d = dg[0]
+	}
+
+	items := make([]language.Object, len(data))
+	for i, b := range data {
+		items[i] = Byte(b, d)
+	}
+	return language.NewList(items, TByte, d)
+}
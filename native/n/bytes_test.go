@@ -0,0 +1,15 @@
+package n_test
+
+import (
+	"testing"
+
+	"github.com/nubolang/nubo/native/n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	list := n.Bytes([]byte("hi"))
+	assert.Equal(t, "[]byte", list.Type().String())
+	assert.Equal(t, []byte("hi"), n.ToBytes(list))
+	assert.Equal(t, []byte("hi"), n.ToBytes(n.String("hi")))
+}
@@ -14,9 +14,10 @@ type FnDescriber struct {
 }
 
 type FnArg struct {
-	Type    *language.Type
-	Name    string
-	Default language.Object
+	Type     *language.Type
+	Name     string
+	Default  language.Object
+	Variadic bool
 }
 
 func Arg(name string, typ *language.Type, def ...language.Object) *FnArg {
@@ -32,6 +33,16 @@ func Arg(name string, typ *language.Type, def ...language.Object) *FnArg {
 	return arg
 }
 
+// Variadic describes a last argument collecting the remaining values of
+// type elementType into a list.
+func Variadic(name string, elementType *language.Type) *FnArg {
+	return &FnArg{
+		Type:     TTList(elementType),
+		Name:     name,
+		Variadic: true,
+	}
+}
+
 func Describe(args ...*FnArg) *FnDescriber {
 	return &FnDescriber{
 		args:    args,
@@ -83,7 +94,7 @@ func (a *Args) Name(name string) language.Object {
 func Function(describe *FnDescriber, fn func(*Args) (any, error)) *language.Function {
 	var args = make([]language.FnArg, len(describe.args))
 	for i, arg := range describe.args {
-		args[i] = &language.BasicFnArg{TypeVal: arg.Type, NameVal: arg.Name, DefaultVal: arg.Default}
+		args[i] = &language.BasicFnArg{TypeVal: arg.Type, NameVal: arg.Name, DefaultVal: arg.Default, VariadicVal: arg.Variadic}
 	}
 
 	return language.NewTypedFunction(args, describe.returns, func(ctx context.Context, o []language.Object) (language.Object, error) {
@@ -101,7 +112,9 @@ func Function(describe *FnDescriber, fn func(*Args) (any, error)) *language.Func
 
 		value, err := fn(userArgs)
 		if err != nil {
-			return nil, exception.From(err, dg)
+			// The caller locates the exception; dg points at where the
+			// first argument was created, which is not where it failed.
+			return nil, exception.From(err, nil)
 		}
 
 		return language.FromValue(value, true, dg)
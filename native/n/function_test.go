@@ -0,0 +1,36 @@
+package n_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nubolang/nubo/internal/nubotest"
+	"github.com/nubolang/nubo/native/n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionErrorLocation(t *testing.T) {
+	fail := n.Function(n.Describe(n.Arg("value", n.TAny)), func(a *n.Args) (any, error) {
+		return nil, errors.New("failed")
+	})
+	globals := map[string]any{"fail": fail}
+
+	// Errors are located at the call, not where the first argument was
+	// created.
+	_, err := nubotest.Exec(`
+		const value = "x"
+
+		fail(value)
+	`, globals)
+	assert.ErrorContains(t, err, "failed at <nativeExecute>:4:")
+
+	results := nubotest.Strings(t, `
+		const value = "x"
+
+		catch e {
+			fail(value)
+		}
+		return [e.metaData["line"]]
+	`, globals)
+	assert.Equal(t, []string{"5"}, results)
+}
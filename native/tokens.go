@@ -2,6 +2,7 @@ package native
 
 import (
 	"context"
+	"io"
 	"os"
 	"time"
 
@@ -17,7 +18,13 @@ func NodesFromFile(path, lxPath string) ([]*astnode.Node, error) {
 	}
 	defer file.Close()
 
-	lx, err := lexer.New(file, lxPath)
+	return NodesFromReader(file, lxPath)
+}
+
+// NodesFromReader parses Nubo source read from r. lxPath is the file name
+// used in debug information.
+func NodesFromReader(r io.Reader, lxPath string) ([]*astnode.Node, error) {
+	lx, err := lexer.New(r, lxPath)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package nubo
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 	"time"
@@ -12,27 +13,49 @@ import (
 	"github.com/nubolang/nubo/internal/lexer"
 	"github.com/nubolang/nubo/internal/runtime"
 	"github.com/nubolang/nubo/language"
+	"github.com/nubolang/nubo/native/n"
 	"github.com/nubolang/nubo/version"
 )
 
 const Version = version.Version
 
+// nativeFile is the file name used for code executed through a Ctx.
+const nativeFile = "<nativeExecute>"
+
 type Ctx struct {
 	r *runtime.Runtime
 }
 
+// Options configures what scripts of a Ctx may do: importable std modules,
+// file roots, network and process access and resource limits.
+type Options = runtime.Options
+
+// Limits bounds the resources scripts of a Ctx may use.
+type Limits = runtime.Limits
+
+// DefaultOptions returns the trusted options used by New.
+func DefaultOptions() Options {
+	return runtime.DefaultOptions()
+}
+
 func New() *Ctx {
 	return NewWithProvider(events.NewDefaultProvider())
 }
 
 func NewWithProvider(provider events.Provider) *Ctx {
+	return NewWithOptions(provider, DefaultOptions())
+}
+
+// NewWithOptions creates a Ctx whose scripts are restricted by opts, e.g. to
+// run untrusted code next to trusted code.
+func NewWithOptions(provider events.Provider, opts Options) *Ctx {
 	return &Ctx{
-		r: runtime.New(provider),
+		r: runtime.New(provider, opts),
 	}
 }
 
 func (c *Ctx) Tokenize(r io.Reader) ([]*lexer.Token, error) {
-	lx, err := lexer.New(r, "<nativeExecute>")
+	lx, err := lexer.New(r, nativeFile)
 	if err != nil {
 		return nil, err
 	}
@@ -59,9 +82,95 @@ func (c *Ctx) Exec(r io.Reader) (language.Object, error) {
 		return nil, err
 	}
 
-	return c.r.Interpret("<nativeExecute>", nodes)
+	return c.r.Interpret(nativeFile, nodes)
 }
 
 func (c *Ctx) ExecString(s string) (language.Object, error) {
 	return c.Exec(strings.NewReader(s))
 }
+
+// Set defines a global visible to all code executed by c. The value is
+// converted with language.FromValue; pass a language.Object (e.g. one built
+// with n.Function) to expose functions.
+func (c *Ctx) Set(name string, value any) error {
+	obj, err := language.FromValue(value, false)
+	if err != nil {
+		return fmt.Errorf("nubo: set %s: %w", name, err)
+	}
+	c.r.SetGlobal(name, obj)
+	return nil
+}
+
+// ProvidePackage registers a package importable under name, e.g.
+// `import tools from "@app/tools"`. Members are converted like in Set.
+func (c *Ctx) ProvidePackage(name string, members Map) error {
+	pkg := n.NewPackage(name, nil)
+	proto := pkg.GetPrototype()
+	for key, value := range members {
+		obj, err := language.FromValue(value, false)
+		if err != nil {
+			return fmt.Errorf("nubo: package %s: member %s: %w", name, key, err)
+		}
+		if err := proto.SetObject(context.Background(), key, obj); err != nil {
+			return fmt.Errorf("nubo: package %s: member %s: %w", name, key, err)
+		}
+	}
+	c.r.ProvidePackage(name, pkg)
+	return nil
+}
+
+// Lookup returns a top-level declaration of the last executed code.
+func (c *Ctx) Lookup(name string) (language.Object, bool) {
+	return c.r.Lookup(nativeFile, name)
+}
+
+// Get returns a top-level declaration of the last executed code as a Go
+// value.
+func (c *Ctx) Get(name string) (any, error) {
+	obj, ok := c.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("nubo: %s is not defined", name)
+	}
+	return language.ToValue(obj)
+}
+
+// Call calls the Nubo function name declared by the last executed code.
+// Arguments are converted with language.FromValue and the result with
+// language.ToValue. The call runs under the options of c, like code run
+// with Exec.
+func (c *Ctx) Call(name string, args ...any) (any, error) {
+	return c.CallContext(c.r.Context(), name, args...)
+}
+
+// CallContext is like Call, but the call stops once ctx is done.
+func (c *Ctx) CallContext(ctx context.Context, name string, args ...any) (any, error) {
+	obj, ok := c.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("nubo: %s is not defined", name)
+	}
+	fn, ok := obj.(*language.Function)
+	if !ok {
+		return nil, fmt.Errorf("nubo: %s is not a function, got %s", name, obj.Type())
+	}
+
+	params := make([]language.Object, len(args))
+	for i, arg := range args {
+		param, err := language.FromValue(arg, false)
+		if err != nil {
+			return nil, fmt.Errorf("nubo: call %s: argument %d: %w", name, i, err)
+		}
+		params[i] = param
+	}
+
+	ctx, cancel := c.r.CallContext(ctx)
+	defer cancel()
+
+	result, err := fn.Data(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return language.ToValue(result)
+}
@@ -1,8 +1,13 @@
 package nubo
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -29,3 +34,155 @@ func Test_Html(t *testing.T) {
 	assert.NoError(t, err, "Execute error should be nil")
 	fmt.Println(obj.Value())
 }
+
+func Test_Embed(t *testing.T) {
+	inst := New()
+	assert.NoError(t, inst.Set("name", "World"))
+	assert.NoError(t, inst.ProvidePackage("@app/tools", Map{"prefix": "Hello"}))
+
+	_, err := inst.ExecString(`
+		import tools from "@app/tools"
+
+		fn greet(x: string) string {
+			return tools.prefix + ", " + x
+		}
+
+		let greeting = greet(name)
+	`)
+	assert.NoError(t, err, "Execute error should be nil")
+
+	value, err := inst.Get("greeting")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World", value)
+
+	value, err = inst.Call("greet", "Go")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Go", value)
+}
+
+func Test_CallContext(t *testing.T) {
+	inst := NewWithOptions(nil, Options{Limits: Limits{Timeout: 100 * time.Millisecond}})
+	_, err := inst.ExecString(`
+		fn spin() {
+			while true {
+				sleep(1)
+			}
+		}
+	`)
+	assert.NoError(t, err)
+
+	// The call is bounded by the timeout of the options.
+	_, err = inst.Call("spin")
+	assert.ErrorContains(t, err, "deadline exceeded")
+
+	// and by the context of the caller.
+	inst = New()
+	_, err = inst.ExecString(`
+		fn spin() {
+			while true {
+				sleep(1)
+			}
+		}
+	`)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = inst.CallContext(ctx, "spin")
+	assert.ErrorContains(t, err, "deadline exceeded")
+}
+
+func Test_Sandbox(t *testing.T) {
+	inst := NewWithOptions(nil, Options{
+		AllowStd: []string{"os"},
+		Roots:    []string{t.TempDir()},
+		Limits:   Limits{MaxCallDepth: 8},
+	})
+
+	_, err := inst.ExecString(`import json from "@std/json"`)
+	assert.Error(t, err, "json should not be importable")
+
+	_, err = inst.ExecString(`
+		import os from "@std/os"
+		os.exists("/etc/passwd")
+	`)
+	assert.Error(t, err, "paths outside of the roots should be denied")
+
+	_, err = inst.ExecString(`
+		fn loop(x: int) int {
+			return loop(x + 1)
+		}
+		loop(0)
+	`)
+	assert.Error(t, err, "call depth should be limited")
+
+	_, err = inst.ExecString(`env("HOME")`)
+	assert.ErrorContains(t, err, "permission denied", "the environment needs process access")
+
+	_, err = inst.ExecString(`exit(3)`)
+	assert.ErrorContains(t, err, "permission denied", "exiting needs process access")
+
+	// Functions called from Go run under the same policy.
+	_, err = inst.ExecString(`
+		import os from "@std/os"
+
+		fn exists(path: string) bool {
+			return os.exists(path)
+		}
+	`)
+	assert.NoError(t, err)
+	_, err = inst.Call("exists", "/etc/passwd")
+	assert.ErrorContains(t, err, "permission denied", "paths outside of the roots should be denied in calls")
+}
+
+func Test_Handler(t *testing.T) {
+	app := fstest.MapFS{
+		"index.nubo": {Data: []byte(`
+			import request from "@server/request"
+			import response from "@server/response"
+			import greet from "./lib/greet"
+
+			response.write(greet.hello(request.value("user").name))
+			return 42
+		`)},
+		"lib/greet.nubo": {Data: []byte(`
+			fn hello(name: string) string {
+				return "Hello, " + name
+			}
+		`)},
+		"style.css": {Data: []byte("body {}")},
+	}
+
+	handler, err := Handler(app, HandlerOptions{Prefix: "/app"})
+	assert.NoError(t, err)
+
+	type user struct{ Name string }
+	req := WithValue(httptest.NewRequest(http.MethodGet, "/app/", nil), "user", &user{Name: "Go"})
+	req, result := CaptureResult(req)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "Hello, Go", rec.Body.String())
+	value, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), value)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/app/style.css", nil))
+	assert.Equal(t, "body {}", rec.Body.String())
+}
+
+func Test_SetMembers(t *testing.T) {
+	inst := New()
+	assert.NoError(t, inst.Set("config", map[string]any{"name": "app", "port": 80}))
+
+	// Members of globals can be read and assigned like those of variables.
+	obj, err := inst.ExecString(`
+		config["port"] = config["port"] + 1
+		config.name = config.name + "!"
+		return config.name + ":" + string(config["port"])
+	`)
+	assert.NoError(t, err)
+	assert.Equal(t, "app!:81", obj.Value())
+}
@@ -16,7 +16,34 @@ func BaseDir() (string, error) {
 	return makeDir(filepath.Join(base, "nubo"))
 }
 
+// vendor is set by UseVendor for apps that ship their packages.
+var vendor struct {
+	root     string
+	packages string
+}
+
+// UseVendor makes the packer read the package and lock files from root and
+// resolve packages from packages instead of the user cache. Bundled
+// executables use it to run from their extracted payload.
+func UseVendor(root, packages string) {
+	vendor.root = root
+	vendor.packages = packages
+}
+
+// DefaultRoot returns the directory holding the package and lock files of
+// the running app.
+func DefaultRoot() string {
+	if vendor.root != "" {
+		return vendor.root
+	}
+	return "."
+}
+
 func PackageDir() (string, error) {
+	if vendor.packages != "" {
+		return vendor.packages, nil
+	}
+
 	base, err := BaseDir()
 	if err != nil {
 		zap.L().Error("packer.packageDir.base", zap.Error(err))
@@ -0,0 +1,302 @@
+package plug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// CallbackMethod is sent by a plugin to invoke a host callback.
+	CallbackMethod = "__callback__"
+	// PublishMethod is sent by a plugin to publish to a host event topic.
+	PublishMethod = "__publish__"
+)
+
+// Callback is a handle to a host function passed to a plugin as an argument.
+// It is only valid until the call that passed it returns.
+type Callback struct {
+	ID uint32 `codec:"$callback"`
+}
+
+// HostFunc handles a callback invocation. params holds the msgpack-encoded
+// argument list; the returned value is encoded as the result.
+type HostFunc func(ctx context.Context, params []byte) (any, error)
+
+// PublishFunc receives an event published by a plugin. args holds the
+// msgpack-encoded argument list.
+type PublishFunc func(topic string, args []byte) error
+
+type callbackRequest struct {
+	ID   uint32 `codec:"id"`
+	Args []byte `codec:"args"`
+}
+
+type publishRequest struct {
+	Topic string `codec:"topic"`
+	Args  []byte `codec:"args"`
+}
+
+// hostHandlers holds what a Plugin exposes to its process.
+type hostHandlers struct {
+	mu          sync.RWMutex
+	callbacks   map[uint32]HostFunc
+	subscribers map[uint32]PublishFunc
+	seq         uint32
+}
+
+// RegisterCallback makes fn callable by the plugin through the returned
+// handle. Call release once the plugin must no longer invoke it.
+func (p *Plugin) RegisterCallback(fn HostFunc) (cb Callback, release func()) {
+	h := &p.host
+	id := atomic.AddUint32(&h.seq, 1)
+
+	h.mu.Lock()
+	if h.callbacks == nil {
+		h.callbacks = make(map[uint32]HostFunc)
+	}
+	h.callbacks[id] = fn
+	h.mu.Unlock()
+
+	return Callback{ID: id}, func() {
+		h.mu.Lock()
+		delete(h.callbacks, id)
+		h.mu.Unlock()
+	}
+}
+
+// Subscribe registers fn for every event the plugin publishes and returns a
+// function that removes it again. A publish only fails for the plugin if
+// every subscriber returned an error.
+func (p *Plugin) Subscribe(fn PublishFunc) (unsubscribe func()) {
+	h := &p.host
+	id := atomic.AddUint32(&h.seq, 1)
+
+	h.mu.Lock()
+	if h.subscribers == nil {
+		h.subscribers = make(map[uint32]PublishFunc)
+	}
+	h.subscribers[id] = fn
+	h.mu.Unlock()
+
+	return func() {
+		h.mu.Lock()
+		delete(h.subscribers, id)
+		h.mu.Unlock()
+	}
+}
+
+// serveHost answers a request frame the plugin sent to the host.
+func (p *Plugin) serveHost(s *session, f *frame) {
+	result, err := p.handleHost(f)
+
+	resp := &frame{ID: f.ID}
+	if err != nil {
+		resp.Err = err.Error()
+	} else if resp.Result, err = Marshal(result); err != nil {
+		resp.Err = err.Error()
+	}
+
+	if err := s.fw.write(resp); err != nil {
+		log.Printf("plug[%s]: reply to %s: %v", p.DisplayName, f.Method, err)
+	}
+}
+
+func (p *Plugin) handleHost(f *frame) (any, error) {
+	h := &p.host
+
+	switch f.Method {
+	case CallbackMethod:
+		var req callbackRequest
+		if err := Unmarshal(f.Params, &req); err != nil {
+			return nil, err
+		}
+
+		h.mu.RLock()
+		fn, ok := h.callbacks[req.ID]
+		h.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("callback %d is not registered (it is only valid during the call that passed it)", req.ID)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.Timeout())
+		defer cancel()
+		return fn(ctx, req.Args)
+
+	case PublishMethod:
+		var req publishRequest
+		if err := Unmarshal(f.Params, &req); err != nil {
+			return nil, err
+		}
+
+		h.mu.RLock()
+		subs := make([]PublishFunc, 0, len(h.subscribers))
+		for _, fn := range h.subscribers {
+			subs = append(subs, fn)
+		}
+		h.mu.RUnlock()
+
+		if len(subs) == 0 {
+			return nil, fmt.Errorf("no host subscribed to events of this plugin")
+		}
+
+		// One failing subscriber, e.g. of a finished request, must not keep
+		// the event from the others.
+		var errs []error
+		for _, fn := range subs {
+			if err := fn(req.Topic, req.Args); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) == len(subs) {
+			return nil, errors.Join(errs...)
+		}
+		for _, err := range errs {
+			log.Printf("plug[%s]: publish %s: %v", p.DisplayName, req.Topic, err)
+		}
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unknown host method: %s", f.Method)
+}
+
+// peer is one host connection as seen from the plugin. It tracks requests
+// the plugin sends to the host.
+type peer struct {
+	fw *frameWriter
+
+	mu      sync.Mutex
+	pending map[uint32]chan *frame
+	calls   map[uint32]*Ctx // running handlers by request ID
+	seq     uint32
+}
+
+func newPeer(fw *frameWriter) *peer {
+	return &peer{
+		fw:      fw,
+		pending: make(map[uint32]chan *frame),
+		calls:   make(map[uint32]*Ctx),
+	}
+}
+
+// call sends a request to the host and waits for the response.
+func (pr *peer) call(ctx context.Context, method string, params any) ([]byte, error) {
+	data, err := Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	id := atomic.AddUint32(&pr.seq, 1)
+	ch := make(chan *frame, 1)
+
+	pr.mu.Lock()
+	pr.pending[id] = ch
+	pr.mu.Unlock()
+
+	if err := pr.fw.write(&frame{ID: id, Method: method, Params: data}); err != nil {
+		pr.mu.Lock()
+		delete(pr.pending, id)
+		pr.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		pr.mu.Lock()
+		delete(pr.pending, id)
+		pr.mu.Unlock()
+		return nil, ctx.Err()
+	case resp := <-ch:
+		if resp.Err != "" {
+			return nil, fmt.Errorf("plug: host: %s", resp.Err)
+		}
+		return resp.Result, nil
+	}
+}
+
+// resolve delivers a response frame from the host to its waiting caller.
+func (pr *peer) resolve(f *frame) {
+	pr.mu.Lock()
+	ch, ok := pr.pending[f.ID]
+	if ok {
+		delete(pr.pending, f.ID)
+	}
+	pr.mu.Unlock()
+
+	if ok {
+		ch <- f
+	}
+}
+
+// close fails every request still waiting for the host and cancels the
+// handlers it is running.
+func (pr *peer) close() {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	for id, ch := range pr.pending {
+		ch <- &frame{ID: id, Err: "host disconnected"}
+		delete(pr.pending, id)
+	}
+	for _, c := range pr.calls {
+		c.cancel()
+	}
+}
+
+func (pr *peer) publish(ctx context.Context, topic string, args []any) error {
+	data, err := Marshal(args)
+	if err != nil {
+		return err
+	}
+	_, err = pr.call(ctx, PublishMethod, publishRequest{Topic: topic, Args: data})
+	return err
+}
+
+// Invoke calls a host callback received as an argument and returns the raw
+// msgpack result. Use Unmarshal or InvokeInto to decode it.
+func (c *Ctx) Invoke(cb Callback, args ...any) ([]byte, error) {
+	data, err := Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	return c.peer.call(c, CallbackMethod, callbackRequest{ID: cb.ID, Args: data})
+}
+
+// InvokeInto calls a host callback and decodes its result into v.
+func (c *Ctx) InvokeInto(v any, cb Callback, args ...any) error {
+	raw, err := c.Invoke(cb, args...)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(raw, v)
+}
+
+// Publish publishes args to the Nubo event called topic on the host that
+// sent this call. It may be used after the handler returned.
+func (c *Ctx) Publish(topic string, args ...any) error {
+	return c.peer.publish(context.WithoutCancel(c), topic, args)
+}
+
+// Publish publishes args to the Nubo event called topic on every connected
+// host. It can be used outside of any handler, e.g. from a background
+// goroutine started in main.
+func (a *App) Publish(topic string, args ...any) error {
+	a.mu.RLock()
+	peers := make([]*peer, 0, len(a.peers))
+	for pr := range a.peers {
+		peers = append(peers, pr)
+	}
+	a.mu.RUnlock()
+
+	if len(peers) == 0 {
+		return fmt.Errorf("plug: no host connected")
+	}
+	for _, pr := range peers {
+		if err := pr.publish(context.Background(), topic, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package plug
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallback(t *testing.T) {
+	_, p := loadTestPlugin(t, "")
+
+	cb, release := p.RegisterCallback(func(ctx context.Context, params []byte) (any, error) {
+		var args []string
+		if err := Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+		return strings.Join(args, "+"), nil
+	})
+
+	var out string
+	require.NoError(t, p.CallInto(context.Background(), "invoke", cb, &out))
+	assert.Equal(t, "x+y", out)
+
+	release()
+	_, err := p.Call(context.Background(), "invoke", cb)
+	assert.ErrorContains(t, err, "is not registered")
+}
+
+func TestPublish(t *testing.T) {
+	_, p := loadTestPlugin(t, "")
+
+	_, err := p.Call(context.Background(), "publish", "ready")
+	assert.ErrorContains(t, err, "no host subscribed to events of this plugin")
+
+	var topics []string
+	unsubscribe := p.Subscribe(func(topic string, args []byte) error {
+		topics = append(topics, topic)
+		return nil
+	})
+	unsubscribeFailing := p.Subscribe(func(topic string, args []byte) error {
+		return errors.New("provider closed")
+	})
+
+	// A failing subscriber does not keep the event from the others.
+	_, err = p.Call(context.Background(), "publish", "ready")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ready"}, topics)
+
+	unsubscribe()
+	_, err = p.Call(context.Background(), "publish", "ready")
+	assert.ErrorContains(t, err, "provider closed")
+	assert.Equal(t, []string{"ready"}, topics)
+
+	unsubscribeFailing()
+	_, err = p.Call(context.Background(), "publish", "ready")
+	assert.ErrorContains(t, err, "no host subscribed to events of this plugin")
+}
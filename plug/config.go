@@ -1,5 +1,7 @@
 package plug
 
+import "time"
+
 // PlugConfig maps _plug.yaml.
 type PlugConfig struct {
 	Plugin PluginMeta `yaml:"plugin"`
@@ -13,6 +15,11 @@ type PluginMeta struct {
 	Binary       string          `yaml:"binary"`
 	Architecture []string        `yaml:"architecture"`
 	Transport    TransportConfig `yaml:"transport"`
+
+	// Timeout bounds a single call from Nubo code, e.g. "10s".
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	Restart RestartConfig `yaml:"restart,omitempty"`
+	Health  HealthConfig  `yaml:"health,omitempty"`
 }
 
 // TransportConfig describes the communication mode between host and plugin.
@@ -20,3 +27,79 @@ type TransportConfig struct {
 	// Mode is "stdio" or "tcp".
 	Mode string `yaml:"mode"`
 }
+
+// RestartConfig controls how the supervisor restarts a crashed plugin.
+type RestartConfig struct {
+	// Limit is the number of consecutive restarts before the plugin is given
+	// up on. Zero uses the default, a negative value disables restarts.
+	Limit int `yaml:"limit,omitempty"`
+	// Backoff is the delay before the first restart. It doubles after every
+	// failed attempt up to MaxBackoff.
+	Backoff    time.Duration `yaml:"backoff,omitempty"`
+	MaxBackoff time.Duration `yaml:"maxBackoff,omitempty"`
+}
+
+// HealthConfig controls the periodic ping health check.
+type HealthConfig struct {
+	// Interval between pings. Zero uses the default, a negative value
+	// disables health checks.
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// Timeout after which an unanswered ping marks the plugin unhealthy.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+const (
+	defaultCallTimeout    = 10 * time.Second
+	defaultRestartLimit   = 5
+	defaultRestartBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 5 * time.Second
+)
+
+// callTimeout returns the configured call timeout or the default.
+func (m PluginMeta) callTimeout() time.Duration {
+	if m.Timeout > 0 {
+		return m.Timeout
+	}
+	return defaultCallTimeout
+}
+
+// restartLimit returns the configured restart limit or the default.
+func (r RestartConfig) restartLimit() int {
+	if r.Limit == 0 {
+		return defaultRestartLimit
+	}
+	return r.Limit
+}
+
+// backoff returns the delay before restart attempt n (starting at 0).
+func (r RestartConfig) backoff(n int) time.Duration {
+	delay, max := r.Backoff, r.MaxBackoff
+	if delay <= 0 {
+		delay = defaultRestartBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+	for ; n > 0 && delay < max; n-- {
+		delay *= 2
+	}
+	return min(delay, max)
+}
+
+// interval returns the configured health check interval or the default.
+func (h HealthConfig) interval() time.Duration {
+	if h.Interval == 0 {
+		return defaultHealthInterval
+	}
+	return h.Interval
+}
+
+// timeout returns the configured ping timeout or the default.
+func (h HealthConfig) timeout() time.Duration {
+	if h.Timeout > 0 {
+		return h.Timeout
+	}
+	return defaultHealthTimeout
+}
@@ -0,0 +1,100 @@
+package plug
+
+import (
+	"context"
+	"fmt"
+)
+
+// DescribeMethod is the built-in RPC every App answers with its Manifest.
+const DescribeMethod = "__describe__"
+
+// Arg describes a single argument of a typed plugin method.
+type Arg struct {
+	Name string `codec:"name" yaml:"name"`
+	// Type is written in Nubo type syntax, e.g. "int", "[]string" or "dict[string, any]?".
+	Type     string `codec:"type" yaml:"type"`
+	Optional bool   `codec:"optional,omitempty" yaml:"optional,omitempty"`
+}
+
+// Method describes a typed RPC method published by a plugin.
+type Method struct {
+	Name    string `codec:"name" yaml:"name"`
+	Args    []Arg  `codec:"args" yaml:"args"`
+	Returns string `codec:"returns" yaml:"returns"`
+	Doc     string `codec:"doc,omitempty" yaml:"doc,omitempty"`
+	// Stream marks a method whose handler emits items with Ctx.Emit. Returns
+	// is then the type of a single item.
+	Stream bool `codec:"stream,omitempty" yaml:"stream,omitempty"`
+}
+
+// Manifest lists the typed methods a plugin exposes through DescribeMethod.
+type Manifest struct {
+	Methods []Method `codec:"methods" yaml:"methods"`
+}
+
+// Lookup returns the method called name, if the manifest publishes it.
+func (m *Manifest) Lookup(name string) (Method, bool) {
+	for _, method := range m.Methods {
+		if method.Name == name {
+			return method, true
+		}
+	}
+	return Method{}, false
+}
+
+// Method registers fn for m.Name and publishes m in the App's manifest.
+// Typed callers send the arguments as a Map keyed by argument name, so
+// handlers decode them with Bind just like untyped ones.
+func (a *App) Method(m Method, fn HandlerFunc) {
+	if m.Returns == "" {
+		m.Returns = "void"
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.handlers[m.Name] = fn
+	for i, existing := range a.manifest.Methods {
+		if existing.Name == m.Name {
+			a.manifest.Methods[i] = m
+			return
+		}
+	}
+	a.manifest.Methods = append(a.manifest.Methods, m)
+}
+
+// describe answers DescribeMethod with a snapshot of the manifest.
+func (a *App) describe(ctx *Ctx) error {
+	a.mu.RLock()
+	manifest := Manifest{Methods: append([]Method(nil), a.manifest.Methods...)}
+	a.mu.RUnlock()
+
+	return ctx.Send(manifest)
+}
+
+// Describe asks the plugin for its Manifest. The result is cached on the
+// session, so a restarted plugin process is asked again. Plugins built before
+// manifests existed answer with an "unknown method" error.
+func (p *Plugin) Describe(ctx context.Context) (*Manifest, error) {
+	p.mu.Lock()
+	s := p.sess
+	if s != nil && s.manifest != nil && !s.isDone() {
+		p.mu.Unlock()
+		return s.manifest, nil
+	}
+	p.mu.Unlock()
+
+	var manifest Manifest
+	if err := p.CallInto(ctx, DescribeMethod, nil, &manifest); err != nil {
+		return nil, fmt.Errorf("plug[%s]: describe: %w", p.DisplayName, err)
+	}
+
+	// Only cache what the session the call started on answered.
+	p.mu.Lock()
+	if s != nil && p.sess == s {
+		s.manifest = &manifest
+	}
+	p.mu.Unlock()
+
+	return &manifest, nil
+}
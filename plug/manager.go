@@ -20,7 +20,8 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// Plugin represents a running plugin subprocess.
+// Plugin represents a supervised plugin subprocess. The process behind it
+// may be restarted by the supervisor; callers keep using the same Plugin.
 type Plugin struct {
 	// Path is the absolute directory from which the plugin was loaded.
 	Path string
@@ -28,20 +29,76 @@ type Plugin struct {
 	// DisplayName is the base directory name, used for logging and error messages.
 	DisplayName string
 
-	cfg    PlugConfig
+	cfg     PlugConfig
+	binPath string
+	mode    string
+	opts    *pluginOptions
+
+	mu      sync.Mutex
+	sess    *session
+	ready   chan struct{} // closed once sess is set or the plugin failed for good
+	failure error         // non-nil once the supervisor gave up
+	stopped bool
+	pending map[uint32]chan *frame
+	seq     uint32
+	host    hostHandlers
+}
+
+// session is one running instance of the plugin process.
+type session struct {
 	cmd    *exec.Cmd
 	fw     *frameWriter
 	reader io.Reader
 	conn   net.Conn // non-nil for tcp transport
 
-	mu      sync.Mutex
-	pending map[uint32]chan *frame
-	seq     uint32
+	started  time.Time
+	done     chan struct{} // closed when the read loop exits
+	manifest *Manifest     // cached by Describe
+}
+
+// isDone reports whether the session's read loop has exited.
+func (s *session) isDone() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// kill terminates the session's process and closes any open TCP connection.
+func (s *session) kill() error {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	if s.cmd.Process == nil {
+		return nil
+	}
+	return s.cmd.Process.Kill()
+}
+
+// Timeout returns the per-call timeout configured in _plug.yaml.
+func (p *Plugin) Timeout() time.Duration {
+	return p.cfg.Plugin.callTimeout()
 }
 
 // Call invokes method on the plugin with msgpack-serialised params and returns
 // the raw msgpack result bytes. Use Unmarshal to decode.
 func (p *Plugin) Call(ctx context.Context, method string, params any) ([]byte, error) {
+	resp, err := p.roundTrip(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.failure(p.DisplayName); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// roundTrip sends a request frame and waits for its response frame. Errors
+// reported by the plugin are left in the frame; only transport failures are
+// returned as errors.
+func (p *Plugin) roundTrip(ctx context.Context, method string, params any) (*frame, error) {
 	data, err := Marshal(params)
 	if err != nil {
 		return nil, err
@@ -50,28 +107,78 @@ func (p *Plugin) Call(ctx context.Context, method string, params any) ([]byte, e
 	id := atomic.AddUint32(&p.seq, 1)
 	ch := make(chan *frame, 1)
 
+	if _, err := p.post(ctx, &frame{ID: id, Method: method, Params: data}, ch); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		p.forget(id)
+		return nil, ctx.Err()
+	case resp := <-ch:
+		return resp, nil
+	}
+}
+
+// post registers ch to receive the responses to f and writes f to the
+// running session, retrying on the restarted session if the write raced with
+// a crash.
+func (p *Plugin) post(ctx context.Context, f *frame, ch chan *frame) (*session, error) {
 	p.mu.Lock()
-	p.pending[id] = ch
+	p.pending[f.ID] = ch
 	p.mu.Unlock()
 
-	if err := p.fw.write(&frame{ID: id, Method: method, Params: data}); err != nil {
-		p.mu.Lock()
-		delete(p.pending, id)
-		p.mu.Unlock()
+	for {
+		s, err := p.acquire(ctx)
+		if err == nil {
+			err = s.fw.write(f)
+		}
+		if err == nil {
+			return s, nil
+		}
+
+		if s != nil && s.isDone() {
+			continue
+		}
+
+		p.forget(f.ID)
 		return nil, err
 	}
+}
 
-	select {
-	case <-ctx.Done():
+// forget stops delivering responses for request id.
+func (p *Plugin) forget(id uint32) {
+	p.mu.Lock()
+	delete(p.pending, id)
+	p.mu.Unlock()
+}
+
+// acquire returns the running session, waiting while the supervisor
+// restarts a crashed process.
+func (p *Plugin) acquire(ctx context.Context) (*session, error) {
+	for {
 		p.mu.Lock()
-		delete(p.pending, id)
+		s, ready, failure, stopped := p.sess, p.ready, p.failure, p.stopped
 		p.mu.Unlock()
-		return nil, ctx.Err()
-	case resp := <-ch:
-		if resp.Err != "" {
-			return nil, fmt.Errorf("plug[%s]: %s", p.DisplayName, resp.Err)
+
+		switch {
+		case failure != nil:
+			return nil, failure
+		case stopped:
+			return nil, fmt.Errorf("plug[%s]: plugin stopped", p.DisplayName)
+		case s != nil && !s.isDone():
+			return s, nil
+		case s != nil:
+			// The process just died; give the supervisor time to notice.
+			ready = make(chan struct{})
+			time.AfterFunc(10*time.Millisecond, func() { close(ready) })
+		}
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("plug[%s]: waiting for restart: %w", p.DisplayName, ctx.Err())
 		}
-		return resp.Result, nil
 	}
 }
 
@@ -84,51 +191,104 @@ func (p *Plugin) CallInto(ctx context.Context, method string, params, v any) err
 	return Unmarshal(raw, v)
 }
 
-// Stop kills the plugin subprocess and closes any open TCP connection.
+// Stop kills the plugin subprocess and closes any open TCP connection. The
+// supervisor does not restart a stopped plugin.
 func (p *Plugin) Stop() error {
-	if p.conn != nil {
-		_ = p.conn.Close()
-	}
-	if p.cmd.Process == nil {
+	p.mu.Lock()
+	p.stopped = true
+	s := p.sess
+	p.mu.Unlock()
+
+	if s == nil {
 		return nil
 	}
-	return p.cmd.Process.Kill()
+	return s.kill()
+}
+
+// Failed reports the error the supervisor gave up with, if any.
+func (p *Plugin) Failed() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.failure
+}
+
+// start launches a new session using the configured transport.
+func (p *Plugin) start() (*session, error) {
+	var (
+		s   *session
+		err error
+	)
+	switch p.mode {
+	case "stdio":
+		s, err = startStdio(p.binPath, p.DisplayName)
+	case "tcp":
+		s, err = startTCP(p.binPath, p.DisplayName, p.opts)
+	default:
+		return nil, fmt.Errorf("plug: unknown transport mode %q (want stdio or tcp)", p.mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	go p.readLoop(s)
+	return s, nil
 }
 
 // readLoop runs in a goroutine and fans incoming frames out to pending callers.
-func (p *Plugin) readLoop() {
+func (p *Plugin) readLoop(s *session) {
+	defer close(s.done)
+
 	for {
-		f, err := readFrame(p.reader)
+		f, err := readFrame(s.reader)
 		if err != nil {
 			if err != io.EOF {
 				log.Printf("plug[%s]: read: %v", p.DisplayName, err)
 			}
 			p.mu.Lock()
 			for id, ch := range p.pending {
-				ch <- &frame{ID: id, Err: "plugin disconnected"}
+				ch <- &frame{ID: id, Err: ErrDisconnected.Error(), local: ErrDisconnected}
 				delete(p.pending, id)
 			}
 			p.mu.Unlock()
 			return
 		}
 
+		// Frames with a method are requests from the plugin to the host.
+		if f.Method != "" {
+			go p.serveHost(s, f)
+			continue
+		}
+
 		p.mu.Lock()
 		ch, ok := p.pending[f.ID]
-		if ok {
+		if ok && !f.Stream {
 			delete(p.pending, f.ID)
 		}
 		p.mu.Unlock()
 
-		if ok {
+		if !ok {
+			continue
+		}
+		if !f.Stream {
 			ch <- f
+			continue
+		}
+
+		// Stream items are bounded by the credit the host granted, so the
+		// buffer only fills up if the plugin ignores flow control.
+		select {
+		case ch <- f:
+		default:
+			log.Printf("plug[%s]: stream %d exceeded its window, dropping item", p.DisplayName, f.ID)
 		}
 	}
 }
 
 // Manager owns a set of plugins keyed by absolute directory path.
 type Manager struct {
-	mu      sync.RWMutex
-	plugins map[string]*Plugin
+	mu       sync.RWMutex
+	plugins  map[string]*Plugin
+	prebuilt bool
 }
 
 var manager *Manager
@@ -142,6 +302,15 @@ func GetManager() *Manager {
 	return manager
 }
 
+// UsePrebuilt makes Load start the binaries already present instead of
+// running the build commands, e.g. in an app bundled by nubo build where no
+// toolchain is available.
+func (m *Manager) UsePrebuilt() {
+	m.mu.Lock()
+	m.prebuilt = true
+	m.mu.Unlock()
+}
+
 // PluginOption configures how the Manager connects to a plugin at load time.
 type PluginOption func(*pluginOptions)
 
@@ -167,31 +336,29 @@ func (m *Manager) Load(path string, opts ...PluginOption) (*Plugin, error) {
 		return nil, err
 	}
 
-	// Fast path: already loaded.
+	// Fast path: already loaded. A plugin the supervisor gave up on is
+	// rebuilt and started from scratch.
 	m.mu.RLock()
-	if existing, ok := m.plugins[absPath]; ok {
+	if existing, ok := m.plugins[absPath]; ok && existing.Failed() == nil {
 		m.mu.RUnlock()
 		return existing, nil
 	}
 	m.mu.RUnlock()
 
-	cfgPath := filepath.Join(absPath, "_plug.yaml")
-	raw, err := os.ReadFile(cfgPath)
+	cfg, err := readConfig(absPath)
 	if err != nil {
-		return nil, fmt.Errorf("plug: read config at %s: %w", cfgPath, err)
-	}
-
-	var cfg PlugConfig
-	if err := yaml.Unmarshal(raw, &cfg); err != nil {
-		return nil, fmt.Errorf("plug: parse config: %w", err)
-	}
-
-	// Reject early if this OS is not in the supported architecture list.
-	if err := checkArchSupport(cfg); err != nil {
 		return nil, err
 	}
 
-	if err := buildPlugin(absPath, cfg); err != nil {
+	m.mu.RLock()
+	prebuilt := m.prebuilt
+	m.mu.RUnlock()
+
+	if prebuilt {
+		if _, err := os.Stat(resolveBinary(absPath, cfg)); err != nil {
+			return nil, fmt.Errorf("plug: prebuilt binary at %q: %w", absPath, err)
+		}
+	} else if err := buildPlugin(absPath, cfg); err != nil {
 		return nil, fmt.Errorf("plug: build at %q: %w", absPath, err)
 	}
 
@@ -200,31 +367,34 @@ func (m *Manager) Load(path string, opts ...PluginOption) (*Plugin, error) {
 		o(po)
 	}
 
-	displayName := filepath.Base(absPath)
-	binPath := resolveBinary(absPath, cfg)
-
 	mode := strings.ToLower(strings.TrimSpace(cfg.Plugin.Transport.Mode))
 	if mode == "" {
 		mode = "stdio"
 	}
 
-	var p *Plugin
-	switch mode {
-	case "stdio":
-		p, err = startStdio(absPath, binPath, cfg, displayName)
-	case "tcp":
-		p, err = startTCP(absPath, binPath, cfg, displayName, po)
-	default:
-		return nil, fmt.Errorf("plug: unknown transport mode %q (want stdio or tcp)", mode)
+	p := &Plugin{
+		Path:        absPath,
+		DisplayName: filepath.Base(absPath),
+		cfg:         cfg,
+		binPath:     resolveBinary(absPath, cfg),
+		mode:        mode,
+		opts:        po,
+		ready:       make(chan struct{}),
+		pending:     make(map[uint32]chan *frame),
 	}
+
+	s, err := p.start()
 	if err != nil {
 		return nil, err
 	}
+	p.sess = s
+	close(p.ready)
+	go p.supervise()
 
 	// Lock and double-check (TOCTOU).
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if existing, dup := m.plugins[absPath]; dup {
+	if existing, dup := m.plugins[absPath]; dup && existing.Failed() == nil {
 		_ = p.Stop()
 		return existing, nil
 	}
@@ -233,7 +403,7 @@ func (m *Manager) Load(path string, opts ...PluginOption) (*Plugin, error) {
 }
 
 // startStdio launches the plugin and wires stdin/stdout as the transport.
-func startStdio(base, binPath string, cfg PlugConfig, displayName string) (*Plugin, error) {
+func startStdio(binPath, displayName string) (*session, error) {
 	cmd := exec.Command(binPath)
 	cmd.Stderr = os.Stderr
 
@@ -249,17 +419,13 @@ func startStdio(base, binPath string, cfg PlugConfig, displayName string) (*Plug
 		return nil, fmt.Errorf("plug: start %q: %w", displayName, err)
 	}
 
-	p := &Plugin{
-		Path:        base,
-		DisplayName: displayName,
-		cfg:         cfg,
-		cmd:         cmd,
-		fw:          &frameWriter{w: stdin},
-		reader:      stdout,
-		pending:     make(map[uint32]chan *frame),
-	}
-	go p.readLoop()
-	return p, nil
+	return &session{
+		cmd:     cmd,
+		fw:      &frameWriter{w: stdin},
+		reader:  stdout,
+		started: time.Now(),
+		done:    make(chan struct{}),
+	}, nil
 }
 
 // startTCP launches the plugin binary and discovers its TCP address from the
@@ -269,8 +435,8 @@ func startStdio(base, binPath string, cfg PlugConfig, displayName string) (*Plug
 //
 // After the address is discovered the manager dials it, performs the optional
 // auth handshake (token supplied via WithToken on the host side, validated by
-// WithAuth / WithAuthValidator on the plugin side), and starts the read loop.
-func startTCP(base, binPath string, cfg PlugConfig, displayName string, po *pluginOptions) (*Plugin, error) {
+// WithAuth / WithAuthValidator on the plugin side).
+func startTCP(binPath, displayName string, po *pluginOptions) (*session, error) {
 	cmd := exec.Command(binPath)
 	cmd.Stderr = os.Stderr
 
@@ -315,18 +481,14 @@ func startTCP(base, binPath string, cfg PlugConfig, displayName string, po *plug
 		}
 	}
 
-	p := &Plugin{
-		Path:        base,
-		DisplayName: displayName,
-		cfg:         cfg,
-		cmd:         cmd,
-		fw:          &frameWriter{w: conn},
-		reader:      conn,
-		conn:        conn,
-		pending:     make(map[uint32]chan *frame),
-	}
-	go p.readLoop()
-	return p, nil
+	return &session{
+		cmd:     cmd,
+		fw:      &frameWriter{w: conn},
+		reader:  conn,
+		conn:    conn,
+		started: time.Now(),
+		done:    make(chan struct{}),
+	}, nil
 }
 
 // readTCPAddrAnnouncement reads the single "PLUG_TCP_ADDR=<addr>" line that
@@ -436,6 +598,46 @@ func (m *Manager) StopAll() {
 	}
 }
 
+// Build compiles the plugin in path without starting it and returns the
+// absolute path of the binary.
+func Build(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := readConfig(absPath)
+	if err != nil {
+		return "", err
+	}
+
+	if err := buildPlugin(absPath, cfg); err != nil {
+		return "", fmt.Errorf("plug: build at %q: %w", absPath, err)
+	}
+	return resolveBinary(absPath, cfg), nil
+}
+
+// readConfig loads _plug.yaml from dir and rejects plugins that do not
+// support the current OS.
+func readConfig(dir string) (PlugConfig, error) {
+	cfgPath := filepath.Join(dir, "_plug.yaml")
+	raw, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return PlugConfig{}, fmt.Errorf("plug: read config at %s: %w", cfgPath, err)
+	}
+
+	var cfg PlugConfig
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return PlugConfig{}, fmt.Errorf("plug: parse config: %w", err)
+	}
+
+	// Reject early if this OS is not in the supported architecture list.
+	if err := checkArchSupport(cfg); err != nil {
+		return PlugConfig{}, err
+	}
+	return cfg, nil
+}
+
 // buildPlugin resolves template variables and runs the build command.
 func buildPlugin(base string, cfg PlugConfig) error {
 	src := filepath.Join(base, cfg.Plugin.Source)
@@ -0,0 +1,97 @@
+package plug
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerLoad(t *testing.T) {
+	m, p := loadTestPlugin(t, "")
+
+	again, err := m.Load(p.Path)
+	require.NoError(t, err)
+	assert.Same(t, p, again, "a loaded plugin is reused")
+
+	got, ok := m.Get(p.Path)
+	assert.True(t, ok)
+	assert.Same(t, p, got)
+	assert.Equal(t, []string{p.Path}, m.Paths())
+	assert.Equal(t, "testplug", p.DisplayName)
+
+	var text string
+	require.NoError(t, p.CallInto(context.Background(), "echo", echoArgs{Text: "hi"}, &text))
+	assert.Equal(t, "hi", text)
+
+	m.StopAll()
+	_, err = p.Call(context.Background(), "echo", echoArgs{Text: "hi"})
+	assert.ErrorContains(t, err, "plugin stopped")
+}
+
+func TestDescribe(t *testing.T) {
+	_, p := loadTestPlugin(t, "")
+
+	manifest, err := p.Describe(context.Background())
+	require.NoError(t, err)
+
+	method, ok := manifest.Lookup("echo")
+	require.True(t, ok)
+	assert.Equal(t, Method{
+		Name:    "echo",
+		Args:    []Arg{{Name: "text", Type: "string"}},
+		Returns: "string",
+		Doc:     "Returns text.",
+	}, method)
+
+	// Handlers registered without a Method are not published.
+	_, ok = manifest.Lookup("pid")
+	assert.False(t, ok)
+
+	cached, err := p.Describe(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, manifest, cached)
+}
+
+func TestDescribeRestart(t *testing.T) {
+	_, p := loadTestPlugin(t, "  health:\n    interval: -1s\n  restart:\n    backoff: 10ms\n")
+
+	manifest, err := p.Describe(context.Background())
+	require.NoError(t, err)
+
+	_, err = p.Call(context.Background(), "crash", nil)
+	assert.ErrorIs(t, err, ErrDisconnected)
+
+	// The restarted process is asked again instead of serving the manifest
+	// of the one that exited.
+	restarted, err := p.Describe(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, manifest, restarted)
+
+	_, ok := restarted.Lookup("echo")
+	assert.True(t, ok)
+}
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(config string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "_plug.yaml"), []byte(config), 0o644))
+	}
+
+	write("plugin:\n  binary: bin/plugin\n  timeout: 2s\n  architecture: [plan9]\n")
+	_, err := readConfig(dir)
+	assert.ErrorContains(t, err, "is not in the plugin's supported architecture list [plan9]")
+
+	write("plugin:\n  binary: bin/plugin\n  timeout: 2s\n")
+	cfg, err := readConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "bin", "plugin"), resolveBinary(dir, cfg))
+	assert.Equal(t, "2s", cfg.Plugin.callTimeout().String())
+	assert.Equal(t, defaultRestartLimit, cfg.Plugin.Restart.restartLimit())
+
+	_, err = readConfig(t.TempDir())
+	assert.ErrorContains(t, err, "plug: read config")
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 )
 
 // HandlerFunc is implemented by plugin authors to handle a named RPC method.
@@ -28,9 +29,14 @@ type Ctx struct {
 	// Method is the name of the called RPC method.
 	Method string
 
-	raw []byte
-	fw  *frameWriter
-	id  uint32
+	raw  []byte
+	fw   *frameWriter
+	id   uint32
+	peer *peer
+
+	cancel  context.CancelFunc
+	credit  chan struct{} // nil unless the call is streamed
+	replied atomic.Bool
 }
 
 // Bind decodes the msgpack request params into v.
@@ -44,11 +50,13 @@ func (c *Ctx) Send(v any) error {
 	if err != nil {
 		return err
 	}
+	c.replied.Store(true)
 	return c.fw.write(&frame{ID: c.id, Result: data})
 }
 
 // Fail sends an error string as the response.
 func (c *Ctx) Fail(err error) error {
+	c.replied.Store(true)
 	return c.fw.write(&frame{ID: c.id, Err: err.Error()})
 }
 
@@ -82,15 +90,20 @@ type App struct {
 	listener      net.Listener // tcp only
 	authEnabled   bool
 	authValidator AuthValidatorFunc
+	manifest      Manifest
+	peers         map[*peer]struct{}
 }
 
 // Create returns a new App wired to os.Stdin and os.Stdout (stdio transport).
 func Create() *App {
-	return &App{
+	a := &App{
 		handlers: make(map[string]HandlerFunc),
 		fw:       &frameWriter{w: os.Stdout},
 		in:       os.Stdin,
 	}
+	a.handlers[DescribeMethod] = a.describe
+	a.handlers[PingMethod] = a.ping
+	return a
 }
 
 // CreateTCP returns a new App that listens on addr (e.g. ":9000") and serves
@@ -104,6 +117,8 @@ func CreateTCP(addr string, opts ...AppOption) (*App, error) {
 		handlers: make(map[string]HandlerFunc),
 		listener: ln,
 	}
+	a.handlers[DescribeMethod] = a.describe
+	a.handlers[PingMethod] = a.ping
 	for _, o := range opts {
 		o(a)
 	}
@@ -176,7 +191,24 @@ func (a *App) recvAuthHandshake(conn net.Conn) bool {
 }
 
 // serveReader reads frames from r and dispatches them using fw for replies.
+// Frames without a method answer requests the plugin sent to the host.
 func (a *App) serveReader(r io.Reader, fw *frameWriter) {
+	pr := newPeer(fw)
+
+	a.mu.Lock()
+	if a.peers == nil {
+		a.peers = make(map[*peer]struct{})
+	}
+	a.peers[pr] = struct{}{}
+	a.mu.Unlock()
+
+	defer func() {
+		a.mu.Lock()
+		delete(a.peers, pr)
+		a.mu.Unlock()
+		pr.close()
+	}()
+
 	for {
 		f, err := readFrame(r)
 		if err != nil {
@@ -185,11 +217,23 @@ func (a *App) serveReader(r io.Reader, fw *frameWriter) {
 			}
 			return
 		}
-		go a.dispatch(f, fw)
+		switch f.Method {
+		case "":
+			pr.resolve(f)
+		case CreditMethod, CancelMethod:
+			pr.control(f)
+		default:
+			// Register before dispatching so stream control frames that
+			// follow the request always find the call.
+			go a.dispatch(f, pr.begin(f))
+		}
 	}
 }
 
-func (a *App) dispatch(f *frame, fw *frameWriter) {
+func (a *App) dispatch(f *frame, ctx *Ctx) {
+	fw := ctx.fw
+	defer ctx.peer.end(ctx)
+
 	a.mu.RLock()
 	fn, ok := a.handlers[f.Method]
 	a.mu.RUnlock()
@@ -202,15 +246,14 @@ func (a *App) dispatch(f *frame, fw *frameWriter) {
 		return
 	}
 
-	ctx := &Ctx{
-		Context: context.Background(),
-		Method:  f.Method,
-		raw:     f.Params,
-		fw:      fw,
-		id:      f.ID,
-	}
-
 	if err := fn(ctx); err != nil {
 		_ = fw.write(&frame{ID: f.ID, Err: err.Error()})
+		return
+	}
+
+	// A stream ends with a regular response; send an empty one if the
+	// handler only emitted items.
+	if ctx.credit != nil && !ctx.replied.Load() {
+		_ = fw.write(&frame{ID: f.ID})
 	}
 }
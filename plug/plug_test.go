@@ -0,0 +1,191 @@
+package plug
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nubolang/nubo/internal/plugtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	plugtest.Main(m, func() { testPlugin().Start() })
+}
+
+type echoArgs struct {
+	Text string `codec:"text"`
+}
+
+func testPlugin() *App {
+	app := Create()
+
+	var stalled atomic.Bool
+	app.Handler(PingMethod, func(ctx *Ctx) error {
+		if stalled.Load() {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return ctx.Send(nil)
+	})
+
+	app.Method(Method{
+		Name:    "echo",
+		Args:    []Arg{{Name: "text", Type: "string"}},
+		Returns: "string",
+		Doc:     "Returns text.",
+	}, func(ctx *Ctx) error {
+		var args echoArgs
+		if err := ctx.Bind(&args); err != nil {
+			return err
+		}
+		return ctx.Send(args.Text)
+	})
+	app.Handler("pid", func(ctx *Ctx) error {
+		return ctx.Send(os.Getpid())
+	})
+	app.Handler("crash", func(ctx *Ctx) error {
+		os.Exit(3)
+		return nil
+	})
+	app.Handler("publish", func(ctx *Ctx) error {
+		var topic string
+		if err := ctx.Bind(&topic); err != nil {
+			return err
+		}
+		if err := ctx.Publish(topic, 1, "a"); err != nil {
+			return err
+		}
+		return ctx.Send(nil)
+	})
+	app.Handler("invoke", func(ctx *Ctx) error {
+		var cb Callback
+		if err := ctx.Bind(&cb); err != nil {
+			return err
+		}
+		var out string
+		if err := ctx.InvokeInto(&out, cb, "x", "y"); err != nil {
+			return err
+		}
+		return ctx.Send(out)
+	})
+	// count emits the numbers below n as a stream. emitted and cancelled
+	// report how far it got and whether the host cancelled it.
+	var (
+		emitted   atomic.Int64
+		cancelled atomic.Bool
+	)
+	app.Handler("count", func(ctx *Ctx) error {
+		var n int
+		if err := ctx.Bind(&n); err != nil {
+			return err
+		}
+		for i := range n {
+			if err := ctx.Emit(i); err != nil {
+				cancelled.Store(ctx.Err() != nil)
+				return err
+			}
+			emitted.Add(1)
+		}
+		return nil
+	})
+	app.Handler("emitted", func(ctx *Ctx) error {
+		return ctx.Send(emitted.Load())
+	})
+	app.Handler("cancelled", func(ctx *Ctx) error {
+		return ctx.Send(cancelled.Load())
+	})
+	// stall stops answering pings; with exit set the process exits shortly
+	// after.
+	app.Handler("stall", func(ctx *Ctx) error {
+		stalled.Store(true)
+		var exit bool
+		if err := ctx.Bind(&exit); err == nil && exit {
+			time.AfterFunc(200*time.Millisecond, func() { os.Exit(3) })
+		}
+		return ctx.Send(nil)
+	})
+	return app
+}
+
+// loadTestPlugin loads the test binary as a plugin of a new Manager. extra
+// is appended to the plugin section of _plug.yaml.
+func loadTestPlugin(t *testing.T, extra string) (*Manager, *Plugin) {
+	t.Helper()
+
+	m := &Manager{plugins: make(map[string]*Plugin)}
+	p, err := m.Load(plugtest.Dir(t, extra))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = p.Stop() })
+	return m, p
+}
+
+func pid(t *testing.T, p *Plugin) int {
+	t.Helper()
+
+	var pid int
+	require.NoError(t, p.CallInto(context.Background(), "pid", nil, &pid))
+	return pid
+}
+
+func TestPingDisconnected(t *testing.T) {
+	_, p := loadTestPlugin(t, "  health:\n    interval: -1s\n  restart:\n    backoff: 10ms\n")
+
+	assert.NoError(t, p.Ping(context.Background(), time.Second))
+
+	// A plugin that answers with an error frame is alive.
+	_, err := p.Call(context.Background(), "missing", nil)
+	assert.ErrorContains(t, err, "unknown method: missing")
+	assert.NotErrorIs(t, err, ErrDisconnected)
+
+	// A ping still waiting when the process exits reports the disconnect.
+	_, err = p.Call(context.Background(), "stall", true)
+	require.NoError(t, err)
+	err = p.Ping(context.Background(), 5*time.Second)
+	assert.ErrorIs(t, err, ErrDisconnected)
+
+	_, err = p.Call(context.Background(), "crash", nil)
+	assert.ErrorIs(t, err, ErrDisconnected)
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	_, p := loadTestPlugin(t, "  health:\n    interval: -1s\n  restart:\n    limit: 2\n    backoff: 10ms\n")
+
+	first := pid(t, p)
+	_, err := p.Call(context.Background(), "crash", nil)
+	assert.ErrorIs(t, err, ErrDisconnected)
+
+	// Calls wait for the restarted process.
+	second := pid(t, p)
+	assert.NotEqual(t, first, second)
+
+	_, err = p.Call(context.Background(), "crash", nil)
+	assert.ErrorIs(t, err, ErrDisconnected)
+	assert.NotEqual(t, second, pid(t, p))
+
+	// The third crash in a row exceeds the limit.
+	_, err = p.Call(context.Background(), "crash", nil)
+	assert.ErrorIs(t, err, ErrDisconnected)
+	_, err = p.Call(context.Background(), "pid", nil)
+	assert.ErrorContains(t, err, "restart limit of 2 reached")
+	assert.Error(t, p.Failed())
+}
+
+func TestSupervisorHealthCheck(t *testing.T) {
+	_, p := loadTestPlugin(t, "  health:\n    interval: 50ms\n    timeout: 50ms\n  restart:\n    backoff: 10ms\n")
+
+	first := pid(t, p)
+	_, err := p.Call(context.Background(), "stall", false)
+	require.NoError(t, err)
+
+	// The supervisor kills the process that stopped answering pings and
+	// starts a new one.
+	assert.Eventually(t, func() bool {
+		var pid int
+		err := p.CallInto(context.Background(), "pid", nil, &pid)
+		return err == nil && pid != first
+	}, 5*time.Second, 20*time.Millisecond)
+}
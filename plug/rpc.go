@@ -2,6 +2,8 @@ package plug
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"sync"
 
@@ -10,6 +12,10 @@ import (
 
 var mh = &codec.MsgpackHandle{}
 
+// ErrDisconnected is reported to calls that were waiting for a response when
+// the plugin process went away.
+var ErrDisconnected = errors.New("plugin disconnected")
+
 // frame is the wire-level message for both requests and responses.
 type frame struct {
 	ID     uint32 `codec:"id"`
@@ -17,6 +23,29 @@ type frame struct {
 	Params []byte `codec:"params,omitempty"`
 	Result []byte `codec:"result,omitempty"`
 	Err    string `codec:"err,omitempty"`
+
+	// Window is set on requests for streamed calls and holds the number of
+	// items the plugin may send before the host grants more credit.
+	Window uint32 `codec:"window,omitempty"`
+	// Stream marks a response frame as one item of a stream. The stream ends
+	// with a regular response frame.
+	Stream bool `codec:"stream,omitempty"`
+
+	// local is the error of a frame the host made up itself, such as
+	// ErrDisconnected. It is never sent.
+	local error
+}
+
+// failure returns the error f reports, or nil. name is the display name of
+// the plugin the frame came from.
+func (f *frame) failure(name string) error {
+	switch {
+	case f.local != nil:
+		return fmt.Errorf("plug[%s]: %w", name, f.local)
+	case f.Err != "":
+		return fmt.Errorf("plug[%s]: %s", name, f.Err)
+	}
+	return nil
 }
 
 // frameWriter serialises frames safely from multiple goroutines.
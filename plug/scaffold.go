@@ -18,8 +18,12 @@ import "github.com/nubolang/nubo/plug"
 func main() {
 	app := plug.Create()
 
-	// Register your handlers here.
-	app.Handler("ping", func(ctx *plug.Ctx) error {
+	// Register your handlers here. Methods registered with app.Method are
+	// published through __describe__ and become typed functions in Nubo.
+	app.Method(plug.Method{
+		Name:    "ping",
+		Returns: "dict[string, string]",
+	}, func(ctx *plug.Ctx) error {
 		var req plug.Map
 		if err := ctx.Bind(&req); err != nil {
 			return ctx.Fail(err)
@@ -48,8 +52,12 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// Register your handlers here.
-	app.Handler("ping", func(ctx *plug.Ctx) error {
+	// Register your handlers here. Methods registered with app.Method are
+	// published through __describe__ and become typed functions in Nubo.
+	app.Method(plug.Method{
+		Name:    "ping",
+		Returns: "dict[string, string]",
+	}, func(ctx *plug.Ctx) error {
 		var req plug.Map
 		if err := ctx.Bind(&req); err != nil {
 			return ctx.Fail(err)
@@ -86,8 +94,12 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// Register your handlers here.
-	app.Handler("ping", func(ctx *plug.Ctx) error {
+	// Register your handlers here. Methods registered with app.Method are
+	// published through __describe__ and become typed functions in Nubo.
+	app.Method(plug.Method{
+		Name:    "ping",
+		Returns: "dict[string, string]",
+	}, func(ctx *plug.Ctx) error {
 		var req plug.Map
 		if err := ctx.Bind(&req); err != nil {
 			return ctx.Fail(err)
@@ -215,9 +227,8 @@ func Scaffold(path string) error {
 // buildNuboExample returns the content of the example.nubo file tailored to
 // the chosen transport mode.
 func buildNuboExample(mode string) string {
-	callPing := `const result = app.send("ping")
-const status = result.status
-println("ping result:", string(status.map(byte)))
+	callPing := `const result = app.ping()
+println("ping result:", result.status)
 `
 
 	if mode == "tcp" {
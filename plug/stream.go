@@ -0,0 +1,220 @@
+package plug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// CreditMethod is sent by the host to let a stream emit more items.
+	CreditMethod = "__credit__"
+	// CancelMethod is sent by the host when it stops reading a stream.
+	CancelMethod = "__cancel__"
+)
+
+// DefaultStreamWindow is the number of items a plugin may emit ahead of the
+// host when no window is given to Plugin.Stream.
+const DefaultStreamWindow = 16
+
+// streamControl is the payload of CreditMethod and CancelMethod frames.
+// Control frames carry no request ID of their own and get no response.
+type streamControl struct {
+	ID uint32 `codec:"id"`
+	N  uint32 `codec:"n,omitempty"`
+}
+
+// Streaming reports whether the host called this method as a stream, so
+// Emit may be used.
+func (c *Ctx) Streaming() bool {
+	return c.credit != nil
+}
+
+// Emit sends v as the next item of a streamed response. It blocks while the
+// host has not consumed enough earlier items and fails once the host stops
+// reading. Returning from the handler ends the stream.
+func (c *Ctx) Emit(v any) error {
+	if c.credit == nil {
+		return fmt.Errorf("plug: %s was not called as a stream", c.Method)
+	}
+
+	select {
+	case <-c.credit:
+	case <-c.Done():
+		return c.Err()
+	}
+
+	data, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.fw.write(&frame{ID: c.id, Result: data, Stream: true})
+}
+
+// begin creates the Ctx for request f and registers it for stream control.
+func (pr *peer) begin(f *frame) *Ctx {
+	base, cancel := context.WithCancel(context.Background())
+	c := &Ctx{
+		Context: base,
+		Method:  f.Method,
+		raw:     f.Params,
+		fw:      pr.fw,
+		id:      f.ID,
+		peer:    pr,
+		cancel:  cancel,
+	}
+
+	if f.Window > 0 {
+		c.credit = make(chan struct{}, f.Window)
+		for range f.Window {
+			c.credit <- struct{}{}
+		}
+	}
+
+	pr.mu.Lock()
+	pr.calls[f.ID] = c
+	pr.mu.Unlock()
+	return c
+}
+
+// end unregisters a finished call and releases its context.
+func (pr *peer) end(c *Ctx) {
+	pr.mu.Lock()
+	delete(pr.calls, c.id)
+	pr.mu.Unlock()
+	c.cancel()
+}
+
+// control applies a CreditMethod or CancelMethod frame.
+func (pr *peer) control(f *frame) {
+	var ctl streamControl
+	if err := Unmarshal(f.Params, &ctl); err != nil {
+		return
+	}
+
+	pr.mu.Lock()
+	c, ok := pr.calls[ctl.ID]
+	pr.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if f.Method == CancelMethod {
+		c.cancel()
+		return
+	}
+	for range ctl.N {
+		select {
+		case c.credit <- struct{}{}:
+		default:
+			return
+		}
+	}
+}
+
+// Stream is the host side of a streamed call.
+type Stream struct {
+	p      *Plugin
+	s      *session
+	id     uint32
+	ch     chan *frame
+	window uint32
+
+	mu       sync.Mutex
+	consumed uint32
+	done     bool
+}
+
+// Stream calls method as a stream. At most window items are buffered before
+// the plugin has to wait for the host to read them; zero uses
+// DefaultStreamWindow. Close the stream when done reading it early.
+func (p *Plugin) Stream(ctx context.Context, method string, params any, window int) (*Stream, error) {
+	if window <= 0 {
+		window = DefaultStreamWindow
+	}
+
+	data, err := Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &Stream{
+		p:      p,
+		id:     atomic.AddUint32(&p.seq, 1),
+		ch:     make(chan *frame, window+1),
+		window: uint32(window),
+	}
+
+	st.s, err = p.post(ctx, &frame{ID: st.id, Method: method, Params: data, Window: st.window}, st.ch)
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Next returns the raw msgpack bytes of the next item. It returns io.EOF once
+// the plugin ended the stream.
+func (st *Stream) Next(ctx context.Context) ([]byte, error) {
+	st.mu.Lock()
+	done := st.done
+	st.mu.Unlock()
+	if done {
+		return nil, io.EOF
+	}
+
+	var f *frame
+	select {
+	case f = <-st.ch:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if !f.Stream {
+		st.done = true
+		if err := f.failure(st.p.DisplayName); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	// Grant credit in batches of half a window to keep control traffic low.
+	st.consumed++
+	if st.consumed >= max(st.window/2, 1) {
+		if err := st.control(CreditMethod, st.consumed); err != nil {
+			return nil, err
+		}
+		st.consumed = 0
+	}
+	return f.Result, nil
+}
+
+// Close stops reading the stream and tells the plugin to cancel the handler.
+// Closing a finished stream is a no-op.
+func (st *Stream) Close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.done {
+		return nil
+	}
+	st.done = true
+	st.p.forget(st.id)
+
+	if st.s.isDone() {
+		return nil
+	}
+	return st.control(CancelMethod, 0)
+}
+
+func (st *Stream) control(method string, n uint32) error {
+	data, err := Marshal(streamControl{ID: st.id, N: n})
+	if err != nil {
+		return err
+	}
+	return st.s.fw.write(&frame{Method: method, Params: data})
+}
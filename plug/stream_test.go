@@ -0,0 +1,88 @@
+package plug
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	_, p := loadTestPlugin(t, "")
+
+	st, err := p.Stream(context.Background(), "count", 5, 2)
+	require.NoError(t, err)
+
+	var items []int
+	for {
+		raw, err := st.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		var item int
+		require.NoError(t, Unmarshal(raw, &item))
+		items = append(items, item)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, items)
+	assert.NoError(t, st.Close())
+
+	_, err = p.Call(context.Background(), "count", 1)
+	assert.ErrorContains(t, err, "count was not called as a stream")
+}
+
+func TestStreamBackpressure(t *testing.T) {
+	_, p := loadTestPlugin(t, "")
+
+	emitted := func() int {
+		var n int
+		require.NoError(t, p.CallInto(context.Background(), "emitted", nil, &n))
+		return n
+	}
+
+	st, err := p.Stream(context.Background(), "count", 100, 4)
+	require.NoError(t, err)
+
+	// The plugin stops after a window of items nobody read.
+	assert.Eventually(t, func() bool { return emitted() == 4 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 4, emitted())
+
+	// Reading half a window grants that much credit back.
+	for range 2 {
+		_, err := st.Next(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return emitted() == 6 }, 5*time.Second, 10*time.Millisecond)
+
+	// Closing cancels the handler, which then sees its context end.
+	require.NoError(t, st.Close())
+	assert.Eventually(t, func() bool {
+		var cancelled bool
+		require.NoError(t, p.CallInto(context.Background(), "cancelled", nil, &cancelled))
+		return cancelled
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = st.Next(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestStreamNextContext(t *testing.T) {
+	_, p := loadTestPlugin(t, "")
+
+	st, err := p.Stream(context.Background(), "count", 100, 1)
+	require.NoError(t, err)
+	defer st.Close()
+
+	_, err = st.Next(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = st.Next(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,149 @@
+package plug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PingMethod is the built-in RPC the supervisor uses for health checks.
+const PingMethod = "__ping__"
+
+// restartResetAfter is how long a session must stay up before earlier
+// crashes stop counting towards the restart limit.
+const restartResetAfter = time.Minute
+
+// ping answers PingMethod with an empty result.
+func (a *App) ping(ctx *Ctx) error {
+	return ctx.Send(nil)
+}
+
+// Ping checks that the plugin answers within timeout. A plugin that replies
+// with an error frame (for example one built before PingMethod existed) is
+// still considered alive.
+func (p *Plugin) Ping(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := p.roundTrip(ctx, PingMethod, nil)
+	if err != nil {
+		return err
+	}
+	if errors.Is(resp.local, ErrDisconnected) {
+		return resp.failure(p.DisplayName)
+	}
+	return nil
+}
+
+// supervise watches the current session, kills it when health checks fail,
+// and restarts the process with exponential backoff until the restart limit
+// is reached or the plugin is stopped.
+func (p *Plugin) supervise() {
+	restarts := 0
+
+	for {
+		p.mu.Lock()
+		s := p.sess
+		p.mu.Unlock()
+
+		p.watch(s)
+		_ = s.kill()
+		_ = s.cmd.Wait()
+
+		p.mu.Lock()
+		p.sess = nil
+		stopped := p.stopped
+		if !stopped {
+			p.ready = make(chan struct{})
+		}
+		p.mu.Unlock()
+
+		if stopped {
+			return
+		}
+
+		if time.Since(s.started) > restartResetAfter {
+			restarts = 0
+		}
+
+		next, err := p.restart(&restarts)
+		if err != nil {
+			log.Printf("plug[%s]: giving up: %v", p.DisplayName, err)
+			p.mu.Lock()
+			p.failure = fmt.Errorf("plug[%s]: plugin is down: %w", p.DisplayName, err)
+			close(p.ready)
+			p.mu.Unlock()
+			return
+		}
+
+		p.mu.Lock()
+		if p.stopped {
+			p.mu.Unlock()
+			_ = next.kill()
+			return
+		}
+		p.sess = next
+		close(p.ready)
+		p.mu.Unlock()
+	}
+}
+
+// watch blocks until the session's read loop ends. When health checks are
+// enabled, a session that stops answering pings is killed.
+func (p *Plugin) watch(s *session) {
+	health := p.cfg.Plugin.Health
+	if health.interval() < 0 {
+		<-s.done
+		return
+	}
+
+	ticker := time.NewTicker(health.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := p.Ping(context.Background(), health.timeout()); err != nil {
+				log.Printf("plug[%s]: health check failed: %v", p.DisplayName, err)
+				_ = s.kill()
+			}
+		}
+	}
+}
+
+// restart starts a fresh session, retrying with backoff. restarts counts
+// consecutive restarts and is shared across crashes.
+func (p *Plugin) restart(restarts *int) (*session, error) {
+	restart := p.cfg.Plugin.Restart
+	limit := restart.restartLimit()
+
+	for {
+		if limit < 0 {
+			return nil, fmt.Errorf("process exited and restarts are disabled")
+		}
+		if *restarts >= limit {
+			return nil, fmt.Errorf("process exited, restart limit of %d reached", limit)
+		}
+
+		time.Sleep(restart.backoff(*restarts))
+		*restarts++
+
+		p.mu.Lock()
+		stopped := p.stopped
+		p.mu.Unlock()
+		if stopped {
+			return nil, fmt.Errorf("plugin stopped")
+		}
+
+		log.Printf("plug[%s]: restarting (attempt %d/%d)", p.DisplayName, *restarts, limit)
+		s, err := p.start()
+		if err == nil {
+			return s, nil
+		}
+		log.Printf("plug[%s]: restart failed: %v", p.DisplayName, err)
+	}
+}
@@ -16,6 +16,11 @@ type Renderer struct {
 
 func Render(code string, data Map) *Renderer {
 	ctx := New()
+	for name, value := range data {
+		if err := ctx.Set(name, value); err != nil {
+			return &Renderer{err: err}
+		}
+	}
 	value, err := ctx.ExecString(code)
 	renderer := &Renderer{object: value, err: err}
 	return renderer
@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"io"
-	"os"
 	"time"
 
 	"github.com/cespare/xxhash/v2"
@@ -73,7 +72,7 @@ func (s *Server) setCache(path string, nodes []*astnode.Node) {
 }
 
 func (s *Server) hashFile(path string) (uint64, error) {
-	f, err := os.Open(path)
+	f, err := s.open(path)
 	if err != nil {
 		return 0, err
 	}
@@ -93,7 +92,7 @@ func (s *Server) getFile(path string) ([]*astnode.Node, bool, error) {
 		return nodes, true, nil
 	}
 
-	file, err := os.Open(path)
+	file, err := s.open(path)
 	if err != nil {
 		zap.L().Error("server.file.open", zap.String("path", path), zap.Error(err))
 		return nil, false, err
@@ -6,10 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/nubolang/nubo/events"
 	"github.com/nubolang/nubo/internal/ast/astnode"
 	"github.com/nubolang/nubo/internal/exception"
-	"github.com/nubolang/nubo/internal/runtime"
 	"github.com/nubolang/nubo/server/modules"
 	"go.uber.org/zap"
 )
@@ -56,9 +54,10 @@ func (s *Server) handleError(err error, w http.ResponseWriter, r *http.Request)
 	}
 
 	if s.isDir {
-		errNodes, _, e := s.getFile(filepath.Join(s.root, "error.nubo"))
+		errFile := filepath.Join(s.root, "error.nubo")
+		errNodes, _, e := s.getFile(errFile)
 		if e == nil {
-			if err := s.customError(errNodes, statusCode, err.Error(), w, r); err == nil {
+			if err := s.customError(errFile, errNodes, statusCode, err.Error(), w, r); err == nil {
 				return
 			} else {
 				zap.L().Warn("error.nubo failed to serve error", zap.Error(err))
@@ -69,8 +68,8 @@ func (s *Server) handleError(err error, w http.ResponseWriter, r *http.Request)
 	http.Error(w, err.Error(), statusCode)
 }
 
-func (s *Server) customError(nodes []*astnode.Node, status int, message string, w http.ResponseWriter, r *http.Request) error {
-	run := runtime.New(events.NewDefaultProvider())
+func (s *Server) customError(file string, nodes []*astnode.Node, status int, message string, w http.ResponseWriter, r *http.Request) error {
+	run := s.newRuntime()
 	zap.L().Debug("server.error.custom", zap.Int("status", status), zap.String("message", message))
 
 	// Bind the response object to the runtime
@@ -84,7 +83,7 @@ func (s *Server) customError(nodes []*astnode.Node, status int, message string,
 	run.ProvidePackage(ServerPrefix+"request", req)
 	run.ProvidePackage(ServerPrefix+"error", modules.NewError(status, message))
 
-	_, err = run.Interpret("error.nubo", nodes)
+	_, err = run.Interpret(file, nodes)
 	if err != nil {
 		return err
 	}
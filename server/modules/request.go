@@ -56,6 +56,8 @@ func NewRequest(r *http.Request) (language.Object, error) {
 		}
 	}
 
+	values := valuesFrom(r.Context())
+
 	ctx := r.Context()
 	proto.SetObject(ctx, "method", language.NewString(r.Method, nil))
 	proto.SetObject(ctx, "headers", headers)
@@ -77,6 +79,15 @@ func NewRequest(r *http.Request) (language.Object, error) {
 		return language.Nil, nil
 	}))
 
+	proto.SetObject(ctx, "value", native.NewTypedFunction(ctx, native.OneArg("name", language.TypeString), language.TypeAny, func(ctx native.FnCtx) (language.Object, error) {
+		name, _ := ctx.Get("name")
+		value, ok := values[name.String()]
+		if !ok {
+			return language.Nil, nil
+		}
+		return native.Bind(value)
+	}))
+
 	proto.SetObject(ctx, "query", native.NewTypedFunction(ctx, native.OneArg("name", language.TypeString), language.Nullable(language.TypeString), func(ctx native.FnCtx) (language.Object, error) {
 		name, _ := ctx.Get("name")
 		value := r.URL.Query().Get(name.String())
@@ -213,6 +224,27 @@ func NewRequest(r *http.Request) (language.Object, error) {
 	return inst, nil
 }
 
+type valuesKey struct{}
+
+// WithValues returns a copy of ctx that carries values set by Go code, e.g.
+// middleware, which pages read with request.value(name). Values of an outer
+// context are kept unless overwritten.
+func WithValues(ctx context.Context, values map[string]any) context.Context {
+	merged := make(map[string]any, len(values))
+	for k, v := range valuesFrom(ctx) {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, valuesKey{}, merged)
+}
+
+func valuesFrom(ctx context.Context) map[string]any {
+	values, _ := ctx.Value(valuesKey{}).(map[string]any)
+	return values
+}
+
 func newHeadersDict(r *http.Request) (*language.Dict, error) {
 	var (
 		keys   = make([]language.Object, 0, len(r.Header))
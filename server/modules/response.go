@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	stdio "github.com/nubolang/nubo/internal/packages/io"
 	"github.com/nubolang/nubo/language"
 	"github.com/nubolang/nubo/native"
 	"github.com/nubolang/nubo/native/n"
@@ -92,6 +93,7 @@ func (r *Response) setupInstance(inst *language.StructInstance) {
 		&language.BasicFnArg{TypeVal: language.TypeString, NameVal: "value"},
 	}, language.TypeVoid, r.fnHeader))
 	proto.SetObject(ctx, "flushbuf", native.NewTypedFunction(ctx, nil, language.TypeVoid, r.fnFlushbuf))
+	proto.SetObject(ctx, "stream", native.NewTypedFunction(ctx, nil, stdio.StreamStruct(nil).Type(), r.fnStream))
 	proto.SetObject(ctx, "json", native.NewTypedFunction(ctx, native.OneArg("data", language.TypeAny), language.TypeVoid, r.fnJSON))
 	proto.SetObject(ctx, "setCookie", native.NewTypedFunction(ctx, []language.FnArg{
 		&language.BasicFnArg{TypeVal: language.TypeString, NameVal: "name"},
@@ -123,10 +125,26 @@ func (r *Response) fnWrite(ctx native.FnCtx) (language.Object, error) {
 		return nil, err
 	}
 
+	// A []byte list is written as raw bytes, such as a generated archive.
+	if list, ok := obj.(*language.List); ok && list.ItemType != nil && list.ItemType.Base() == language.ObjectTypeByte {
+		for _, item := range list.Data {
+			if err := r.body.WriteByte(item.(*language.Byte).Data); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
 	_, err = r.body.WriteString(obj.String())
 	return nil, err
 }
 
+// fnStream returns a write-only stream into the response body, so writers
+// of other packages can produce the response.
+func (r *Response) fnStream(ctx native.FnCtx) (language.Object, error) {
+	return stdio.NewIOStream(bytes.NewReader(nil), r.body), nil
+}
+
 func (r *Response) fnHeader(ctx native.FnCtx) (language.Object, error) {
 	key, _ := ctx.Get("key")
 	value, _ := ctx.Get("value")
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nubolang/nubo/language"
+)
+
+// Result receives the outcome of a page once the Server served it, so Go
+// middleware around the Server can read it.
+type Result struct {
+	mu    sync.Mutex
+	value language.Object
+	err   error
+	done  bool
+}
+
+type resultKey struct{}
+
+// WithResult returns a copy of ctx that makes the Server store the outcome
+// of the request in res.
+func WithResult(ctx context.Context, res *Result) context.Context {
+	return context.WithValue(ctx, resultKey{}, res)
+}
+
+func resultFrom(ctx context.Context) *Result {
+	res, _ := ctx.Value(resultKey{}).(*Result)
+	return res
+}
+
+func (res *Result) set(value language.Object, err error) {
+	if res == nil {
+		return
+	}
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	res.value, res.err, res.done = value, err, true
+}
+
+// Done reports whether a page ran, successfully or not.
+func (res *Result) Done() bool {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	return res.done
+}
+
+// Object returns the value the page returned, or nil.
+func (res *Result) Object() language.Object {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	return res.value
+}
+
+// Value returns the value the page returned converted to Go.
+func (res *Result) Value() (any, error) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	if res.err != nil || res.value == nil {
+		return nil, res.err
+	}
+	return language.ToValue(res.value)
+}
+
+// Err returns the error the page failed with.
+func (res *Result) Err() error {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	return res.err
+}
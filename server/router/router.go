@@ -1,6 +1,7 @@
 package router
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,8 +9,11 @@ import (
 
 // Router represents a web router.
 type Router struct {
-	// root is the root directory of the router.
+	// root is the root directory of the router, empty for routers on an
+	// fs.FS.
 	root string
+	// fsys is the filesystem the routes are read from.
+	fsys fs.FS
 
 	// entries is a map of entries in the router.
 	entries map[string]Entry
@@ -17,28 +21,40 @@ type Router struct {
 
 // New creates a new Router instance.
 func New(root string) *Router {
+	root = filepath.Clean(root)
 	return &Router{
-		root:    filepath.Clean(root),
+		root:    root,
+		fsys:    os.DirFS(root),
+		entries: make(map[string]Entry),
+	}
+}
+
+// NewFS creates a Router serving the files of fsys. File paths of its
+// entries are slash separated paths in fsys.
+func NewFS(fsys fs.FS) *Router {
+	return &Router{
+		fsys:    fsys,
 		entries: make(map[string]Entry),
 	}
 }
 
 // Reload reloads the router's entries.
 func (r *Router) Reload() error {
-	r.entries = make(map[string]Entry)
+	entries := make(map[string]Entry)
 
-	return filepath.Walk(r.root, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+	err := fs.WalkDir(r.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
 			return err
 		}
 
-		rel, err := filepath.Rel(r.root, path)
-		if err != nil {
-			return err
+		rel := filepath.FromSlash(name)
+		path := name
+		if r.root != "" {
+			path = filepath.Join(r.root, rel)
 		}
 
 		var routePath string
-		exec := isExecutable(info.Name())
+		exec := isExecutable(d.Name())
 
 		if exec {
 			routePath = "/" + strings.TrimSuffix(filepath.ToSlash(rel), filepath.Ext(rel))
@@ -75,7 +91,7 @@ func (r *Router) Reload() error {
 		}
 
 		// Store the Entry, which contains the parts
-		r.entries[routePath] = Entry{
+		entries[routePath] = Entry{
 			FilePath:     path,
 			Path:         rel,
 			IsExecutable: exec,
@@ -84,6 +100,12 @@ func (r *Router) Reload() error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	r.entries = entries
+	return nil
 }
 
 func (r *Router) Match(url string) (*Route, bool) {
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -25,6 +26,11 @@ const ServerPrefix = "@server/"
 type Server struct {
 	root  string
 	isDir bool
+	// fsys, when set, holds the app instead of root.
+	fsys fs.FS
+
+	options runtime.Options
+	events  func() events.Provider
 
 	colorMode bool
 	router    *router.Router
@@ -54,6 +60,8 @@ func New(root string) (*Server, error) {
 	srv := &Server{
 		root:      root,
 		isDir:     isDir,
+		options:   runtime.DefaultOptions(),
+		events:    configEvents,
 		colorMode: color.NoColor,
 		router:    r,
 		cache:     make(map[string]*NodeCache),
@@ -63,6 +71,78 @@ func New(root string) (*Server, error) {
 	return srv, nil
 }
 
+// Options configures a Server created by NewFS.
+type Options struct {
+	// Runtime restricts the scripts run for each request.
+	Runtime runtime.Options
+	// Events returns the event provider of a request's runtime. Nil uses the
+	// events setting of the config.
+	Events func() events.Provider
+}
+
+// NewFS creates a Server for the route tree in fsys, e.g. an embed.FS, so it
+// can be mounted in an existing net/http server.
+func NewFS(fsys fs.FS, opts Options) (*Server, error) {
+	config.Verify()
+
+	r := router.NewFS(fsys)
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	if opts.Events == nil {
+		opts.Events = configEvents
+	}
+
+	srv := &Server{
+		isDir:     true,
+		fsys:      fsys,
+		options:   opts.Runtime,
+		events:    opts.Events,
+		colorMode: color.NoColor,
+		router:    r,
+		cache:     make(map[string]*NodeCache),
+		sem:       make(chan struct{}, config.Current.Runtime.Server.MaxConcurrency),
+	}
+	zap.L().Info("server.new.fs")
+	return srv, nil
+}
+
+// configEvents returns a new event provider if events are enabled in the
+// config.
+func configEvents() events.Provider {
+	if config.Current.Runtime.Events.Enabled {
+		return events.NewDefaultProvider()
+	}
+	return nil
+}
+
+// newRuntime returns the runtime for a single request.
+func (s *Server) newRuntime() *runtime.Runtime {
+	run := runtime.New(s.events(), s.options)
+	if s.fsys != nil {
+		run.UseFS(s.fsys)
+	}
+	return run
+}
+
+// serveFile serves a file that is not executed.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, path string) {
+	if s.fsys != nil {
+		http.ServeFileFS(w, r, s.fsys, path)
+		return
+	}
+	http.ServeFile(w, r, path)
+}
+
+// open opens a file of the app.
+func (s *Server) open(path string) (fs.File, error) {
+	if s.fsys != nil {
+		return s.fsys.Open(path)
+	}
+	return os.Open(path)
+}
+
 // Serve starts the server
 func (s *Server) Serve(addr string) error {
 	s.sem <- struct{}{}        // acquire
@@ -143,7 +223,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if !route.IsExecutable {
-			http.ServeFile(w, r, route.FilePath)
+			s.serveFile(w, r, route.FilePath)
 			return
 		}
 
@@ -155,9 +235,12 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		file = s.root
 	}
 
+	result := resultFrom(r.Context())
+
 	nodes, c, err := s.getFile(file)
 	if err != nil {
 		zap.L().Error("server.request.parse", zap.String("file", file), zap.Error(err))
+		result.set(nil, err)
 		s.handleError(err, w, r)
 		return
 	}
@@ -165,13 +248,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	cached = c
 	zap.L().Debug("server.request.nodes", zap.String("file", file), zap.Bool("cached", cached))
 
-	var eventProvider events.Provider
-	if config.Current.Runtime.Events.Enabled {
-		eventProvider = events.NewDefaultProvider()
-	}
-
-	run := runtime.New(eventProvider)
-	zap.L().Debug("server.runtime.created", zap.Bool("events", eventProvider != nil))
+	run := s.newRuntime()
+	zap.L().Debug("server.runtime.created")
 
 	// Bind the response object to the runtime
 	res := modules.NewResponse(w, r)
@@ -179,18 +257,21 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	req, err := modules.NewRequest(r)
 	if err != nil {
 		zap.L().Error("server.request.module", zap.String("module", "request"), zap.Error(err))
+		result.set(nil, err)
 		s.handleError(err, w, r)
 		return
 	}
 
 	run.ProvidePackage(ServerPrefix+"request", req)
 
-	_, err = run.Interpret(file, nodes)
+	ret, err := run.Interpret(file, nodes)
 	if err != nil {
 		zap.L().Error("server.runtime.interpretError", zap.String("file", file), zap.Error(err))
+		result.set(nil, err)
 		s.handleError(err, w, r)
 		return
 	}
+	result.set(ret, nil)
 
 	// Sync and output the generated data
 	res.Sync()